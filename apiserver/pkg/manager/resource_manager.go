@@ -207,6 +207,26 @@ func (r *ResourceManager) DeleteCluster(ctx context.Context, clusterName string,
 	return nil
 }
 
+// WakeUpCluster asks the KubeRay operator to wake up a cluster scaled to zero by its idle policy.
+func (r *ResourceManager) WakeUpCluster(ctx context.Context, clusterName string, namespace string) (*v1alpha1.RayCluster, error) {
+	client := r.getRayClusterClient(namespace)
+	cluster, err := getClusterByName(ctx, client, clusterName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[util.RayClusterWakeUpAnnotationKey] = r.clientManager.Time().Now().String()
+	updatedCluster, err := client.Update(ctx, cluster, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to wake up cluster %v.", clusterName)
+	}
+
+	return updatedCluster, nil
+}
+
 func (r *ResourceManager) CreateJob(ctx context.Context, apiJob *api.RayJob) (*v1alpha1.RayJob, error) {
 	computeTemplateMap := make(map[string]*api.ComputeTemplate)
 	var err error
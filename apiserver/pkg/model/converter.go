@@ -141,6 +141,7 @@ func PopulateRayClusterSpec(spec v1alpha1.RayClusterSpec) *api.ClusterSpec {
 	clusterSpec := &api.ClusterSpec{}
 	clusterSpec.HeadGroupSpec = PopulateHeadNodeSpec(spec.HeadGroupSpec)
 	clusterSpec.WorkerGroupSpec = PopulateWorkerNodeSpec(spec.WorkerGroupSpecs)
+	clusterSpec.EnableManagedRedisPassword = spec.ManagedRedisPassword != nil
 	return clusterSpec
 }
 
@@ -424,9 +425,24 @@ func PopulateServeDeploymentStatus(serveDeploymentStatuses map[string]v1alpha1.S
 	deploymentStatuses := make([]*api.ServeDeploymentStatus, 0)
 	for deploymentName, deploymentStatus := range serveDeploymentStatuses {
 		ds := &api.ServeDeploymentStatus{
-			DeploymentName: deploymentName,
-			Status:         deploymentStatus.Status,
-			Message:        deploymentStatus.Message,
+			DeploymentName:    deploymentName,
+			Status:            deploymentStatus.Status,
+			Message:           deploymentStatus.Message,
+			AutoscalingConfig: deploymentStatus.AutoscalingConfig,
+		}
+		if deploymentStatus.TargetReplicas != nil {
+			ds.TargetReplicas = *deploymentStatus.TargetReplicas
+		}
+		if deploymentStatus.RunningReplicas != nil {
+			ds.RunningReplicas = *deploymentStatus.RunningReplicas
+		}
+		for _, scaleEvent := range deploymentStatus.ScaleEvents {
+			ds.ScaleEvents = append(ds.ScaleEvents, &api.ServeDeploymentScaleEvent{
+				Time:         &timestamp.Timestamp{Seconds: scaleEvent.Time.Unix()},
+				FromReplicas: scaleEvent.FromReplicas,
+				ToReplicas:   scaleEvent.ToReplicas,
+				Trigger:      scaleEvent.Trigger,
+			})
 		}
 		deploymentStatuses = append(deploymentStatuses, ds)
 	}
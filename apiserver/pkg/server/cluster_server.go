@@ -136,6 +136,29 @@ func (s *ClusterServer) DeleteCluster(ctx context.Context, request *api.DeleteCl
 	return &emptypb.Empty{}, nil
 }
 
+// Wakes up a Cluster scaled to zero by its idle policy. The KubeRay operator restores
+// the replicas of the worker groups and restarts the head Pod if it was stopped.
+func (s *ClusterServer) WakeUpCluster(ctx context.Context, request *api.WakeUpClusterRequest) (*api.Cluster, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidInputError("Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+	}
+
+	cluster, err := s.resourceManager.WakeUpCluster(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Wake up cluster failed.")
+	}
+	events, err := s.resourceManager.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	if err != nil {
+		klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", cluster.Namespace, cluster.Name, err)
+	}
+
+	return model.FromCrdToApiCluster(cluster, events), nil
+}
+
 func ValidateCreateClusterRequest(request *api.CreateClusterRequest) error {
 	if request.Namespace == "" {
 		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
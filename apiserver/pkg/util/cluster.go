@@ -116,9 +116,33 @@ func buildRayClusterSpec(imageVersion string, envs map[string]string, clusterSpe
 		rayClusterSpec.WorkerGroupSpecs = append(rayClusterSpec.WorkerGroupSpecs, workerNodeSpec)
 	}
 
+	// Let the operator generate the Redis password if the request asks for it.
+	if clusterSpec.EnableManagedRedisPassword {
+		if hasRedisPassword(envs, clusterSpec) {
+			return nil, fmt.Errorf("enable_managed_redis_password cannot be combined with redis-password in rayStartParams or REDIS_PASSWORD in the environment")
+		}
+		rayClusterSpec.ManagedRedisPassword = &rayalphaapi.ManagedRedisPasswordOptions{}
+	}
+
 	return rayClusterSpec, nil
 }
 
+// hasRedisPassword returns whether the request sets the Redis password in the environment or in the rayStartParams of a group.
+func hasRedisPassword(envs map[string]string, clusterSpec *api.ClusterSpec) bool {
+	if _, ok := envs["REDIS_PASSWORD"]; ok {
+		return true
+	}
+	if _, ok := clusterSpec.HeadGroupSpec.RayStartParams["redis-password"]; ok {
+		return true
+	}
+	for _, spec := range clusterSpec.WorkerGroupSpec {
+		if _, ok := spec.RayStartParams["redis-password"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // Annotations common to both head and worker nodes
 func buildNodeGroupAnnotations(computeTemplate *api.ComputeTemplate, image string) map[string]string {
 	annotations := map[string]string{}
@@ -6,6 +6,7 @@ import (
 
 	api "github.com/ray-project/kuberay/proto/go_client"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -306,6 +307,23 @@ func TestBuildRayCluster(t *testing.T) {
 	if !(*cluster.Spec.HeadGroupSpec.EnableIngress) {
 		t.Errorf("failed to propagate create Ingress")
 	}
+	if cluster.Spec.ManagedRedisPassword != nil {
+		t.Errorf("the managed Redis password is enabled without being requested")
+	}
+}
+
+func TestBuildRayClusterWithManagedRedisPassword(t *testing.T) {
+	apiCluster := proto.Clone(&rayCluster).(*api.Cluster)
+	apiCluster.ClusterSpec.EnableManagedRedisPassword = true
+	cluster, err := NewRayCluster(apiCluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	if cluster.Spec.ManagedRedisPassword == nil {
+		t.Errorf("failed to enable the managed Redis password")
+	}
+
+	apiCluster.ClusterSpec.HeadGroupSpec.RayStartParams["redis-password"] = "LetMeInRay"
+	_, err = NewRayCluster(apiCluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.NotNil(t, err)
 }
 
 func TestBuilWorkerPodTemplate(t *testing.T) {
@@ -21,6 +21,8 @@ const (
 	// Role level
 	RayClusterComputeTemplateAnnotationKey = "ray.io/compute-template"
 	RayClusterImageAnnotationKey           = "ray.io/compute-image"
+	// RayClusterWakeUpAnnotationKey asks the KubeRay operator to wake up a RayCluster scaled to zero by its idle policy.
+	RayClusterWakeUpAnnotationKey = "ray.io/wake-up"
 
 	RayClusterDefaultImageRepository = "rayproject/ray"
 )
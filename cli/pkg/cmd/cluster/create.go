@@ -106,7 +106,7 @@ func createCluster(opts CreateOptions) error {
 	headStartParams["port"] = "6379"
 	headStartParams["dashboard-host"] = "0.0.0.0"
 	headStartParams["node-ip-address"] = "$MY_POD_IP"
-	headStartParams["redis-password"] = "LetMeInRay"
+	// The Redis password is generated by the operator and never leaves the cluster.
 
 	headSpec := &go_client.HeadGroupSpec{
 		ComputeTemplate: opts.headComputeTemplate,
@@ -117,7 +117,6 @@ func createCluster(opts CreateOptions) error {
 
 	workerStartParams := make(map[string]string)
 	workerStartParams["node-ip-address"] = "$MY_POD_IP"
-	workerStartParams["redis-password"] = "LetMeInRay"
 
 	var workerGroupSpecs []*go_client.WorkerGroupSpec
 	spec := &go_client.WorkerGroupSpec{
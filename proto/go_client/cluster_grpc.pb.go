@@ -31,6 +31,9 @@ type ClusterServiceClient interface {
 	// avoid unexpected behaviors, delete an cluster's runs and jobs before
 	// deleting the cluster.
 	DeleteCluster(ctx context.Context, in *DeleteClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Wakes up a cluster that was scaled to zero by its idle policy. The worker
+	// groups get their replicas back and the head is restarted if it was stopped.
+	WakeUpCluster(ctx context.Context, in *WakeUpClusterRequest, opts ...grpc.CallOption) (*Cluster, error)
 }
 
 type clusterServiceClient struct {
@@ -86,6 +89,15 @@ func (c *clusterServiceClient) DeleteCluster(ctx context.Context, in *DeleteClus
 	return out, nil
 }
 
+func (c *clusterServiceClient) WakeUpCluster(ctx context.Context, in *WakeUpClusterRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/WakeUpCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClusterServiceServer is the server API for ClusterService service.
 // All implementations must embed UnimplementedClusterServiceServer
 // for forward compatibility
@@ -102,6 +114,9 @@ type ClusterServiceServer interface {
 	// avoid unexpected behaviors, delete an cluster's runs and jobs before
 	// deleting the cluster.
 	DeleteCluster(context.Context, *DeleteClusterRequest) (*emptypb.Empty, error)
+	// Wakes up a cluster that was scaled to zero by its idle policy. The worker
+	// groups get their replicas back and the head is restarted if it was stopped.
+	WakeUpCluster(context.Context, *WakeUpClusterRequest) (*Cluster, error)
 	mustEmbedUnimplementedClusterServiceServer()
 }
 
@@ -124,6 +139,9 @@ func (UnimplementedClusterServiceServer) ListAllClusters(context.Context, *ListA
 func (UnimplementedClusterServiceServer) DeleteCluster(context.Context, *DeleteClusterRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCluster not implemented")
 }
+func (UnimplementedClusterServiceServer) WakeUpCluster(context.Context, *WakeUpClusterRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WakeUpCluster not implemented")
+}
 func (UnimplementedClusterServiceServer) mustEmbedUnimplementedClusterServiceServer() {}
 
 // UnsafeClusterServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -227,6 +245,24 @@ func _ClusterService_DeleteCluster_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClusterService_WakeUpCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WakeUpClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).WakeUpCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/WakeUpCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).WakeUpCluster(ctx, req.(*WakeUpClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClusterService_ServiceDesc is the grpc.ServiceDesc for ClusterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -254,6 +290,10 @@ var ClusterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCluster",
 			Handler:    _ClusterService_DeleteCluster_Handler,
 		},
+		{
+			MethodName: "WakeUpCluster",
+			Handler:    _ClusterService_WakeUpCluster_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cluster.proto",
@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -23,6 +25,228 @@ type RayClusterSpec struct {
 	// AutoscalerOptions specifies optional configuration for the Ray autoscaler.
 	AutoscalerOptions      *AutoscalerOptions `json:"autoscalerOptions,omitempty"`
 	HeadServiceAnnotations map[string]string  `json:"headServiceAnnotations,omitempty"`
+	// TLS enables mutual TLS authentication for the traffic between the Ray nodes of the cluster.
+	TLS *TLSOptions `json:"tls,omitempty"`
+	// DashboardAuth puts an authenticating reverse proxy in front of the Ray dashboard and the dashboard agent of the head Pod.
+	// KubeRay also creates a NetworkPolicy that closes the dashboard and dashboard agent ports to the Pods outside of the cluster.
+	DashboardAuth *DashboardAuthOptions `json:"dashboardAuth,omitempty"`
+	// NetworkIsolation makes KubeRay create a NetworkPolicy that only allows the Ray Pods of the cluster and the given peers
+	// to connect to the Ray Pods.
+	NetworkIsolation *NetworkIsolationOptions `json:"networkIsolation,omitempty"`
+	// PodDisruptionBudget makes KubeRay create PodDisruptionBudgets for the head Pod and each worker group.
+	PodDisruptionBudget *PodDisruptionBudgetOptions `json:"podDisruptionBudget,omitempty"`
+	// GcsFaultToleranceOptions enables GCS fault tolerance, which stores the GCS metadata in an external Redis so that the
+	// RayCluster survives the loss of its head Pod. It replaces the deprecated ray.io/ft-enabled and
+	// ray.io/external-storage-namespace annotations.
+	GcsFaultToleranceOptions *GcsFaultToleranceOptions `json:"gcsFaultToleranceOptions,omitempty"`
+	// ManagedRedisPassword makes KubeRay generate the Redis password of the RayCluster and store it in a Secret owned by
+	// the RayCluster, instead of setting redis-password in rayStartParams.
+	ManagedRedisPassword *ManagedRedisPasswordOptions `json:"managedRedisPassword,omitempty"`
+	// Monitoring makes KubeRay create a Prometheus Operator monitor scraping the Ray metrics of the cluster.
+	Monitoring *MonitoringOptions `json:"monitoring,omitempty"`
+	// EnableWorkerHeadlessService makes KubeRay create a headless service selecting the worker Pods, so that each worker
+	// Pod is resolvable as <pod-name>.<cluster-name>-workers.<namespace>.svc.
+	EnableWorkerHeadlessService *bool `json:"enableWorkerHeadlessService,omitempty"`
+	// EnableRayWorkerGroups makes KubeRay create a RayWorkerGroup for each worker group. A RayWorkerGroup exposes the
+	// replicas of its worker group through the scale subresource, for the HorizontalPodAutoscaler, KEDA, and `kubectl scale`.
+	EnableRayWorkerGroups *bool `json:"enableRayWorkerGroups,omitempty"`
+	// BatchScheduling submits the Pods of the RayCluster to a batch scheduler. It requires the operator to run with
+	// --enable-batch-scheduler, and replaces the ray.io/scheduler-name and ray.io/priority-class-name labels.
+	BatchScheduling *BatchSchedulingOptions `json:"batchScheduling,omitempty"`
+	// IdlePolicy makes KubeRay scale all the worker groups to zero, and optionally stop the head Pod, once the RayCluster
+	// has been idle for a while. Setting the ray.io/wake-up annotation restores the RayCluster.
+	IdlePolicy *IdlePolicy `json:"idlePolicy,omitempty"`
+	// Preemption sets the priority of the RayCluster, or of the RayJob it was created for, when the operator runs with
+	// --enable-preemption.
+	Preemption *PreemptionOptions `json:"preemption,omitempty"`
+}
+
+// PreemptionOptions configures the preemption between the RayClusters of a namespace, or of the namespaces grouped in a
+// quota group by the operator configuration. When Pods of a RayCluster stay unschedulable for longer than the preemption
+// threshold of the operator, KubeRay suspends the RayJobs or scales down the workers of the preemptible RayClusters with
+// a lower priority in the same scope, the lowest priority first, until the resources they release cover the requests of
+// the unschedulable Pods.
+type PreemptionOptions struct {
+	// Priority of the RayCluster. A RayCluster only preempts RayClusters with a strictly lower priority. Defaults to 0.
+	Priority int32 `json:"priority,omitempty"`
+	// Preemptible allows KubeRay to preempt the RayCluster in favor of RayClusters with a higher priority.
+	Preemptible bool `json:"preemptible,omitempty"`
+}
+
+// IdlePolicy configures when KubeRay hibernates an idle RayCluster. The RayCluster is idle when, according to the
+// dashboard of the head Pod, no job is pending or running, which includes the drivers of connected Ray clients, no Ray
+// resource is in use, and the dashboard serves no request apart from those of KubeRay.
+type IdlePolicy struct {
+	// IdleTimeoutSeconds is how long the RayCluster must stay idle before it is hibernated. Defaults to 1800 (30 minutes).
+	// +kubebuilder:validation:Minimum=60
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// StopHead also deletes the head Pod when the RayCluster is hibernated. The head Pod is recreated on wake-up; the
+	// state of the Ray cluster is lost unless GCS fault tolerance is enabled.
+	StopHead bool `json:"stopHead,omitempty"`
+}
+
+// BatchSchedulingOptions specifies the batch scheduler of the Pods of a RayCluster.
+type BatchSchedulingOptions struct {
+	// SchedulerName is the name of the batch scheduler plugin, such as volcano, yunikorn or scheduler-plugins.
+	SchedulerName string `json:"schedulerName"`
+	// Queue is the queue of the batch scheduler the Pods are submitted to.
+	Queue string `json:"queue,omitempty"`
+	// PriorityClassName is the PriorityClass of the Pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// MinAvailable is the number of Pods, including the head Pod, that must be scheduled together. Defaults to the
+	// desired replicas, or to the minReplicas when autoscaling is enabled.
+	// +kubebuilder:validation:Minimum=1
+	MinAvailable *int32 `json:"minAvailable,omitempty"`
+	// ScheduleTimeoutSeconds is how long the scheduler waits for all the Pods that must be scheduled together before
+	// giving up on the attempt. Defaults to the timeout of the scheduler.
+	// +kubebuilder:validation:Minimum=1
+	ScheduleTimeoutSeconds *int32 `json:"scheduleTimeoutSeconds,omitempty"`
+}
+
+// MonitorType is the kind of Prometheus Operator monitor KubeRay creates.
+// +kubebuilder:validation:Enum=PodMonitor;ServiceMonitor
+type MonitorType string
+
+const (
+	// MonitorTypePodMonitor scrapes the head and worker Pods.
+	MonitorTypePodMonitor MonitorType = "PodMonitor"
+	// MonitorTypeServiceMonitor scrapes the head service, which only reaches the head Pod.
+	MonitorTypeServiceMonitor MonitorType = "ServiceMonitor"
+)
+
+// MonitoringOptions specifies the monitor KubeRay creates for the RayCluster. The monitor is only created if the Prometheus
+// Operator CRDs are installed when the operator starts.
+type MonitoringOptions struct {
+	// Type is the kind of monitor. Defaults to PodMonitor.
+	// +kubebuilder:default:=PodMonitor
+	Type MonitorType `json:"type,omitempty"`
+	// Labels are added to the monitor, for example to match the monitor selector of a Prometheus instance.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Interval is the scrape interval, such as 30s. Defaults to the scrape interval of the Prometheus instance.
+	// +kubebuilder:validation:Pattern:="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	Interval string `json:"interval,omitempty"`
+}
+
+// ManagedRedisPasswordOptions specifies the Redis password that KubeRay generates for the RayCluster. The password is passed
+// to the Ray containers and the Redis cleanup Job through the REDIS_PASSWORD environment variable, so it never appears in
+// the RayCluster or in the command line of the Pods.
+type ManagedRedisPasswordOptions struct {
+	// RotationID is an arbitrary value. Changing it makes KubeRay generate a new password in a new Secret and recreate
+	// the Pods that use the previous password, starting with the head Pod. With an external Redis, the Pods are only
+	// recreated once the ray.io/redis-password-rollout annotation of the RayCluster is set to the new rotation ID.
+	RotationID string `json:"rotationID,omitempty"`
+}
+
+// RedisPasswordStatus reports the Redis password managed by KubeRay that the Pods of the RayCluster use.
+type RedisPasswordStatus struct {
+	// RotationID is the rotation ID of the password of the Pods. It lags behind spec.managedRedisPassword.rotationID
+	// while a new password waits for its rollout.
+	RotationID string `json:"rotationID,omitempty"`
+}
+
+// RedisCleanupPolicy is what KubeRay does with the GCS metadata stored in Redis when the RayCluster is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type RedisCleanupPolicy string
+
+const (
+	// RedisCleanupPolicyDelete runs a Job deleting the external storage namespace from Redis before the RayCluster is removed.
+	RedisCleanupPolicyDelete RedisCleanupPolicy = "Delete"
+	// RedisCleanupPolicyRetain keeps the external storage namespace in Redis, for example to reuse it in a new RayCluster.
+	RedisCleanupPolicyRetain RedisCleanupPolicy = "Retain"
+)
+
+// GcsFaultToleranceOptions specifies the external Redis storing the GCS metadata of the RayCluster.
+type GcsFaultToleranceOptions struct {
+	// RedisAddress is the address of the Redis server as host:port. Use the rediss:// prefix to connect with TLS.
+	RedisAddress string `json:"redisAddress"`
+	// RedisPassword selects the key of a Secret in the RayCluster's namespace holding the password of the Redis server.
+	RedisPassword *v1.SecretKeySelector `json:"redisPassword,omitempty"`
+	// ExternalStorageNamespace isolates the GCS metadata of the RayCluster from the other RayClusters sharing the Redis server.
+	// Defaults to the UID of the RayCluster.
+	ExternalStorageNamespace string `json:"externalStorageNamespace,omitempty"`
+	// RedisCleanupPolicy is what KubeRay does with the external storage namespace when the RayCluster is deleted.
+	// +kubebuilder:default:=Delete
+	RedisCleanupPolicy RedisCleanupPolicy `json:"redisCleanupPolicy,omitempty"`
+}
+
+// PodDisruptionBudgetOptions specifies the PodDisruptionBudgets that protect the Pods of a RayCluster from voluntary disruptions
+// such as node drains. The head Pod is never evicted voluntarily. The number of worker Pods of a group that can be evicted at
+// the same time is limited by the maxUnavailable of the group, which defaults to WorkerMaxUnavailable.
+type PodDisruptionBudgetOptions struct {
+	// WorkerMaxUnavailable is the default maxUnavailable of the worker groups, as a number or a percentage of the replicas
+	// rounded up. Defaults to 1.
+	WorkerMaxUnavailable *intstr.IntOrString `json:"workerMaxUnavailable,omitempty"`
+}
+
+// TLSOptions specifies how KubeRay provisions the certificates used for TLS between the Ray nodes.
+// Each Pod gets its own node certificate, issued by KubeRay once the Pod has an IP and stored in a Secret that only holds
+// the CA bundle besides the node certificate. The CA key never leaves the operator.
+type TLSOptions struct {
+	// CASecretName is the name of a Secret in the RayCluster's namespace holding the CA certificate (tls.crt),
+	// its private key (tls.key), and the trusted CA bundle (ca.crt), such as a Secret managed by a cert-manager issuer.
+	// KubeRay neither modifies nor rotates this Secret. If empty, KubeRay generates a CA for the RayCluster and rotates it before it expires.
+	CASecretName string `json:"caSecretName,omitempty"`
+	// CADuration is the validity of the CA generated by KubeRay. Defaults to 8760h (one year).
+	CADuration *metav1.Duration `json:"caDuration,omitempty"`
+	// RenewBefore is how long before the CA expires KubeRay generates a new one. Defaults to 720h (30 days).
+	// The node certificates issued by the previous CA are renewed after the rotation.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// NodeCertDuration is the validity of the node certificates KubeRay issues to the Ray Pods. Defaults to 720h (30 days).
+	// KubeRay renews a node certificate in its Secret once two thirds of its lifetime have elapsed.
+	NodeCertDuration *metav1.Duration `json:"nodeCertDuration,omitempty"`
+	// RecreatePodsOnRenewal makes KubeRay recreate the Pods whose node certificate needs a renewal, one at a time and the
+	// head last, instead of renewing the certificate in their Secret. Recreating the head Pod restarts the Ray cluster
+	// unless GCS fault tolerance is enabled.
+	RecreatePodsOnRenewal bool `json:"recreatePodsOnRenewal,omitempty"`
+}
+
+// DashboardAuthMode is the way the dashboard proxy authenticates requests.
+// +kubebuilder:validation:Enum=Token;OIDC
+type DashboardAuthMode string
+
+const (
+	// DashboardAuthModeToken only accepts the bearer token that KubeRay generates for the RayCluster.
+	DashboardAuthModeToken DashboardAuthMode = "Token"
+	// DashboardAuthModeOIDC additionally accepts OIDC ID tokens issued by the configured issuer.
+	DashboardAuthModeOIDC DashboardAuthMode = "OIDC"
+)
+
+// DashboardAuthOptions specifies the authenticating proxy that KubeRay injects into the head Pod.
+// The head service forwards the dashboard and dashboard agent ports to the proxy, and the dashboard itself only listens on localhost.
+// KubeRay stores a generated bearer token in the Secret <cluster name>-dashboard-auth, which is always accepted by the proxy.
+type DashboardAuthOptions struct {
+	// Mode is either Token or OIDC. Defaults to Token.
+	// +kubebuilder:default:=Token
+	Mode DashboardAuthMode `json:"mode,omitempty"`
+	// OIDC configures the verification of OIDC ID tokens. Required if mode is OIDC.
+	OIDC *DashboardOIDCOptions `json:"oidc,omitempty"`
+	// Image optionally overrides the image of the proxy container. Defaults to the KubeRay operator image.
+	Image *string `json:"image,omitempty"`
+	// Resources specifies the resource requests and limits of the proxy container.
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// DashboardOIDCOptions specifies the OIDC provider trusted by the dashboard proxy.
+type DashboardOIDCOptions struct {
+	// IssuerURL is the URL of the OIDC issuer. Its discovery document must be served at <issuerURL>/.well-known/openid-configuration.
+	IssuerURL string `json:"issuerURL"`
+	// Audience is the value that the aud claim of the ID tokens must contain, usually the OIDC client ID.
+	Audience string `json:"audience"`
+}
+
+// NetworkIsolationOptions specifies which peers, besides the Ray Pods of the cluster, may connect to the ports of the Ray Pods.
+// The Pods of the RayCluster can always reach each other on every port. The namespace of the KubeRay operator can always reach the
+// dashboard, dashboard agent, and serve ports, and so can the submitter Pods of the RayJob that created the RayCluster.
+// A port that has no peers is only reachable from the Pods of the RayCluster.
+type NetworkIsolationOptions struct {
+	// DashboardPeers may connect to the dashboard and dashboard agent ports.
+	DashboardPeers []networkingv1.NetworkPolicyPeer `json:"dashboardPeers,omitempty"`
+	// ClientPeers may connect to the Ray client port.
+	ClientPeers []networkingv1.NetworkPolicyPeer `json:"clientPeers,omitempty"`
+	// ServePeers may connect to the Serve HTTP and gRPC ports.
+	ServePeers []networkingv1.NetworkPolicyPeer `json:"servePeers,omitempty"`
+	// MetricsPeers may connect to the metrics port, e.g. to let Prometheus scrape the Ray Pods.
+	MetricsPeers []networkingv1.NetworkPolicyPeer `json:"metricsPeers,omitempty"`
 }
 
 // HeadGroupSpec are the spec for the head pod
@@ -58,6 +282,19 @@ type WorkerGroupSpec struct {
 	Template v1.PodTemplateSpec `json:"template"`
 	// ScaleStrategy defines which pods to remove
 	ScaleStrategy ScaleStrategy `json:"scaleStrategy,omitempty"`
+	// MaxUnavailable is the maximum number of Pods of the group that can be evicted at the same time, as a number or a
+	// percentage of the replicas rounded up. Only used if spec.podDisruptionBudget is set.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// IdleTimeoutSeconds overrides spec.autoscalerOptions.idleTimeoutSeconds for the worker Pods of this group. It is
+	// read by the KubeRay operator, and requires spec.autoscalerOptions.type "Operator".
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// ScaleDownProtection prevents the autoscaler from removing the idle worker Pods of this group. The group still
+	// scales down when its replicas are lowered by hand. It is read by the KubeRay operator, and requires
+	// spec.autoscalerOptions.type "Operator".
+	// +optional
+	ScaleDownProtection *bool `json:"scaleDownProtection,omitempty"`
 }
 
 // ScaleStrategy to remove workers
@@ -86,19 +323,42 @@ type AutoscalerOptions struct {
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
 	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
 	// IdleTimeoutSeconds is the number of seconds to wait before scaling down a worker pod which is not using Ray resources.
-	// Defaults to 60 (one minute). It is not read by the KubeRay operator but by the Ray autoscaler.
+	// Defaults to 60 (one minute). It is read by the Ray autoscaler, or by the KubeRay operator if Type is "Operator".
 	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
 	// UpscalingMode is "Conservative", "Default", or "Aggressive."
 	// Conservative: Upscaling is rate-limited; the number of pending worker pods is at most the size of the Ray cluster.
 	// Default: Upscaling is not rate-limited.
 	// Aggressive: An alias for Default; upscaling is not rate-limited.
-	// It is not read by the KubeRay operator but by the Ray autoscaler.
+	// It is read by the Ray autoscaler, or by the KubeRay operator if Type is "Operator".
 	UpscalingMode *UpscalingMode `json:"upscalingMode,omitempty"`
+	// UpscalingSpeed caps the number of pending worker Pods at max(5, upscalingSpeed * number of Ray nodes). It takes
+	// precedence over UpscalingMode, which corresponds to an upscaling speed of 1 for "Conservative" and 1000 otherwise.
+	// It is read by the KubeRay operator, and requires Type "Operator".
+	// +optional
+	UpscalingSpeed *float64 `json:"upscalingSpeed,omitempty"`
+	// Type selects where the autoscaling loop runs. "Sidecar", the default, injects the Ray autoscaler container into
+	// the head Pod. "Deployment" runs the Ray autoscaler in a Deployment owned by the RayCluster, so that the autoscaler
+	// can crash or be upgraded without restarting the head Pod. "Operator" runs the autoscaling loop in the KubeRay
+	// operator, which reads the resource demand from the Ray dashboard of the head Pod; no autoscaler container,
+	// ServiceAccount, Role, or RoleBinding is created.
+	// The Resources, Image, ImagePullPolicy, Env, EnvFrom, and SecurityContext fields apply to "Sidecar" and
+	// "Deployment", VolumeMounts only to "Sidecar".
+	// +optional
+	Type *AutoscalerType `json:"type,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Default;Aggressive;Conservative
 type UpscalingMode string
 
+// +kubebuilder:validation:Enum=Sidecar;Deployment;Operator
+type AutoscalerType string
+
+const (
+	SidecarAutoscaler    AutoscalerType = "Sidecar"
+	DeploymentAutoscaler AutoscalerType = "Deployment"
+	OperatorAutoscaler   AutoscalerType = "Operator"
+)
+
 // The overall state of the Ray cluster.
 type ClusterState string
 
@@ -106,6 +366,8 @@ const (
 	Ready     ClusterState = "ready"
 	Unhealthy ClusterState = "unhealthy"
 	Failed    ClusterState = "failed"
+	// Hibernated means that KubeRay scaled the worker groups to zero because the RayCluster was idle.
+	Hibernated ClusterState = "hibernated"
 )
 
 // RayClusterStatus defines the observed state of RayCluster
@@ -135,6 +397,59 @@ type RayClusterStatus struct {
 	// RayCluster's generation, which is updated on mutation by the API Server.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// TLS reports the CA used for TLS between the Ray nodes when spec.tls is set.
+	// +optional
+	TLS *TLSStatus `json:"tls,omitempty"`
+	// Hibernation reports the activity of the RayCluster when spec.idlePolicy is set.
+	// +optional
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
+	// Preemption records the last time the workers of the RayCluster were scaled down by a preemption. The replicas are
+	// restored once the preempting RayCluster is deleted or no longer holds its workers.
+	// +optional
+	Preemption *PreemptionStatus `json:"preemption,omitempty"`
+	// RedisPassword reports the Redis password of the Pods when spec.managedRedisPassword is set.
+	// +optional
+	RedisPassword *RedisPasswordStatus `json:"redisPassword,omitempty"`
+	// Conditions reports the conditions of the RayCluster that need the attention of the user.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// The types of the conditions of a RayCluster.
+const (
+	// TLSCAExpiring is true while the user-provided CA of spec.tls expires within spec.tls.renewBefore.
+	TLSCAExpiring = "TLSCAExpiring"
+)
+
+// PreemptionStatus records the preemption of a RayCluster or of a RayJob.
+type PreemptionStatus struct {
+	// PreemptedBy is the namespace/name of the RayCluster whose unschedulable Pods caused the preemption.
+	PreemptedBy string `json:"preemptedBy,omitempty"`
+	// PreemptorPriority is the priority of the preempting RayCluster.
+	PreemptorPriority int32 `json:"preemptorPriority,omitempty"`
+	// PreemptionTime is when the preemption happened.
+	PreemptionTime *metav1.Time `json:"preemptionTime,omitempty"`
+}
+
+// HibernationStatus reports the activity observed by the idle policy of a RayCluster.
+type HibernationStatus struct {
+	// LastActivityTime is the last time KubeRay observed activity on the RayCluster.
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+	// HibernationTime is when the RayCluster was hibernated. It is unset while the RayCluster is awake.
+	HibernationTime *metav1.Time `json:"hibernationTime,omitempty"`
+	// DashboardRequestCount is the number of user requests served by the dashboard at the last activity check. A
+	// different count at the next check is reported as activity.
+	DashboardRequestCount *int64 `json:"dashboardRequestCount,omitempty"`
+}
+
+// TLSStatus reports the CA that issues the node certificates of a RayCluster.
+type TLSStatus struct {
+	// CASecretName is the name of the Secret holding the CA.
+	CASecretName string `json:"caSecretName,omitempty"`
+	// CAExpirationTime is when the current CA certificate expires. Node certificates never outlive it.
+	CAExpirationTime *metav1.Time `json:"caExpirationTime,omitempty"`
 }
 
 // HeadInfo gives info about head
@@ -83,6 +83,9 @@ type RayJobSpec struct {
 	// EntrypointResources specifies the custom resources and quantities to reserve for the
 	// entrypoint command.
 	EntrypointResources string `json:"entrypointResources,omitempty"`
+	// BatchScheduling submits the Pods of the RayCluster created for the RayJob to a batch scheduler, unless
+	// rayClusterSpec sets its own batchScheduling.
+	BatchScheduling *BatchSchedulingOptions `json:"batchScheduling,omitempty"`
 }
 
 // RayJobStatus defines the observed state of RayJob
@@ -106,6 +109,9 @@ type RayJobStatus struct {
 	// RayJob's generation, which is updated on mutation by the API Server.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Preemption records the last time the RayJob was suspended by a preemption.
+	// +optional
+	Preemption *PreemptionStatus `json:"preemption,omitempty"`
 }
 
 // +kubebuilder:object:root=true
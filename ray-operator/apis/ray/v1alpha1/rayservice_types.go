@@ -20,6 +20,7 @@ const (
 	FailedToUpdateIngress            ServiceStatus = "FailedToUpdateIngress"
 	FailedToUpdateServingPodLabel    ServiceStatus = "FailedToUpdateServingPodLabel"
 	FailedToUpdateService            ServiceStatus = "FailedToUpdateService"
+	FailedToGetServeConfig           ServiceStatus = "FailedToGetServeConfig"
 )
 
 // These statuses should match Ray Serve's application statuses
@@ -56,12 +57,64 @@ type RayServiceSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	ServeDeploymentGraphSpec ServeDeploymentGraphSpec `json:"serveConfig,omitempty"`
 	// Defines the applications and deployments to deploy, should be a YAML multi-line scalar string.
-	ServeConfigV2                      string         `json:"serveConfigV2,omitempty"`
-	RayClusterSpec                     RayClusterSpec `json:"rayClusterConfig,omitempty"`
-	ServiceUnhealthySecondThreshold    *int32         `json:"serviceUnhealthySecondThreshold,omitempty"`
-	DeploymentUnhealthySecondThreshold *int32         `json:"deploymentUnhealthySecondThreshold,omitempty"`
+	ServeConfigV2 string `json:"serveConfigV2,omitempty"`
+	// ServeConfigRef references a ConfigMap key whose value is used in place of ServeConfigV2, so that the
+	// Serve config can be shared between RayServices. Only one of ServeConfigV2, ServeConfigRef, and serveConfig can be set.
+	ServeConfigRef                     *ServeConfigReference `json:"serveConfigRef,omitempty"`
+	RayClusterSpec                     RayClusterSpec        `json:"rayClusterConfig,omitempty"`
+	ServiceUnhealthySecondThreshold    *int32                `json:"serviceUnhealthySecondThreshold,omitempty"`
+	DeploymentUnhealthySecondThreshold *int32                `json:"deploymentUnhealthySecondThreshold,omitempty"`
 	// ServeService is the Kubernetes service for head node and worker nodes who have healthy http proxy to serve traffics.
 	ServeService *v1.Service `json:"serveService,omitempty"`
+	// ServeApplicationServices, if set, makes the operator create one Kubernetes Service per Serve application
+	// defined in ServeConfigV2, in addition to ServeService. It is ignored for single-application configs.
+	ServeApplicationServices *ServeApplicationServicesOptions `json:"serveApplicationServices,omitempty"`
+	// EnableGRPCIngress indicates whether operator should create an ingress object for the gRPC port of ServeService.
+	// The Ray head container must have a port named "serve-grpc".
+	EnableGRPCIngress *bool `json:"enableGRPCIngress,omitempty"`
+	// GRPCRoute, if set, makes the operator create a Gateway API GRPCRoute for the gRPC port of ServeService.
+	// The Ray head container must have a port named "serve-grpc", and the GRPCRoute CRD must be installed.
+	GRPCRoute *GRPCRouteOptions `json:"grpcRoute,omitempty"`
+}
+
+// ServeApplicationServicesOptions configures the per-application Kubernetes Services of a multi-application RayService.
+type ServeApplicationServicesOptions struct {
+	// ServiceType is the Kubernetes service type of the per-application services.
+	// Defaults to the ServiceType of the head group.
+	ServiceType v1.ServiceType `json:"serviceType,omitempty"`
+	// EnableIngress indicates whether operator should create an ingress object with one path per Serve application.
+	// Each path is the application's route prefix and is backed by the application's service.
+	EnableIngress *bool `json:"enableIngress,omitempty"`
+}
+
+// GRPCRouteOptions configures the Gateway API GRPCRoute that routes gRPC traffic to ServeService.
+type GRPCRouteOptions struct {
+	// ParentRefs are the Gateways the GRPCRoute attaches to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+	// Hostnames matched by the GRPCRoute. All the hostnames of the Gateways are matched if empty.
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// GatewayParentReference identifies a Gateway, and optionally one of its listeners.
+type GatewayParentReference struct {
+	// Name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the Gateway. Defaults to the namespace of the RayService.
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the listener of the Gateway.
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ServeConfigReference selects a key of a ConfigMap in the namespace of the RayService.
+type ServeConfigReference struct {
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key of the ConfigMap whose value has the same format as ServeConfigV2.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
 }
 
 type ServeDeploymentGraphSpec struct {
@@ -118,6 +171,9 @@ type RayServiceStatus struct {
 	DashboardStatus  DashboardStatus      `json:"dashboardStatus,omitempty"`
 	RayClusterName   string               `json:"rayClusterName,omitempty"`
 	RayClusterStatus RayClusterStatus     `json:"rayClusterStatus,omitempty"`
+	// ServeConfigResourceVersion is the resourceVersion of the ConfigMap referenced by ServeConfigRef
+	// whose Serve config was last applied to the RayCluster.
+	ServeConfigResourceVersion string `json:"serveConfigResourceVersion,omitempty"`
 }
 
 // DashboardStatus defines the current states of Ray Dashboard
@@ -137,6 +193,9 @@ type AppStatus struct {
 	// Update when Serve deployment is healthy or first time convert to unhealthy from healthy.
 	HealthLastUpdateTime *metav1.Time                     `json:"healthLastUpdateTime,omitempty"`
 	Deployments          map[string]ServeDeploymentStatus `json:"serveDeploymentStatuses,omitempty"`
+	// ServiceEndpoint is the in-cluster address of the application's own Kubernetes service.
+	// It is only set when ServeApplicationServices is enabled.
+	ServiceEndpoint string `json:"serviceEndpoint,omitempty"`
 }
 
 // ServeDeploymentStatus defines the current state of a Serve deployment
@@ -149,6 +208,23 @@ type ServeDeploymentStatus struct {
 	// Keep track of how long the service is healthy.
 	// Update when Serve deployment is healthy or first time convert to unhealthy from healthy.
 	HealthLastUpdateTime *metav1.Time `json:"healthLastUpdateTime,omitempty"`
+	// TargetReplicas is the number of replicas Serve is currently scaling the deployment to.
+	TargetReplicas *int32 `json:"targetReplicas,omitempty"`
+	// RunningReplicas is the number of replicas of the deployment in the RUNNING state.
+	RunningReplicas *int32 `json:"runningReplicas,omitempty"`
+	// AutoscalingConfig is the JSON-encoded autoscaling config in effect for the deployment, if any.
+	AutoscalingConfig string `json:"autoscalingConfig,omitempty"`
+	// ScaleEvents records the most recent changes of TargetReplicas observed by KubeRay, oldest first.
+	ScaleEvents []ServeDeploymentScaleEvent `json:"scaleEvents,omitempty"`
+}
+
+// ServeDeploymentScaleEvent describes a change of a Serve deployment's target number of replicas
+type ServeDeploymentScaleEvent struct {
+	Time         metav1.Time `json:"time"`
+	FromReplicas int32       `json:"fromReplicas"`
+	ToReplicas   int32       `json:"toReplicas"`
+	// Trigger is the Serve deployment status when the change was observed.
+	Trigger string `json:"trigger,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RayWorkerGroupSpec defines the desired state of RayWorkerGroup
+type RayWorkerGroupSpec struct {
+	// RayClusterName is the name of the RayCluster the worker group belongs to.
+	RayClusterName string `json:"rayClusterName"`
+	// GroupName is the name of the worker group in spec.workerGroupSpecs of the RayCluster.
+	GroupName string `json:"groupName"`
+	// Replicas is the desired number of worker Pods of the group. It is kept in sync with the replicas of the worker
+	// group in the RayCluster, in both directions, and clamped to its minReplicas and maxReplicas.
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// RayWorkerGroupStatus defines the observed state of RayWorkerGroup
+type RayWorkerGroupStatus struct {
+	// Replicas is the number of worker Pods of the group.
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of running worker Pods of the group.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Selector is the label selector of the worker Pods of the group, used by the scale subresource.
+	Selector string `json:"selector,omitempty"`
+}
+
+// RayWorkerGroup exposes a worker group of a RayCluster through the scale subresource, so that the
+// HorizontalPodAutoscaler, KEDA, or `kubectl scale` can scale it. KubeRay creates one RayWorkerGroup for each
+// worker group of a RayCluster with spec.enableRayWorkerGroups set.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="cluster",type="string",JSONPath=".spec.rayClusterName",priority=0
+// +kubebuilder:printcolumn:name="group",type="string",JSONPath=".spec.groupName",priority=0
+// +kubebuilder:printcolumn:name="desired",type=integer,JSONPath=".spec.replicas",priority=0
+// +kubebuilder:printcolumn:name="current",type=integer,JSONPath=".status.replicas",priority=0
+// +kubebuilder:printcolumn:name="ready",type=integer,JSONPath=".status.readyReplicas",priority=0
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +genclient
+type RayWorkerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RayWorkerGroupSpec   `json:"spec,omitempty"`
+	Status RayWorkerGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayWorkerGroupList contains a list of RayWorkerGroup
+type RayWorkerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayWorkerGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayWorkerGroup{}, &RayWorkerGroupList{})
+}
@@ -7,7 +7,10 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -94,6 +97,16 @@ func (in *AutoscalerOptions) DeepCopyInto(out *AutoscalerOptions) {
 		*out = new(UpscalingMode)
 		**out = **in
 	}
+	if in.UpscalingSpeed != nil {
+		in, out := &in.UpscalingSpeed, &out.UpscalingSpeed
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(AutoscalerType)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerOptions.
@@ -106,6 +119,76 @@ func (in *AutoscalerOptions) DeepCopy() *AutoscalerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchSchedulingOptions) DeepCopyInto(out *BatchSchedulingOptions) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScheduleTimeoutSeconds != nil {
+		in, out := &in.ScheduleTimeoutSeconds, &out.ScheduleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchSchedulingOptions.
+func (in *BatchSchedulingOptions) DeepCopy() *BatchSchedulingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchSchedulingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardAuthOptions) DeepCopyInto(out *DashboardAuthOptions) {
+	*out = *in
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(DashboardOIDCOptions)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardAuthOptions.
+func (in *DashboardAuthOptions) DeepCopy() *DashboardAuthOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardAuthOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardOIDCOptions) DeepCopyInto(out *DashboardOIDCOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardOIDCOptions.
+func (in *DashboardOIDCOptions) DeepCopy() *DashboardOIDCOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardOIDCOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DashboardStatus) DeepCopyInto(out *DashboardStatus) {
 	*out = *in
@@ -129,6 +212,66 @@ func (in *DashboardStatus) DeepCopy() *DashboardStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCRouteOptions) DeepCopyInto(out *GRPCRouteOptions) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCRouteOptions.
+func (in *GRPCRouteOptions) DeepCopy() *GRPCRouteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCRouteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcsFaultToleranceOptions) DeepCopyInto(out *GcsFaultToleranceOptions) {
+	*out = *in
+	if in.RedisPassword != nil {
+		in, out := &in.RedisPassword, &out.RedisPassword
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcsFaultToleranceOptions.
+func (in *GcsFaultToleranceOptions) DeepCopy() *GcsFaultToleranceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GcsFaultToleranceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
 	*out = *in
@@ -182,6 +325,188 @@ func (in *HeadInfo) DeepCopy() *HeadInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationStatus) DeepCopyInto(out *HibernationStatus) {
+	*out = *in
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+	if in.HibernationTime != nil {
+		in, out := &in.HibernationTime, &out.HibernationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DashboardRequestCount != nil {
+		in, out := &in.DashboardRequestCount, &out.DashboardRequestCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationStatus.
+func (in *HibernationStatus) DeepCopy() *HibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdlePolicy) DeepCopyInto(out *IdlePolicy) {
+	*out = *in
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdlePolicy.
+func (in *IdlePolicy) DeepCopy() *IdlePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IdlePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedRedisPasswordOptions) DeepCopyInto(out *ManagedRedisPasswordOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRedisPasswordOptions.
+func (in *ManagedRedisPasswordOptions) DeepCopy() *ManagedRedisPasswordOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedRedisPasswordOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringOptions) DeepCopyInto(out *MonitoringOptions) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringOptions.
+func (in *MonitoringOptions) DeepCopy() *MonitoringOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkIsolationOptions) DeepCopyInto(out *NetworkIsolationOptions) {
+	*out = *in
+	if in.DashboardPeers != nil {
+		in, out := &in.DashboardPeers, &out.DashboardPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientPeers != nil {
+		in, out := &in.ClientPeers, &out.ClientPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServePeers != nil {
+		in, out := &in.ServePeers, &out.ServePeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsPeers != nil {
+		in, out := &in.MetricsPeers, &out.MetricsPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIsolationOptions.
+func (in *NetworkIsolationOptions) DeepCopy() *NetworkIsolationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkIsolationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetOptions) DeepCopyInto(out *PodDisruptionBudgetOptions) {
+	*out = *in
+	if in.WorkerMaxUnavailable != nil {
+		in, out := &in.WorkerMaxUnavailable, &out.WorkerMaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetOptions.
+func (in *PodDisruptionBudgetOptions) DeepCopy() *PodDisruptionBudgetOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionOptions) DeepCopyInto(out *PreemptionOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionOptions.
+func (in *PreemptionOptions) DeepCopy() *PreemptionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionStatus) DeepCopyInto(out *PreemptionStatus) {
+	*out = *in
+	if in.PreemptionTime != nil {
+		in, out := &in.PreemptionTime, &out.PreemptionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionStatus.
+func (in *PreemptionStatus) DeepCopy() *PreemptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayActorOptionSpec) DeepCopyInto(out *RayActorOptionSpec) {
 	*out = *in
@@ -304,6 +629,66 @@ func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardAuth != nil {
+		in, out := &in.DashboardAuth, &out.DashboardAuth
+		*out = new(DashboardAuthOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GcsFaultToleranceOptions != nil {
+		in, out := &in.GcsFaultToleranceOptions, &out.GcsFaultToleranceOptions
+		*out = new(GcsFaultToleranceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedRedisPassword != nil {
+		in, out := &in.ManagedRedisPassword, &out.ManagedRedisPassword
+		*out = new(ManagedRedisPasswordOptions)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableWorkerHeadlessService != nil {
+		in, out := &in.EnableWorkerHeadlessService, &out.EnableWorkerHeadlessService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableRayWorkerGroups != nil {
+		in, out := &in.EnableRayWorkerGroups, &out.EnableRayWorkerGroups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BatchScheduling != nil {
+		in, out := &in.BatchScheduling, &out.BatchScheduling
+		*out = new(BatchSchedulingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdlePolicy != nil {
+		in, out := &in.IdlePolicy, &out.IdlePolicy
+		*out = new(IdlePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(PreemptionOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterSpec.
@@ -331,6 +716,33 @@ func (in *RayClusterStatus) DeepCopyInto(out *RayClusterStatus) {
 		}
 	}
 	out.Head = in.Head
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(PreemptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisPassword != nil {
+		in, out := &in.RedisPassword, &out.RedisPassword
+		*out = new(RedisPasswordStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterStatus.
@@ -434,6 +846,11 @@ func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
 		*out = new(v1.PodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BatchScheduling != nil {
+		in, out := &in.BatchScheduling, &out.BatchScheduling
+		*out = new(BatchSchedulingOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobSpec.
@@ -458,6 +875,11 @@ func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
 		*out = (*in).DeepCopy()
 	}
 	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(PreemptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStatus.
@@ -533,6 +955,11 @@ func (in *RayServiceList) DeepCopyObject() runtime.Object {
 func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
 	*out = *in
 	in.ServeDeploymentGraphSpec.DeepCopyInto(&out.ServeDeploymentGraphSpec)
+	if in.ServeConfigRef != nil {
+		in, out := &in.ServeConfigRef, &out.ServeConfigRef
+		*out = new(ServeConfigReference)
+		**out = **in
+	}
 	in.RayClusterSpec.DeepCopyInto(&out.RayClusterSpec)
 	if in.ServiceUnhealthySecondThreshold != nil {
 		in, out := &in.ServiceUnhealthySecondThreshold, &out.ServiceUnhealthySecondThreshold
@@ -549,6 +976,21 @@ func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
 		*out = new(v1.Service)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServeApplicationServices != nil {
+		in, out := &in.ServeApplicationServices, &out.ServeApplicationServices
+		*out = new(ServeApplicationServicesOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableGRPCIngress != nil {
+		in, out := &in.EnableGRPCIngress, &out.EnableGRPCIngress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GRPCRoute != nil {
+		in, out := &in.GRPCRoute, &out.GRPCRoute
+		*out = new(GRPCRouteOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceSpec.
@@ -602,6 +1044,115 @@ func (in *RayServiceStatuses) DeepCopy() *RayServiceStatuses {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayWorkerGroup) DeepCopyInto(out *RayWorkerGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayWorkerGroup.
+func (in *RayWorkerGroup) DeepCopy() *RayWorkerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RayWorkerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayWorkerGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayWorkerGroupList) DeepCopyInto(out *RayWorkerGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayWorkerGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayWorkerGroupList.
+func (in *RayWorkerGroupList) DeepCopy() *RayWorkerGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayWorkerGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayWorkerGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayWorkerGroupSpec) DeepCopyInto(out *RayWorkerGroupSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayWorkerGroupSpec.
+func (in *RayWorkerGroupSpec) DeepCopy() *RayWorkerGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayWorkerGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayWorkerGroupStatus) DeepCopyInto(out *RayWorkerGroupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayWorkerGroupStatus.
+func (in *RayWorkerGroupStatus) DeepCopy() *RayWorkerGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayWorkerGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisPasswordStatus) DeepCopyInto(out *RedisPasswordStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisPasswordStatus.
+func (in *RedisPasswordStatus) DeepCopy() *RedisPasswordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisPasswordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleStrategy) DeepCopyInto(out *ScaleStrategy) {
 	*out = *in
@@ -622,6 +1173,41 @@ func (in *ScaleStrategy) DeepCopy() *ScaleStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeApplicationServicesOptions) DeepCopyInto(out *ServeApplicationServicesOptions) {
+	*out = *in
+	if in.EnableIngress != nil {
+		in, out := &in.EnableIngress, &out.EnableIngress
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeApplicationServicesOptions.
+func (in *ServeApplicationServicesOptions) DeepCopy() *ServeApplicationServicesOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeApplicationServicesOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeConfigReference) DeepCopyInto(out *ServeConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeConfigReference.
+func (in *ServeConfigReference) DeepCopy() *ServeConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServeConfigSpec) DeepCopyInto(out *ServeConfigSpec) {
 	*out = *in
@@ -690,6 +1276,22 @@ func (in *ServeDeploymentGraphSpec) DeepCopy() *ServeDeploymentGraphSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeDeploymentScaleEvent) DeepCopyInto(out *ServeDeploymentScaleEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeDeploymentScaleEvent.
+func (in *ServeDeploymentScaleEvent) DeepCopy() *ServeDeploymentScaleEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeDeploymentScaleEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServeDeploymentStatus) DeepCopyInto(out *ServeDeploymentStatus) {
 	*out = *in
@@ -701,6 +1303,23 @@ func (in *ServeDeploymentStatus) DeepCopyInto(out *ServeDeploymentStatus) {
 		in, out := &in.HealthLastUpdateTime, &out.HealthLastUpdateTime
 		*out = (*in).DeepCopy()
 	}
+	if in.TargetReplicas != nil {
+		in, out := &in.TargetReplicas, &out.TargetReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RunningReplicas != nil {
+		in, out := &in.RunningReplicas, &out.RunningReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleEvents != nil {
+		in, out := &in.ScaleEvents, &out.ScaleEvents
+		*out = make([]ServeDeploymentScaleEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeDeploymentStatus.
@@ -713,6 +1332,55 @@ func (in *ServeDeploymentStatus) DeepCopy() *ServeDeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSOptions) DeepCopyInto(out *TLSOptions) {
+	*out = *in
+	if in.CADuration != nil {
+		in, out := &in.CADuration, &out.CADuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NodeCertDuration != nil {
+		in, out := &in.NodeCertDuration, &out.NodeCertDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSOptions.
+func (in *TLSOptions) DeepCopy() *TLSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSStatus) DeepCopyInto(out *TLSStatus) {
+	*out = *in
+	if in.CAExpirationTime != nil {
+		in, out := &in.CAExpirationTime, &out.CAExpirationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSStatus.
+func (in *TLSStatus) DeepCopy() *TLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 	*out = *in
@@ -740,6 +1408,21 @@ func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 	}
 	in.Template.DeepCopyInto(&out.Template)
 	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownProtection != nil {
+		in, out := &in.ScaleDownProtection, &out.ScaleDownProtection
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupSpec.
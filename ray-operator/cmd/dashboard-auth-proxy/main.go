@@ -0,0 +1,109 @@
+// dashboard-auth-proxy authenticates the requests to the Ray dashboard and the dashboard agent of a head Pod.
+// KubeRay injects it as a sidecar container when spec.dashboardAuth is set on a RayCluster.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	k8szap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/ray-project/kuberay/ray-operator/pkg/dashboardauth"
+)
+
+// routeFlags collects the repeated --route flags, each of the form <listen address>=<upstream URL>.
+type routeFlags []string
+
+func (r *routeFlags) String() string {
+	return strings.Join(*r, ",")
+}
+
+func (r *routeFlags) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+func main() {
+	var routes routeFlags
+	var tokenFile, oidcIssuerURL, oidcAudience string
+	flag.Var(&routes, "route", "A listen address and the upstream URL it forwards to, e.g. :8266=http://127.0.0.1:8265. Can be repeated.")
+	flag.StringVar(&tokenFile, "token-file", "", "The file holding the bearer token that is always accepted.")
+	flag.StringVar(&oidcIssuerURL, "oidc-issuer-url", "", "If set, also accept ID tokens issued by this OIDC issuer.")
+	flag.StringVar(&oidcAudience, "oidc-audience", "", "The audience that OIDC ID tokens must contain.")
+	opts := k8szap.Options{
+		TimeEncoder: zapcore.ISO8601TimeEncoder,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	log := k8szap.New(k8szap.UseFlagOptions(&opts)).WithName("dashboard-auth-proxy")
+
+	if len(routes) == 0 || tokenFile == "" {
+		log.Error(fmt.Errorf("--route and --token-file are required"), "Invalid flags")
+		os.Exit(1)
+	}
+
+	tokenAuthenticator, err := dashboardauth.NewTokenAuthenticatorFromFile(tokenFile)
+	if err != nil {
+		log.Error(err, "Failed to load the token")
+		os.Exit(1)
+	}
+	authenticators := []dashboardauth.Authenticator{tokenAuthenticator}
+	if oidcIssuerURL != "" {
+		oidcAuthenticator, err := dashboardauth.NewOIDCAuthenticator(oidcIssuerURL, oidcAudience, nil)
+		if err != nil {
+			log.Error(err, "Invalid OIDC configuration")
+			os.Exit(1)
+		}
+		authenticators = append(authenticators, oidcAuthenticator)
+	}
+
+	var servers []*http.Server
+	for _, route := range routes {
+		listenAddr, upstream, found := strings.Cut(route, "=")
+		upstreamURL, err := url.Parse(upstream)
+		if !found || err != nil || upstreamURL.Host == "" {
+			log.Error(err, "Invalid route", "route", route)
+			os.Exit(1)
+		}
+		servers = append(servers, &http.Server{
+			Addr:              listenAddr,
+			Handler:           dashboardauth.NewProxy(upstreamURL, authenticators, log.WithValues("listen", listenAddr)),
+			ReadHeaderTimeout: 10 * time.Second,
+		})
+	}
+
+	errCh := make(chan error, len(servers))
+	for _, server := range servers {
+		go func(server *http.Server) {
+			log.Info("Listening", "address", server.Addr)
+			errCh <- server.ListenAndServe()
+		}(server)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-errCh:
+		log.Error(err, "Server stopped")
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "Failed to shut down", "address", server.Addr)
+		}
+	}
+}
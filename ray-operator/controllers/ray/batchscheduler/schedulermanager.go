@@ -2,6 +2,8 @@ package batchscheduler
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -10,13 +12,17 @@ import (
 
 	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/schedulerplugins"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/volcano"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 )
 
 var schedulerContainers = map[string]schedulerinterface.BatchSchedulerFactory{
 	schedulerinterface.GetDefaultPluginName(): &schedulerinterface.DefaultBatchSchedulerFactory{},
 	volcano.GetPluginName():                   &volcano.VolcanoBatchSchedulerFactory{},
+	yunikorn.GetPluginName():                  &yunikorn.YuniKornBatchSchedulerFactory{},
+	schedulerplugins.GetPluginName():          &schedulerplugins.SchedulerPluginsBatchSchedulerFactory{},
 }
 
 func GetRegisteredNames() []string {
@@ -27,6 +33,36 @@ func GetRegisteredNames() []string {
 	return pluginNames
 }
 
+// ValidateBatchScheduling makes sure the batch scheduler in spec.batchScheduling of the RayCluster is a registered plugin.
+func ValidateBatchScheduling(app *rayv1alpha1.RayCluster) error {
+	return validateBatchSchedulingOptions("spec.batchScheduling", app.Spec.BatchScheduling)
+}
+
+// ValidateRayJobBatchScheduling makes sure the batch schedulers in spec.batchScheduling of the RayJob and in
+// spec.rayClusterSpec.batchScheduling are registered plugins, so that the RayCluster of the RayJob is not rejected.
+func ValidateRayJobBatchScheduling(rayJob *rayv1alpha1.RayJob) error {
+	if err := validateBatchSchedulingOptions("spec.batchScheduling", rayJob.Spec.BatchScheduling); err != nil {
+		return err
+	}
+	if rayJob.Spec.RayClusterSpec == nil {
+		return nil
+	}
+	return validateBatchSchedulingOptions("spec.rayClusterSpec.batchScheduling", rayJob.Spec.RayClusterSpec.BatchScheduling)
+}
+
+func validateBatchSchedulingOptions(field string, options *rayv1alpha1.BatchSchedulingOptions) error {
+	if options == nil {
+		return nil
+	}
+	if _, registered := schedulerContainers[options.SchedulerName]; !registered {
+		names := GetRegisteredNames()
+		sort.Strings(names)
+		return fmt.Errorf("%s.schedulerName %q is not a registered scheduler plugin, expected one of %s",
+			field, options.SchedulerName, strings.Join(names, ", "))
+	}
+	return nil
+}
+
 func ConfigureReconciler(b *builder.Builder) *builder.Builder {
 	for _, factory := range schedulerContainers {
 		b = factory.ConfigureReconciler(b)
@@ -55,7 +91,7 @@ func NewSchedulerManager(config *rest.Config) *SchedulerManager {
 }
 
 func (batch *SchedulerManager) GetSchedulerForCluster(app *rayv1alpha1.RayCluster) (schedulerinterface.BatchScheduler, error) {
-	if schedulerName, ok := app.ObjectMeta.Labels[common.RaySchedulerName]; ok {
+	if schedulerName := common.GetBatchSchedulerName(app); schedulerName != "" {
 		return batch.GetScheduler(schedulerName)
 	}
 
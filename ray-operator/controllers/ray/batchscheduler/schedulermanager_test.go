@@ -0,0 +1,72 @@
+package batchscheduler
+
+import (
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateBatchScheduling(t *testing.T) {
+	cluster := &rayv1alpha1.RayCluster{}
+	assert.Nil(t, ValidateBatchScheduling(cluster))
+
+	for _, name := range GetRegisteredNames() {
+		cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{SchedulerName: name}
+		assert.Nil(t, ValidateBatchScheduling(cluster))
+	}
+
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{SchedulerName: "volcan0"}
+	err := ValidateBatchScheduling(cluster)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "volcan0")
+	assert.Contains(t, err.Error(), "volcano")
+}
+
+func TestValidateRayJobBatchScheduling(t *testing.T) {
+	rayJob := &rayv1alpha1.RayJob{}
+	assert.Nil(t, ValidateRayJobBatchScheduling(rayJob))
+
+	rayJob.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{SchedulerName: yunikorn.GetPluginName()}
+	rayJob.Spec.RayClusterSpec = &rayv1alpha1.RayClusterSpec{}
+	assert.Nil(t, ValidateRayJobBatchScheduling(rayJob))
+
+	rayJob.Spec.RayClusterSpec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{SchedulerName: "volcan0"}
+	err := ValidateRayJobBatchScheduling(rayJob)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "spec.rayClusterSpec.batchScheduling")
+
+	rayJob.Spec.RayClusterSpec.BatchScheduling = nil
+	rayJob.Spec.BatchScheduling.SchedulerName = "volcan0"
+	assert.NotNil(t, ValidateRayJobBatchScheduling(rayJob))
+}
+
+func TestGetSchedulerForCluster(t *testing.T) {
+	manager := NewSchedulerManager(nil)
+
+	// The default scheduler is used without spec.batchScheduling or label.
+	cluster := &rayv1alpha1.RayCluster{}
+	scheduler, err := manager.GetSchedulerForCluster(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, schedulerinterface.GetDefaultPluginName(), scheduler.Name())
+
+	// The label is used as a fallback.
+	cluster.ObjectMeta = metav1.ObjectMeta{Labels: map[string]string{common.RaySchedulerName: yunikorn.GetPluginName()}}
+	scheduler, err = manager.GetSchedulerForCluster(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, yunikorn.GetPluginName(), scheduler.Name())
+
+	// spec.batchScheduling takes precedence over the label.
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{SchedulerName: schedulerinterface.GetDefaultPluginName()}
+	scheduler, err = manager.GetSchedulerForCluster(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, schedulerinterface.GetDefaultPluginName(), scheduler.Name())
+
+	cluster.Spec.BatchScheduling.SchedulerName = "unknown"
+	_, err = manager.GetSchedulerForCluster(cluster)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,206 @@
+package schedulerplugins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+)
+
+const (
+	PodGroupName = "podgroups.scheduling.x-k8s.io"
+	// PodGroupLabelKey is the label of the Pods of a PodGroup.
+	PodGroupLabelKey = "scheduling.x-k8s.io/pod-group"
+	// SchedulerName is the name of the secondary scheduler deployed by the scheduler-plugins Helm chart.
+	SchedulerName = "scheduler-plugins-scheduler"
+)
+
+// PodGroupGVR is the resource of the PodGroups of the coscheduling plugin. PodGroups are handled as unstructured objects
+// so that KubeRay does not depend on the scheduler-plugins API.
+var PodGroupGVR = schema.GroupVersionResource{Group: "scheduling.x-k8s.io", Version: "v1alpha1", Resource: "podgroups"}
+
+type SchedulerPluginsBatchScheduler struct {
+	dynamicClient dynamic.Interface
+	log           logr.Logger
+}
+
+type SchedulerPluginsBatchSchedulerFactory struct{}
+
+func GetPluginName() string {
+	return "scheduler-plugins"
+}
+
+func (s *SchedulerPluginsBatchScheduler) Name() string {
+	return GetPluginName()
+}
+
+func (s *SchedulerPluginsBatchScheduler) DoBatchSchedulingOnSubmission(app *rayv1alpha1.RayCluster) error {
+	minMember, totalResource := common.GetGangSchedulingMinMemberAndResources(app)
+
+	return s.syncPodGroup(app, minMember, totalResource)
+}
+
+func getAppPodGroupName(app *rayv1alpha1.RayCluster) string {
+	return fmt.Sprintf("ray-%s-pg", app.Name)
+}
+
+func (s *SchedulerPluginsBatchScheduler) syncPodGroup(app *rayv1alpha1.RayCluster, size int32, totalResource corev1.ResourceList) error {
+	podGroupName := getAppPodGroupName(app)
+	podGroups := s.dynamicClient.Resource(PodGroupGVR).Namespace(app.Namespace)
+	pg, err := podGroups.Get(context.TODO(), podGroupName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+
+		podGroup, err := createPodGroup(app, podGroupName, size, totalResource)
+		if err != nil {
+			return err
+		}
+		if _, err := podGroups.Create(context.TODO(), podGroup, metav1.CreateOptions{}); err != nil {
+			if errors.IsAlreadyExists(err) {
+				s.log.Info("pod group already exists, no need to create")
+				return nil
+			}
+
+			s.log.Error(err, "Pod group CREATE error!", "PodGroup.Error", err)
+			return err
+		}
+		return nil
+	}
+
+	minMember, _, _ := unstructured.NestedInt64(pg.Object, "spec", "minMember")
+	timeout, hasTimeout, _ := unstructured.NestedInt64(pg.Object, "spec", "scheduleTimeoutSeconds")
+	desiredTimeout := common.GetBatchSchedulingTimeoutSeconds(app)
+	timeoutChanged := hasTimeout != (desiredTimeout != nil) || (desiredTimeout != nil && int32(timeout) != *desiredTimeout)
+	minResources, err := getMinResources(pg)
+	if err != nil {
+		return err
+	}
+	if int32(minMember) != size || !quotav1.Equals(minResources, totalResource) || timeoutChanged {
+		if err := setPodGroupSpec(app, pg, size, totalResource); err != nil {
+			return err
+		}
+		if _, err := podGroups.Update(context.TODO(), pg, metav1.UpdateOptions{}); err != nil {
+			s.log.Error(err, "Pod group UPDATE error!", "podGroup", podGroupName)
+			return err
+		}
+	}
+	return nil
+}
+
+func createPodGroup(
+	app *rayv1alpha1.RayCluster,
+	podGroupName string,
+	size int32,
+	totalResource corev1.ResourceList,
+) (*unstructured.Unstructured, error) {
+	podGroup := &unstructured.Unstructured{}
+	podGroup.SetAPIVersion(PodGroupGVR.GroupVersion().String())
+	podGroup.SetKind("PodGroup")
+	podGroup.SetNamespace(app.Namespace)
+	podGroup.SetName(podGroupName)
+	// The PodGroup is garbage collected when the RayCluster is deleted.
+	podGroup.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(app, rayv1alpha1.SchemeGroupVersion.WithKind("RayCluster")),
+	})
+	if err := setPodGroupSpec(app, podGroup, size, totalResource); err != nil {
+		return nil, err
+	}
+	return podGroup, nil
+}
+
+func setPodGroupSpec(app *rayv1alpha1.RayCluster, podGroup *unstructured.Unstructured, size int32, totalResource corev1.ResourceList) error {
+	minResources := map[string]interface{}{}
+	for name, quantity := range totalResource {
+		minResources[string(name)] = quantity.String()
+	}
+	if err := unstructured.SetNestedField(podGroup.Object, int64(size), "spec", "minMember"); err != nil {
+		return err
+	}
+	if timeout := common.GetBatchSchedulingTimeoutSeconds(app); timeout != nil {
+		if err := unstructured.SetNestedField(podGroup.Object, int64(*timeout), "spec", "scheduleTimeoutSeconds"); err != nil {
+			return err
+		}
+	} else {
+		unstructured.RemoveNestedField(podGroup.Object, "spec", "scheduleTimeoutSeconds")
+	}
+	return unstructured.SetNestedMap(podGroup.Object, minResources, "spec", "minResources")
+}
+
+func getMinResources(podGroup *unstructured.Unstructured) (corev1.ResourceList, error) {
+	minResources := corev1.ResourceList{}
+	unstructuredMinResources, _, err := unstructured.NestedStringMap(podGroup.Object, "spec", "minResources")
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range unstructuredMinResources {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, err
+		}
+		minResources[corev1.ResourceName(name)] = quantity
+	}
+	return minResources, nil
+}
+
+func (s *SchedulerPluginsBatchScheduler) AddMetadataToPod(app *rayv1alpha1.RayCluster, pod *corev1.Pod) {
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[PodGroupLabelKey] = getAppPodGroupName(app)
+	if priorityClassName := common.GetBatchSchedulingPriorityClassName(app); priorityClassName != "" {
+		pod.Spec.PriorityClassName = priorityClassName
+	}
+	pod.Spec.SchedulerName = SchedulerName
+}
+
+func (sf *SchedulerPluginsBatchSchedulerFactory) New(config *rest.Config) (schedulerinterface.BatchScheduler, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dynamic client with error %v", err)
+	}
+
+	extClient, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize k8s extension client with error %v", err)
+	}
+
+	if _, err := extClient.ApiextensionsV1().CustomResourceDefinitions().Get(
+		context.TODO(),
+		PodGroupName,
+		metav1.GetOptions{},
+	); err != nil {
+		return nil, fmt.Errorf("podGroup CRD of scheduler-plugins is required to exist in current cluster. error: %s", err)
+	}
+	return &SchedulerPluginsBatchScheduler{
+		dynamicClient: dynamicClient,
+		log:           logf.Log.WithName("scheduler-plugins"),
+	}, nil
+}
+
+func (sf *SchedulerPluginsBatchSchedulerFactory) AddToScheme(scheme *runtime.Scheme) {
+}
+
+// ConfigureReconciler does not watch PodGroups, because the watches of every registered plugin are added when the batch
+// scheduler is enabled, and the scheduler-plugins CRDs may not be installed.
+func (sf *SchedulerPluginsBatchSchedulerFactory) ConfigureReconciler(b *builder.Builder) *builder.Builder {
+	return b
+}
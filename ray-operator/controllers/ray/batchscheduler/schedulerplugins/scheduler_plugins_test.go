@@ -0,0 +1,131 @@
+package schedulerplugins
+
+import (
+	"context"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/pointer"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func createTestRayCluster() rayv1alpha1.RayCluster {
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ray",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("256m"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	return rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-sample",
+			Namespace: "default",
+		},
+		Spec: rayv1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: podSpec,
+				},
+				Replicas: pointer.Int32Ptr(1),
+			},
+			WorkerGroupSpecs: []rayv1alpha1.WorkerGroupSpec{
+				{
+					Template: corev1.PodTemplateSpec{
+						Spec: podSpec,
+					},
+					Replicas:    pointer.Int32Ptr(2),
+					MinReplicas: pointer.Int32Ptr(1),
+					MaxReplicas: pointer.Int32Ptr(4),
+				},
+			},
+		},
+	}
+}
+
+func TestDoBatchSchedulingOnSubmission(t *testing.T) {
+	a := assert.New(t)
+
+	cluster := createTestRayCluster()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		PodGroupGVR: "PodGroupList",
+	})
+	scheduler := &SchedulerPluginsBatchScheduler{dynamicClient: dynamicClient, log: logf.Log.WithName("scheduler-plugins")}
+	getPodGroup := func() *unstructured.Unstructured {
+		pg, err := dynamicClient.Resource(PodGroupGVR).Namespace("default").Get(context.TODO(), "ray-raycluster-sample-pg", metav1.GetOptions{})
+		a.Nil(err)
+		return pg
+	}
+
+	// 1 head + 2 workers (desired, not min replicas)
+	a.Nil(scheduler.DoBatchSchedulingOnSubmission(&cluster))
+	pg := getPodGroup()
+	a.True(metav1.IsControlledBy(pg, &cluster))
+	minMember, _, _ := unstructured.NestedInt64(pg.Object, "spec", "minMember")
+	a.Equal(int64(3), minMember)
+	minResources, err := getMinResources(pg)
+	a.Nil(err)
+	// 256m * 3 (requests, not limits)
+	a.Equal("768m", minResources.Cpu().String())
+	a.Equal("768Mi", minResources.Memory().String())
+
+	// 1 head + 1 worker (min replicas) when autoscaling is enabled
+	cluster.Spec.EnableInTreeAutoscaling = pointer.BoolPtr(true)
+	a.Nil(scheduler.DoBatchSchedulingOnSubmission(&cluster))
+	pg = getPodGroup()
+	minMember, _, _ = unstructured.NestedInt64(pg.Object, "spec", "minMember")
+	a.Equal(int64(2), minMember)
+	minResources, err = getMinResources(pg)
+	a.Nil(err)
+	a.Equal("512m", minResources.Cpu().String())
+
+	// spec.batchScheduling overrides the min member and sets the scheduling timeout.
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{
+		SchedulerName:          GetPluginName(),
+		MinAvailable:           pointer.Int32Ptr(1),
+		ScheduleTimeoutSeconds: pointer.Int32Ptr(60),
+	}
+	a.Nil(scheduler.DoBatchSchedulingOnSubmission(&cluster))
+	pg = getPodGroup()
+	minMember, _, _ = unstructured.NestedInt64(pg.Object, "spec", "minMember")
+	a.Equal(int64(1), minMember)
+	timeout, _, _ := unstructured.NestedInt64(pg.Object, "spec", "scheduleTimeoutSeconds")
+	a.Equal(int64(60), timeout)
+
+	// The timeout is removed when it is unset.
+	cluster.Spec.BatchScheduling.ScheduleTimeoutSeconds = nil
+	a.Nil(scheduler.DoBatchSchedulingOnSubmission(&cluster))
+	_, found, _ := unstructured.NestedInt64(getPodGroup().Object, "spec", "scheduleTimeoutSeconds")
+	a.False(found)
+}
+
+func TestAddMetadataToPod(t *testing.T) {
+	a := assert.New(t)
+
+	cluster := createTestRayCluster()
+	scheduler := &SchedulerPluginsBatchScheduler{}
+	pod := &corev1.Pod{}
+	scheduler.AddMetadataToPod(&cluster, pod)
+
+	a.Equal("ray-raycluster-sample-pg", pod.Labels[PodGroupLabelKey])
+	a.Equal(SchedulerName, pod.Spec.SchedulerName)
+}
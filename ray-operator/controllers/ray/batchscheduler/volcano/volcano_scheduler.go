@@ -21,7 +21,6 @@ import (
 
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
-	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -49,15 +48,7 @@ func (v *VolcanoBatchScheduler) Name() string {
 }
 
 func (v *VolcanoBatchScheduler) DoBatchSchedulingOnSubmission(app *rayv1alpha1.RayCluster) error {
-	var minMember int32
-	var totalResource corev1.ResourceList
-	if app.Spec.EnableInTreeAutoscaling == nil || !*app.Spec.EnableInTreeAutoscaling {
-		minMember = utils.CalculateDesiredReplicas(app) + *app.Spec.HeadGroupSpec.Replicas
-		totalResource = utils.CalculateDesiredResources(app)
-	} else {
-		minMember = utils.CalculateMinReplicas(app) + *app.Spec.HeadGroupSpec.Replicas
-		totalResource = utils.CalculateMinResources(app)
-	}
+	minMember, totalResource := common.GetGangSchedulingMinMemberAndResources(app)
 
 	if err := v.syncPodGroup(app, minMember, totalResource); err != nil {
 		return err
@@ -126,11 +117,11 @@ func createPodGroup(
 		},
 	}
 
-	if queue, ok := app.ObjectMeta.Labels[QueueNameLabelKey]; ok {
+	if queue := common.GetBatchSchedulingQueue(app, QueueNameLabelKey); queue != "" {
 		podGroup.Spec.Queue = queue
 	}
 
-	if priorityClassName, ok := app.ObjectMeta.Labels[common.RayPriorityClassName]; ok {
+	if priorityClassName := common.GetBatchSchedulingPriorityClassName(app); priorityClassName != "" {
 		podGroup.Spec.PriorityClassName = priorityClassName
 	}
 
@@ -139,10 +130,10 @@ func createPodGroup(
 
 func (v *VolcanoBatchScheduler) AddMetadataToPod(app *rayv1alpha1.RayCluster, pod *corev1.Pod) {
 	pod.Annotations[v1beta1.KubeGroupNameAnnotationKey] = v.getAppPodGroupName(app)
-	if queue, ok := app.ObjectMeta.Labels[QueueNameLabelKey]; ok {
+	if queue := common.GetBatchSchedulingQueue(app, QueueNameLabelKey); queue != "" {
 		pod.Labels[QueueNameLabelKey] = queue
 	}
-	if priorityClassName, ok := app.ObjectMeta.Labels[common.RayPriorityClassName]; ok {
+	if priorityClassName := common.GetBatchSchedulingPriorityClassName(app); priorityClassName != "" {
 		pod.Spec.PriorityClassName = priorityClassName
 	}
 	pod.Spec.SchedulerName = v.Name()
@@ -94,4 +94,14 @@ func TestCreatePodGroup(t *testing.T) {
 
 	// 2 GPUs total
 	a.Equal("2", pg.Spec.MinResources.Name("nvidia.com/gpu", resource.BinarySI).String())
+
+	// The queue and priority class are taken from spec.batchScheduling.
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{
+		SchedulerName:     GetPluginName(),
+		Queue:             "kuberay-test-queue",
+		PriorityClassName: "high-priority",
+	}
+	pg = createPodGroup(&cluster, cluster.ClusterName, minMember, totalResource)
+	a.Equal("kuberay-test-queue", pg.Spec.Queue)
+	a.Equal("high-priority", pg.Spec.PriorityClassName)
 }
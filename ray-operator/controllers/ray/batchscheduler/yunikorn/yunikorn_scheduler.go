@@ -0,0 +1,160 @@
+package yunikorn
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// ApplicationIDLabelKey and QueueLabelKey are read from the RayCluster metadata.
+	ApplicationIDLabelKey = "yunikorn.apache.org/app-id"
+	QueueLabelKey         = "yunikorn.apache.org/queue"
+
+	// PodApplicationIDLabelKey and PodQueueLabelKey are the Pod labels YuniKorn uses to group Pods into applications.
+	PodApplicationIDLabelKey = "applicationId"
+	PodQueueLabelKey         = "queue"
+
+	// TaskGroupNameAnnotationKey and TaskGroupsAnnotationKey declare the task groups YuniKorn gang schedules.
+	TaskGroupNameAnnotationKey = "yunikorn.apache.org/task-group-name"
+	TaskGroupsAnnotationKey    = "yunikorn.apache.org/task-groups"
+	// SchedulingPolicyParametersAnnotationKey sets how long YuniKorn waits for all the task groups to be scheduled.
+	SchedulingPolicyParametersAnnotationKey = "yunikorn.apache.org/schedulingPolicyParameters"
+)
+
+// TaskGroup is a group of Pods with the same resources that YuniKorn reserves resources for with placeholder Pods
+// before any Pod of the application is scheduled.
+type TaskGroup struct {
+	Name         string              `json:"name"`
+	MinMember    int32               `json:"minMember"`
+	MinResource  corev1.ResourceList `json:"minResource"`
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+	Affinity     *corev1.Affinity    `json:"affinity,omitempty"`
+}
+
+type YuniKornBatchScheduler struct {
+	log logr.Logger
+}
+
+type YuniKornBatchSchedulerFactory struct{}
+
+func GetPluginName() string {
+	return "yunikorn"
+}
+
+func (y *YuniKornBatchScheduler) Name() string {
+	return GetPluginName()
+}
+
+// DoBatchSchedulingOnSubmission is a no-op: YuniKorn builds the application from the labels and annotations of its Pods.
+func (y *YuniKornBatchScheduler) DoBatchSchedulingOnSubmission(app *rayv1alpha1.RayCluster) error {
+	return nil
+}
+
+// getApplicationID returns the YuniKorn application of the RayCluster, which defaults to the namespace and name of
+// the RayCluster.
+func getApplicationID(app *rayv1alpha1.RayCluster) string {
+	if applicationID, ok := app.ObjectMeta.Labels[ApplicationIDLabelKey]; ok && applicationID != "" {
+		return applicationID
+	}
+	return fmt.Sprintf("%s-%s", app.Namespace, app.Name)
+}
+
+// getTaskGroups returns a task group for the head group and for each worker group. The minimum members of a worker
+// group are its MinReplicas, so YuniKorn only starts the RayCluster once its minimum size fits in the queue. The
+// resources of a group are read from the Pods KubeRay builds, which include the containers added by KubeRay, such as
+// the autoscaler sidecar. The submitter Pod of a RayJob has its own task group, so its resources are reserved with the
+// RayCluster.
+func getTaskGroups(app *rayv1alpha1.RayCluster) []TaskGroup {
+	// Building the Pods fills in the rayStartParams, so they are built from a copy of the RayCluster.
+	cluster := app.DeepCopy()
+	headSpec := common.BuildHeadPod(*cluster).Spec
+	headReplicas := int32(1)
+	if app.Spec.HeadGroupSpec.Replicas != nil {
+		headReplicas = *app.Spec.HeadGroupSpec.Replicas
+	}
+	taskGroups := []TaskGroup{{
+		Name:         common.HeadGroupName,
+		MinMember:    headReplicas,
+		MinResource:  utils.CalculatePodResource(headSpec),
+		NodeSelector: headSpec.NodeSelector,
+		Tolerations:  headSpec.Tolerations,
+		Affinity:     headSpec.Affinity,
+	}}
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		workerSpec := common.BuildWorkerPod(*cluster, worker).Spec
+		minReplicas := int32(0)
+		if worker.MinReplicas != nil {
+			minReplicas = *worker.MinReplicas
+		}
+		taskGroups = append(taskGroups, TaskGroup{
+			Name:         worker.GroupName,
+			MinMember:    minReplicas,
+			MinResource:  utils.CalculatePodResource(workerSpec),
+			NodeSelector: workerSpec.NodeSelector,
+			Tolerations:  workerSpec.Tolerations,
+			Affinity:     workerSpec.Affinity,
+		})
+	}
+	if submitterResources, ok := common.GetRayJobSubmitterResources(app); ok {
+		taskGroups = append(taskGroups, TaskGroup{
+			Name:        common.RayJobSubmitterGroupName,
+			MinMember:   1,
+			MinResource: submitterResources,
+		})
+	}
+	return taskGroups
+}
+
+func (y *YuniKornBatchScheduler) AddMetadataToPod(app *rayv1alpha1.RayCluster, pod *corev1.Pod) {
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Labels[PodApplicationIDLabelKey] = getApplicationID(app)
+	if queue := common.GetBatchSchedulingQueue(app, QueueLabelKey); queue != "" {
+		pod.Labels[PodQueueLabelKey] = queue
+	}
+	if priorityClassName := common.GetBatchSchedulingPriorityClassName(app); priorityClassName != "" {
+		pod.Spec.PriorityClassName = priorityClassName
+	}
+	if timeout := common.GetBatchSchedulingTimeoutSeconds(app); timeout != nil {
+		pod.Annotations[SchedulingPolicyParametersAnnotationKey] = fmt.Sprintf("placeholderTimeoutInSeconds=%d", *timeout)
+	}
+
+	taskGroups, err := json.Marshal(getTaskGroups(app))
+	if err != nil {
+		y.log.Error(err, "failed to marshal the task groups of the RayCluster", "RayCluster", app.Name)
+	} else {
+		pod.Annotations[TaskGroupNameAnnotationKey] = pod.Labels[common.RayNodeGroupLabelKey]
+		pod.Annotations[TaskGroupsAnnotationKey] = string(taskGroups)
+	}
+	pod.Spec.SchedulerName = y.Name()
+}
+
+func (yf *YuniKornBatchSchedulerFactory) New(config *rest.Config) (schedulerinterface.BatchScheduler, error) {
+	return &YuniKornBatchScheduler{
+		log: logf.Log.WithName("yunikorn"),
+	}, nil
+}
+
+func (yf *YuniKornBatchSchedulerFactory) AddToScheme(scheme *runtime.Scheme) {
+}
+
+func (yf *YuniKornBatchSchedulerFactory) ConfigureReconciler(b *builder.Builder) *builder.Builder {
+	return b
+}
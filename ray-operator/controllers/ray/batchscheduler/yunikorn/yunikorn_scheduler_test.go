@@ -0,0 +1,214 @@
+package yunikorn
+
+import (
+	"encoding/json"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func createTestRayCluster() rayv1alpha1.RayCluster {
+	headSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ray-head",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("256m"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	workerSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "ray-worker",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+						"nvidia.com/gpu":      resource.MustParse("1"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("256m"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+		},
+		NodeSelector: map[string]string{"accelerator": "nvidia-tesla-t4"},
+	}
+
+	return rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-sample",
+			Namespace: "default",
+			Labels: map[string]string{
+				common.RaySchedulerName: GetPluginName(),
+				QueueLabelKey:           "root.ray",
+			},
+		},
+		Spec: rayv1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: headSpec,
+				},
+				Replicas: pointer.Int32Ptr(1),
+			},
+			WorkerGroupSpecs: []rayv1alpha1.WorkerGroupSpec{
+				{
+					GroupName: "gpu-group",
+					Template: corev1.PodTemplateSpec{
+						Spec: workerSpec,
+					},
+					Replicas:    pointer.Int32Ptr(2),
+					MinReplicas: pointer.Int32Ptr(1),
+					MaxReplicas: pointer.Int32Ptr(4),
+				},
+			},
+		},
+	}
+}
+
+func TestAddMetadataToPod(t *testing.T) {
+	a := assert.New(t)
+
+	cluster := createTestRayCluster()
+	scheduler, err := (&YuniKornBatchSchedulerFactory{}).New(nil)
+	a.Nil(err)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{common.RayNodeGroupLabelKey: "gpu-group"},
+		},
+	}
+	scheduler.AddMetadataToPod(&cluster, pod)
+
+	a.Equal(GetPluginName(), pod.Spec.SchedulerName)
+	// The application ID defaults to the namespace and name of the RayCluster.
+	a.Equal("default-raycluster-sample", pod.Labels[PodApplicationIDLabelKey])
+	a.Equal("root.ray", pod.Labels[PodQueueLabelKey])
+	a.Equal("gpu-group", pod.Annotations[TaskGroupNameAnnotationKey])
+
+	var taskGroups []TaskGroup
+	a.Nil(json.Unmarshal([]byte(pod.Annotations[TaskGroupsAnnotationKey]), &taskGroups))
+	a.Len(taskGroups, 2)
+
+	a.Equal(common.HeadGroupName, taskGroups[0].Name)
+	a.Equal(int32(1), taskGroups[0].MinMember)
+	// requests, not limits
+	a.Equal("256m", taskGroups[0].MinResource.Cpu().String())
+	a.Equal("256Mi", taskGroups[0].MinResource.Memory().String())
+
+	a.Equal("gpu-group", taskGroups[1].Name)
+	// min replicas, not desired replicas
+	a.Equal(int32(1), taskGroups[1].MinMember)
+	a.Equal("256m", taskGroups[1].MinResource.Cpu().String())
+	a.Equal("256Mi", taskGroups[1].MinResource.Memory().String())
+	// limits are used for resources without requests
+	a.Equal("1", taskGroups[1].MinResource.Name("nvidia.com/gpu", resource.BinarySI).String())
+	a.Equal(map[string]string{"accelerator": "nvidia-tesla-t4"}, taskGroups[1].NodeSelector)
+}
+
+func TestGetTaskGroupsFromBuiltPods(t *testing.T) {
+	a := assert.New(t)
+
+	// The autoscaler sidecar added by KubeRay is part of the resources of the head Pod.
+	cluster := createTestRayCluster()
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(true)
+	taskGroups := getTaskGroups(&cluster)
+	a.Equal("756m", taskGroups[0].MinResource.Cpu().String())
+	a.Equal("768Mi", taskGroups[0].MinResource.Memory().String())
+
+	// An init container larger than the containers sets the resources of the Pod.
+	cluster = createTestRayCluster()
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.InitContainers = []corev1.Container{{
+		Name: "download-model",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		},
+	}}
+	taskGroups = getTaskGroups(&cluster)
+	a.Equal("2", taskGroups[1].MinResource.Cpu().String())
+	a.Equal("256Mi", taskGroups[1].MinResource.Memory().String())
+}
+
+func TestAddMetadataToPodWithApplicationID(t *testing.T) {
+	a := assert.New(t)
+
+	cluster := createTestRayCluster()
+	cluster.Labels[ApplicationIDLabelKey] = "ray-app-0001"
+	delete(cluster.Labels, QueueLabelKey)
+	scheduler, err := (&YuniKornBatchSchedulerFactory{}).New(nil)
+	a.Nil(err)
+
+	pod := &corev1.Pod{}
+	scheduler.AddMetadataToPod(&cluster, pod)
+
+	a.Equal("ray-app-0001", pod.Labels[PodApplicationIDLabelKey])
+	_, ok := pod.Labels[PodQueueLabelKey]
+	a.False(ok)
+}
+
+func TestAddMetadataToPodWithBatchScheduling(t *testing.T) {
+	a := assert.New(t)
+
+	cluster := createTestRayCluster()
+	cluster.Labels = nil
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{
+		SchedulerName:          GetPluginName(),
+		Queue:                  "root.batch",
+		PriorityClassName:      "high-priority",
+		ScheduleTimeoutSeconds: pointer.Int32Ptr(120),
+	}
+	scheduler, err := (&YuniKornBatchSchedulerFactory{}).New(nil)
+	a.Nil(err)
+
+	pod := &corev1.Pod{}
+	scheduler.AddMetadataToPod(&cluster, pod)
+
+	a.Equal("root.batch", pod.Labels[PodQueueLabelKey])
+	a.Equal("high-priority", pod.Spec.PriorityClassName)
+	a.Equal("placeholderTimeoutInSeconds=120", pod.Annotations[SchedulingPolicyParametersAnnotationKey])
+}
+
+func TestAddMetadataToPodWithRayJobSubmitter(t *testing.T) {
+	a := assert.New(t)
+
+	cluster := createTestRayCluster()
+	a.Nil(common.SetRayJobSubmitterResources(&cluster, corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "ray-job-submitter",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				},
+			}},
+		},
+	}))
+	scheduler, err := (&YuniKornBatchSchedulerFactory{}).New(nil)
+	a.Nil(err)
+
+	pod := &corev1.Pod{}
+	scheduler.AddMetadataToPod(&cluster, pod)
+
+	var taskGroups []TaskGroup
+	a.Nil(json.Unmarshal([]byte(pod.Annotations[TaskGroupsAnnotationKey]), &taskGroups))
+	a.Len(taskGroups, 3)
+	a.Equal(common.RayJobSubmitterGroupName, taskGroups[2].Name)
+	a.Equal(int32(1), taskGroups[2].MinMember)
+	a.Equal("500m", taskGroups[2].MinResource.Cpu().String())
+}
@@ -0,0 +1,419 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultAutoscalerIdleTimeoutSeconds is the idle timeout used when spec.autoscalerOptions.idleTimeoutSeconds is not set.
+	DefaultAutoscalerIdleTimeoutSeconds = 60
+	// OperatorAutoscalingPeriod is how often the operator autoscaling loop runs for a RayCluster.
+	OperatorAutoscalingPeriod = 5 * time.Second
+
+	// The upscaling speeds of the upscaling modes, defined as in the Ray autoscaler: the number of pending worker Pods
+	// is at most max(minPendingWorkers, upscalingSpeed * number of Ray nodes).
+	conservativeUpscalingSpeed = 1
+	defaultUpscalingSpeed      = 1000
+	minPendingWorkers          = 5
+
+	// resourceEpsilon absorbs the rounding errors of the float resource amounts reported by Ray.
+	resourceEpsilon = 1e-6
+)
+
+// IsOperatorAutoscalingEnabled returns true if in-tree autoscaling is enabled for the RayCluster and the autoscaling
+// loop runs in the KubeRay operator instead of the autoscaler sidecar of the head Pod.
+func IsOperatorAutoscalingEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling &&
+		instance.Spec.AutoscalerOptions != nil && instance.Spec.AutoscalerOptions.Type != nil &&
+		*instance.Spec.AutoscalerOptions.Type == rayv1alpha1.OperatorAutoscaler
+}
+
+// ValidateAutoscalerOptions makes sure the autoscaling options only read by the KubeRay operator are not set unless
+// spec.autoscalerOptions.type is "Operator". The Ray autoscaler of the sidecar and of the Deployment ignores them.
+func ValidateAutoscalerOptions(instance rayv1alpha1.RayCluster) error {
+	if IsOperatorAutoscalingEnabled(instance) {
+		return nil
+	}
+	if instance.Spec.AutoscalerOptions != nil && instance.Spec.AutoscalerOptions.UpscalingSpeed != nil {
+		return fmt.Errorf("spec.autoscalerOptions.upscalingSpeed requires spec.autoscalerOptions.type %s", rayv1alpha1.OperatorAutoscaler)
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.IdleTimeoutSeconds != nil {
+			return fmt.Errorf("the idleTimeoutSeconds of worker group %s requires spec.autoscalerOptions.type %s, use spec.autoscalerOptions.idleTimeoutSeconds instead",
+				worker.GroupName, rayv1alpha1.OperatorAutoscaler)
+		}
+		if worker.ScaleDownProtection != nil {
+			return fmt.Errorf("the scaleDownProtection of worker group %s requires spec.autoscalerOptions.type %s", worker.GroupName, rayv1alpha1.OperatorAutoscaler)
+		}
+	}
+	return nil
+}
+
+// GetAutoscalerIdleTimeout returns how long a worker Pod must be idle before the autoscaler removes it.
+func GetAutoscalerIdleTimeout(instance rayv1alpha1.RayCluster) time.Duration {
+	if instance.Spec.AutoscalerOptions != nil && instance.Spec.AutoscalerOptions.IdleTimeoutSeconds != nil {
+		return time.Duration(*instance.Spec.AutoscalerOptions.IdleTimeoutSeconds) * time.Second
+	}
+	return DefaultAutoscalerIdleTimeoutSeconds * time.Second
+}
+
+// GetWorkerGroupIdleTimeout returns how long a worker Pod of the group must be idle before the autoscaler removes it.
+// The idle timeout of the group takes precedence over the one of spec.autoscalerOptions.
+func GetWorkerGroupIdleTimeout(instance rayv1alpha1.RayCluster, worker rayv1alpha1.WorkerGroupSpec) time.Duration {
+	if worker.IdleTimeoutSeconds != nil {
+		return time.Duration(*worker.IdleTimeoutSeconds) * time.Second
+	}
+	return GetAutoscalerIdleTimeout(instance)
+}
+
+// IsScaleDownProtected returns true if the autoscaler must not remove the idle worker Pods of the group.
+func IsScaleDownProtected(worker rayv1alpha1.WorkerGroupSpec) bool {
+	return worker.ScaleDownProtection != nil && *worker.ScaleDownProtection
+}
+
+func getUpscalingSpeed(instance rayv1alpha1.RayCluster) float64 {
+	if instance.Spec.AutoscalerOptions == nil {
+		return defaultUpscalingSpeed
+	}
+	if instance.Spec.AutoscalerOptions.UpscalingSpeed != nil {
+		return math.Max(*instance.Spec.AutoscalerOptions.UpscalingSpeed, 0)
+	}
+	if instance.Spec.AutoscalerOptions.UpscalingMode != nil && *instance.Spec.AutoscalerOptions.UpscalingMode == "Conservative" {
+		return conservativeUpscalingSpeed
+	}
+	return defaultUpscalingSpeed
+}
+
+// GetWorkerGroupRayResources returns the Ray resources of a node of the worker group. As in the `ray start` command
+// built by the operator, rayStartParams take precedence over the resource limits of the Ray container.
+func GetWorkerGroupRayResources(worker rayv1alpha1.WorkerGroupSpec) map[string]float64 {
+	resources := map[string]float64{}
+	var limits v1.ResourceList
+	if len(worker.Template.Spec.Containers) > RayContainerIndex {
+		limits = worker.Template.Spec.Containers[RayContainerIndex].Resources.Limits
+	}
+
+	if value, ok := parseRayStartParamFloat(worker.RayStartParams, "num-cpus"); ok {
+		resources["CPU"] = value
+	} else if cpu, ok := limits[v1.ResourceCPU]; ok && !cpu.IsZero() {
+		resources["CPU"] = float64(cpu.Value())
+	}
+
+	if value, ok := parseRayStartParamFloat(worker.RayStartParams, "num-gpus"); ok {
+		resources["GPU"] = value
+	} else {
+		for resourceKey, quantity := range limits {
+			if strings.HasSuffix(string(resourceKey), "gpu") && !quantity.IsZero() {
+				resources["GPU"] = float64(quantity.Value())
+				break
+			}
+		}
+	}
+
+	if value, ok := parseRayStartParamFloat(worker.RayStartParams, "memory"); ok {
+		resources["memory"] = value
+	} else if memory, ok := limits[v1.ResourceMemory]; ok && !memory.IsZero() {
+		resources["memory"] = float64(memory.Value())
+	}
+
+	if value, ok := worker.RayStartParams["resources"]; ok {
+		// The custom resources are passed to `ray start` as a quoted JSON object, e.g. '"{\"Custom1\": 1}"'.
+		value = strings.ReplaceAll(strings.Trim(value, `'"`), `\"`, `"`)
+		custom := map[string]float64{}
+		if err := json.Unmarshal([]byte(value), &custom); err == nil {
+			for name, amount := range custom {
+				resources[name] = amount
+			}
+		}
+	}
+
+	for name, amount := range resources {
+		if amount <= 0 {
+			delete(resources, name)
+		}
+	}
+	return resources
+}
+
+func parseRayStartParamFloat(rayStartParams map[string]string, key string) (float64, bool) {
+	value, ok := rayStartParams[key]
+	if !ok {
+		return 0, false
+	}
+	parsed, err := strconv.ParseFloat(strings.Trim(value, `'"`), 64)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// IsRayNodeIdle returns true if a Ray node does not use any of its resources. The node-specific resources and the
+// object store memory are not taken into account.
+func IsRayNodeIdle(usage map[string][2]float64) bool {
+	for name, usedAndTotal := range usage {
+		if strings.HasPrefix(name, "node:") || name == "object_store_memory" {
+			continue
+		}
+		if usedAndTotal[0] > resourceEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateIdleWorkers returns the time at which each idle worker Pod was first seen idle. idleSince holds the result of
+// the previous round; Pods that are busy or not part of the Ray cluster yet are dropped.
+func UpdateIdleWorkers(clusterStatus *utils.ClusterStatus, workerPods []v1.Pod, idleSince map[string]time.Time, now time.Time) map[string]time.Time {
+	updated := map[string]time.Time{}
+	for _, pod := range workerPods {
+		usage, ok := clusterStatus.LoadMetricsReport.UsageByNode[pod.Status.PodIP]
+		if pod.Status.PodIP == "" || !ok || !IsRayNodeIdle(usage) {
+			continue
+		}
+		if since, ok := idleSince[pod.Name]; ok {
+			updated[pod.Name] = since
+		} else {
+			updated[pod.Name] = now
+		}
+	}
+	return updated
+}
+
+// WorkerGroupScaling is the target of a worker group computed by the operator autoscaling loop.
+type WorkerGroupScaling struct {
+	Replicas        int32
+	WorkersToDelete []string
+}
+
+// autoscalingNode is the free capacity of a Ray node, or of a worker Pod that has not joined the Ray cluster yet.
+type autoscalingNode struct {
+	podName   string
+	available map[string]float64
+	// used is set when a pending resource demand is assigned to the node in this round.
+	used bool
+}
+
+type autoscalingGroup struct {
+	spec      rayv1alpha1.WorkerGroupSpec
+	resources map[string]float64
+	replicas  int32
+	added     int32
+}
+
+// ComputeWorkerGroupScaling computes the target of every worker group of the RayCluster from the resource demand and
+// the resource usage reported by the Ray monitor.
+//
+// The pending resource demand is first packed onto the free capacity of the Ray nodes and of the worker Pods that have
+// not joined the cluster yet. The demand that does not fit adds worker Pods to the first worker group, in the order of
+// spec.workerGroupSpecs, whose Pods can hold it and that has not reached maxReplicas; groups with GPUs are only used for
+// demand without GPUs if no other group fits. The number of pending worker Pods is capped according to the upscaling
+// speed. Worker Pods that have been idle for the idle timeout of their group, according to idleSince, are removed as
+// long as their group stays at or above minReplicas and is not protected from scaling down.
+//
+// workerPods are the worker Pods of the RayCluster. The result is keyed by the worker group name.
+func ComputeWorkerGroupScaling(instance rayv1alpha1.RayCluster, clusterStatus *utils.ClusterStatus, workerPods []v1.Pod, idleSince map[string]time.Time, now time.Time) map[string]WorkerGroupScaling {
+	report := clusterStatus.LoadMetricsReport
+	groups := make([]*autoscalingGroup, 0, len(instance.Spec.WorkerGroupSpecs))
+	groupsByName := map[string]*autoscalingGroup{}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		group := &autoscalingGroup{spec: worker, resources: GetWorkerGroupRayResources(worker)}
+		if worker.Replicas != nil {
+			group.replicas = *worker.Replicas
+		}
+		groups = append(groups, group)
+		groupsByName[worker.GroupName] = group
+	}
+
+	// The free capacity of the Ray nodes, including the head, and of the worker Pods that are still starting.
+	var nodes []*autoscalingNode
+	nodesByPod := map[string]*autoscalingNode{}
+	podIPs := map[string]bool{}
+	numPendingWorkers := 0
+	numWorkersByGroup := map[string]int32{}
+	for _, pod := range workerPods {
+		group, ok := groupsByName[pod.Labels[RayNodeGroupLabelKey]]
+		if !ok || !pod.DeletionTimestamp.IsZero() || utils.Contains(group.spec.ScaleStrategy.WorkersToDelete, pod.Name) {
+			continue
+		}
+		numWorkersByGroup[group.spec.GroupName]++
+		node := &autoscalingNode{podName: pod.Name, available: map[string]float64{}}
+		if usage, ok := report.UsageByNode[pod.Status.PodIP]; ok && pod.Status.PodIP != "" {
+			podIPs[pod.Status.PodIP] = true
+			for name, usedAndTotal := range usage {
+				node.available[name] = usedAndTotal[1] - usedAndTotal[0]
+			}
+		} else {
+			numPendingWorkers++
+			for name, amount := range group.resources {
+				node.available[name] = amount
+			}
+		}
+		nodes = append(nodes, node)
+		nodesByPod[pod.Name] = node
+	}
+	for ip, usage := range report.UsageByNode {
+		if podIPs[ip] {
+			continue
+		}
+		node := &autoscalingNode{available: map[string]float64{}}
+		for name, usedAndTotal := range usage {
+			node.available[name] = usedAndTotal[1] - usedAndTotal[0]
+		}
+		nodes = append(nodes, node)
+	}
+	// The worker Pods that the operator has not created yet.
+	for _, group := range groups {
+		for i := numWorkersByGroup[group.spec.GroupName]; i < group.replicas; i++ {
+			numPendingWorkers++
+			node := &autoscalingNode{available: map[string]float64{}}
+			for name, amount := range group.resources {
+				node.available[name] = amount
+			}
+			nodes = append(nodes, node)
+		}
+	}
+
+	maxPendingWorkers := int(math.Max(minPendingWorkers, math.Ceil(getUpscalingSpeed(instance)*float64(len(report.UsageByNode)))))
+	launchBudget := maxPendingWorkers - numPendingWorkers
+
+	for _, bundle := range getResourceDemandBundles(report) {
+		if placeBundle(nodes, bundle) {
+			continue
+		}
+		if launchBudget <= 0 {
+			continue
+		}
+		group := pickWorkerGroup(groups, bundle)
+		if group == nil {
+			// The demand is infeasible for every worker group that can still scale up.
+			continue
+		}
+		node := &autoscalingNode{available: map[string]float64{}, used: true}
+		for name, amount := range group.resources {
+			node.available[name] = amount
+		}
+		subtractResources(node.available, bundle)
+		nodes = append(nodes, node)
+		group.added++
+		launchBudget--
+	}
+
+	result := map[string]WorkerGroupScaling{}
+	for _, group := range groups {
+		replicas := group.replicas + group.added
+		if group.spec.MaxReplicas != nil && replicas > *group.spec.MaxReplicas {
+			replicas = *group.spec.MaxReplicas
+		}
+		if group.spec.MinReplicas != nil && replicas < *group.spec.MinReplicas {
+			replicas = *group.spec.MinReplicas
+		}
+
+		var workersToDelete []string
+		if group.added == 0 && !IsScaleDownProtected(group.spec) {
+			idleTimeout := GetWorkerGroupIdleTimeout(instance, group.spec)
+			for _, pod := range workerPods {
+				if pod.Labels[RayNodeGroupLabelKey] != group.spec.GroupName {
+					continue
+				}
+				node, ok := nodesByPod[pod.Name]
+				since, idle := idleSince[pod.Name]
+				if !ok || node.used || !idle || now.Sub(since) < idleTimeout {
+					continue
+				}
+				if group.spec.MinReplicas != nil && replicas <= *group.spec.MinReplicas {
+					break
+				}
+				workersToDelete = append(workersToDelete, pod.Name)
+				replicas--
+			}
+		}
+		result[group.spec.GroupName] = WorkerGroupScaling{Replicas: replicas, WorkersToDelete: workersToDelete}
+	}
+	return result
+}
+
+// getResourceDemandBundles flattens the pending tasks, actors, and placement groups into resource bundles, the
+// largest first.
+func getResourceDemandBundles(report utils.LoadMetricsReport) []map[string]float64 {
+	var bundles []map[string]float64
+	for _, demand := range report.ResourceDemand {
+		for i := 0; i < demand.Count; i++ {
+			bundles = append(bundles, demand.Resources)
+		}
+	}
+	for _, placementGroup := range report.PlacementGroupDemand {
+		for i := 0; i < placementGroup.Count; i++ {
+			for _, bundle := range placementGroup.Bundles {
+				for j := 0; j < bundle.Count; j++ {
+					bundles = append(bundles, bundle.Resources)
+				}
+			}
+		}
+	}
+	sort.SliceStable(bundles, func(i, j int) bool {
+		for _, name := range []string{"GPU", "CPU", "memory"} {
+			if bundles[i][name] != bundles[j][name] {
+				return bundles[i][name] > bundles[j][name]
+			}
+		}
+		return false
+	})
+	return bundles
+}
+
+// placeBundle assigns the bundle to the first node that has enough free capacity.
+func placeBundle(nodes []*autoscalingNode, bundle map[string]float64) bool {
+	for _, node := range nodes {
+		if fitsResources(node.available, bundle) {
+			subtractResources(node.available, bundle)
+			node.used = true
+			return true
+		}
+	}
+	return false
+}
+
+func pickWorkerGroup(groups []*autoscalingGroup, bundle map[string]float64) *autoscalingGroup {
+	var gpuGroup *autoscalingGroup
+	for _, group := range groups {
+		if group.spec.MaxReplicas != nil && group.replicas+group.added >= *group.spec.MaxReplicas {
+			continue
+		}
+		if !fitsResources(group.resources, bundle) {
+			continue
+		}
+		if bundle["GPU"] == 0 && group.resources["GPU"] > 0 {
+			if gpuGroup == nil {
+				gpuGroup = group
+			}
+			continue
+		}
+		return group
+	}
+	return gpuGroup
+}
+
+func fitsResources(available map[string]float64, bundle map[string]float64) bool {
+	for name, amount := range bundle {
+		if amount > available[name]+resourceEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+func subtractResources(available map[string]float64, bundle map[string]float64) {
+	for name, amount := range bundle {
+		available[name] -= amount
+	}
+}
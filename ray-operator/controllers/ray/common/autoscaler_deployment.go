@@ -0,0 +1,140 @@
+package common
+
+import (
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// autoscalerPodIPEnv is the environment variable holding the IP of the standalone autoscaler Pod.
+const autoscalerPodIPEnv = "POD_IP"
+
+// The `ray kuberay-autoscaler` command of the sidecar connects to the GCS on the IP of its own Pod and on port 6379. The
+// standalone autoscaler runs the same monitor with the GCS address of the head service from RAY_ADDRESS instead, once
+// `ray health-check` reports that the GCS is ready.
+const standaloneAutoscalerScript = `import os
+import subprocess
+import time
+from ray.autoscaler._private.kuberay.autoscaling_config import AutoscalingConfigProducer
+from ray.autoscaler._private.monitor import Monitor
+address = os.environ["` + RAY_ADDRESS + `"]
+while subprocess.call(["ray", "health-check", "--address", address, "--skip-version-check"]) != 0:
+    time.sleep(5)
+Monitor(
+    address=address,
+    autoscaling_config=AutoscalingConfigProducer(os.environ["` + RAY_CLUSTER_NAME + `"], os.environ["RAY_CLUSTER_NAMESPACE"]),
+    monitor_ip=os.environ["` + autoscalerPodIPEnv + `"],
+    retry_on_failure=False,
+).run()
+`
+
+// IsDeploymentAutoscalerEnabled returns true if in-tree autoscaling is enabled for the RayCluster and the Ray autoscaler
+// runs in a Deployment owned by the RayCluster instead of a sidecar of the head Pod.
+func IsDeploymentAutoscalerEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling &&
+		instance.Spec.AutoscalerOptions != nil && instance.Spec.AutoscalerOptions.Type != nil &&
+		*instance.Spec.AutoscalerOptions.Type == rayv1alpha1.DeploymentAutoscaler
+}
+
+// BuildAutoscalerDeployment builds the Deployment running the Ray autoscaler of the RayCluster. It runs one replica, or
+// none while the RayCluster is hibernated or preempted so that the autoscaler does not scale the worker groups back up. The Pods
+// are not labeled with ray.io/cluster, which selects the Ray Pods of the RayCluster. When TLS is enabled, the Pods mount
+// the certificate Secret of the autoscaler, and tlsCertIssueTime rolls them out when the certificate is renewed.
+func BuildAutoscalerDeployment(instance rayv1alpha1.RayCluster, tlsCertIssueTime string) (*appsv1.Deployment, error) {
+	podLabels := map[string]string{
+		RayAutoscalerLabelKey:             instance.Name,
+		KubernetesApplicationNameLabelKey: ApplicationName,
+		KubernetesCreatedByLabelKey:       ComponentName,
+	}
+	headTemplate := instance.Spec.HeadGroupSpec.Template
+
+	// Use the same image as Ray head container by default.
+	container := BuildAutoscalerContainer(headTemplate.Spec.Containers[RayContainerIndex].Image)
+	container.Env = []v1.EnvVar{
+		{Name: RAY_CLUSTER_NAME, Value: instance.Name},
+		{
+			Name: "RAY_CLUSTER_NAMESPACE",
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+			},
+		},
+		{
+			Name: autoscalerPodIPEnv,
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.podIP"},
+			},
+		},
+		{
+			Name:  RAY_ADDRESS,
+			Value: utils.GenerateFQDNServiceName(instance, instance.Namespace) + ":" + GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams),
+		},
+	}
+	container.Command = []string{"python", "-c", standaloneAutoscalerScript}
+	container.Args = nil
+	if instance.Spec.AutoscalerOptions != nil {
+		// The volumes the VolumeMounts refer to only exist in the head Pod.
+		options := *instance.Spec.AutoscalerOptions
+		options.VolumeMounts = nil
+		mergeAutoscalerOverrides(&container, &options)
+	}
+	// The Ray autoscaler writes its logs under /tmp/ray.
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath})
+
+	template := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+		Spec: v1.PodSpec{
+			// utils.CheckName clips the name to match the behavior of reconcileAutoscalerServiceAccount
+			ServiceAccountName: utils.CheckName(utils.GetHeadGroupServiceAccountName(&instance)),
+			ImagePullSecrets:   headTemplate.Spec.ImagePullSecrets,
+			Volumes: []v1.Volume{{
+				Name:         RayLogVolumeName,
+				VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+			}},
+		},
+	}
+	if IsTLSEnabled(instance) {
+		// The certificate of the autoscaler is a client certificate, so it does not depend on the IP of the Pod and is
+		// issued before the Pod is created.
+		template.Annotations = map[string]string{RayTLSNodeCertIssuedAnnotationKey: tlsCertIssueTime}
+		template.Spec.Volumes = append(template.Spec.Volumes, v1.Volume{
+			Name: RayTLSVolumeName,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
+				SecretName: utils.GenerateAutoscalerTLSSecretName(instance.Name),
+			}},
+		})
+		addTLSVolumeMounts(&container, []v1.VolumeMount{{Name: RayTLSVolumeName, MountPath: RayTLSVolumeMountPath, ReadOnly: true}})
+		setTLSEnvVars(&container)
+	}
+	template.Spec.Containers = []v1.Container{container}
+	templateHash, err := utils.GenerateJsonHash(template)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := int32(1)
+	if IsWorkerScalingSuspended(instance) {
+		replicas = 0
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateAutoscalerDeploymentName(instance.Name),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                instance.Name,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+			Annotations: map[string]string{RayAutoscalerTemplateHashAnnotationKey: templateHash},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{RayAutoscalerLabelKey: instance.Name}},
+			// Two autoscalers must not scale the RayCluster at the same time.
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: template,
+		},
+	}, nil
+}
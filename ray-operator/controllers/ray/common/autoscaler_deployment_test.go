@@ -0,0 +1,123 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func deploymentAutoscaledCluster() *rayv1alpha1.RayCluster {
+	cluster := instance.DeepCopy()
+	deploymentAutoscaler := rayv1alpha1.DeploymentAutoscaler
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(true)
+	cluster.Spec.AutoscalerOptions = &rayv1alpha1.AutoscalerOptions{Type: &deploymentAutoscaler}
+	return cluster
+}
+
+func TestIsDeploymentAutoscalerEnabled(t *testing.T) {
+	cluster := deploymentAutoscaledCluster()
+	assert.True(t, IsDeploymentAutoscalerEnabled(*cluster))
+	assert.False(t, IsOperatorAutoscalingEnabled(*cluster))
+
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(false)
+	assert.False(t, IsDeploymentAutoscalerEnabled(*cluster))
+
+	cluster = deploymentAutoscaledCluster()
+	cluster.Spec.AutoscalerOptions.Type = nil
+	assert.False(t, IsDeploymentAutoscalerEnabled(*cluster))
+}
+
+func TestDefaultHeadPodTemplateWithDeploymentAutoscaler(t *testing.T) {
+	cluster := deploymentAutoscaledCluster()
+	podName := strings.ToLower(cluster.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
+
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	for _, container := range podTemplateSpec.Spec.Containers {
+		assert.NotEqual(t, AutoscalerContainerName, container.Name)
+	}
+	assert.Empty(t, podTemplateSpec.Spec.ServiceAccountName)
+	// The standalone autoscaler replaces the monitor process of the head.
+	assert.Equal(t, "true", cluster.Spec.HeadGroupSpec.RayStartParams["no-monitor"])
+}
+
+func TestBuildAutoscalerDeployment(t *testing.T) {
+	cluster := deploymentAutoscaledCluster()
+	cluster.Spec.HeadGroupSpec.Template.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: "registry"}}
+	pullPolicy := v1.PullAlways
+	cluster.Spec.AutoscalerOptions.ImagePullPolicy = &pullPolicy
+	cluster.Spec.AutoscalerOptions.Env = []v1.EnvVar{{Name: "AUTOSCALER_LOG_LEVEL", Value: "debug"}}
+	cluster.Spec.AutoscalerOptions.VolumeMounts = []v1.VolumeMount{{Name: "ca-tls", MountPath: "/etc/ca/tls"}}
+
+	deployment, err := BuildAutoscalerDeployment(*cluster, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster-sample-autoscaler", deployment.Name)
+	assert.Equal(t, cluster.Namespace, deployment.Namespace)
+	assert.Equal(t, cluster.Name, deployment.Labels[RayClusterLabelKey])
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	assert.Equal(t, map[string]string{RayAutoscalerLabelKey: cluster.Name}, deployment.Spec.Selector.MatchLabels)
+	assert.Equal(t, cluster.Name, deployment.Spec.Template.Labels[RayAutoscalerLabelKey])
+	_, ok := deployment.Spec.Template.Labels[RayClusterLabelKey]
+	assert.False(t, ok)
+	assert.NotEmpty(t, deployment.Annotations[RayAutoscalerTemplateHashAnnotationKey])
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, cluster.Name, podSpec.ServiceAccountName)
+	assert.Equal(t, cluster.Spec.HeadGroupSpec.Template.Spec.ImagePullSecrets, podSpec.ImagePullSecrets)
+	assert.Equal(t, 1, len(podSpec.Containers))
+	container := podSpec.Containers[0]
+	assert.Equal(t, AutoscalerContainerName, container.Name)
+	assert.Equal(t, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex].Image, container.Image)
+	assert.Equal(t, v1.PullAlways, container.ImagePullPolicy)
+	assert.Equal(t, []string{"python", "-c", standaloneAutoscalerScript}, container.Command)
+	assert.Equal(t, v1.EnvVar{Name: RAY_CLUSTER_NAME, Value: cluster.Name}, container.Env[0])
+	assert.Equal(t, autoscalerPodIPEnv, container.Env[2].Name)
+	// The GCS port of the head group is used.
+	assert.Equal(t, v1.EnvVar{Name: RAY_ADDRESS, Value: utils.GenerateFQDNServiceName(*cluster, cluster.Namespace) + ":6379"}, container.Env[3])
+	assert.Equal(t, "AUTOSCALER_LOG_LEVEL", container.Env[4].Name)
+	assert.NotContains(t, standaloneAutoscalerScript, "get_node_ip_address")
+	// The VolumeMounts of autoscalerOptions refer to volumes of the head Pod.
+	assert.Equal(t, []v1.VolumeMount{{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath}}, container.VolumeMounts)
+	assert.Equal(t, RayLogVolumeName, podSpec.Volumes[0].Name)
+
+	// The hash of the Pod template changes with the autoscaler image.
+	cluster.Spec.AutoscalerOptions.Image = pointer.String("rayproject/ray:nightly")
+	updated, err := BuildAutoscalerDeployment(*cluster, "")
+	assert.Nil(t, err)
+	assert.NotEqual(t, deployment.Annotations[RayAutoscalerTemplateHashAnnotationKey], updated.Annotations[RayAutoscalerTemplateHashAnnotationKey])
+
+	// The autoscaler does not run while the RayCluster is hibernated.
+	cluster.Annotations = map[string]string{RayClusterHibernatedReplicasAnnotationKey: "{}"}
+	hibernated, err := BuildAutoscalerDeployment(*cluster, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), *hibernated.Spec.Replicas)
+	assert.Equal(t, updated.Annotations, hibernated.Annotations)
+}
+
+func TestBuildAutoscalerDeploymentWithTLS(t *testing.T) {
+	cluster := deploymentAutoscaledCluster()
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{}
+
+	deployment, err := BuildAutoscalerDeployment(*cluster, "2023-10-01T00:00:00Z")
+	assert.Nil(t, err)
+	template := deployment.Spec.Template
+	assert.Equal(t, "2023-10-01T00:00:00Z", template.Annotations[RayTLSNodeCertIssuedAnnotationKey])
+	volume := template.Spec.Volumes[len(template.Spec.Volumes)-1]
+	assert.Equal(t, RayTLSVolumeName, volume.Name)
+	assert.Equal(t, utils.GenerateAutoscalerTLSSecretName(cluster.Name), volume.Secret.SecretName)
+	container := template.Spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, v1.VolumeMount{Name: RayTLSVolumeName, MountPath: RayTLSVolumeMountPath, ReadOnly: true})
+	assert.True(t, envVarExists(RAY_USE_TLS, container.Env))
+	assert.True(t, envVarExists(RAY_TLS_CA_CERT, container.Env))
+
+	// A renewed certificate rolls out the Deployment.
+	renewed, err := BuildAutoscalerDeployment(*cluster, "2023-10-21T00:00:00Z")
+	assert.Nil(t, err)
+	assert.NotEqual(t, deployment.Annotations[RayAutoscalerTemplateHashAnnotationKey], renewed.Annotations[RayAutoscalerTemplateHashAnnotationKey])
+}
@@ -0,0 +1,346 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func operatorAutoscaledCluster() rayv1alpha1.RayCluster {
+	operatorAutoscaler := rayv1alpha1.OperatorAutoscaler
+	workerGroup := func(name string, limits v1.ResourceList, minReplicas, replicas, maxReplicas int32) rayv1alpha1.WorkerGroupSpec {
+		return rayv1alpha1.WorkerGroupSpec{
+			GroupName:      name,
+			MinReplicas:    pointer.Int32(minReplicas),
+			Replicas:       pointer.Int32(replicas),
+			MaxReplicas:    pointer.Int32(maxReplicas),
+			RayStartParams: map[string]string{},
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "ray-worker", Resources: v1.ResourceRequirements{Limits: limits}}},
+				},
+			},
+		}
+	}
+	return rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster-autoscaler", Namespace: "default"},
+		Spec: rayv1alpha1.RayClusterSpec{
+			EnableInTreeAutoscaling: pointer.Bool(true),
+			AutoscalerOptions:       &rayv1alpha1.AutoscalerOptions{Type: &operatorAutoscaler},
+			WorkerGroupSpecs: []rayv1alpha1.WorkerGroupSpec{
+				workerGroup("cpu-group", v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}, 0, 0, 10),
+				workerGroup("gpu-group", v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("1")}, 0, 0, 2),
+			},
+		},
+	}
+}
+
+func autoscalerWorkerPod(name string, group string, podIP string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{RayNodeGroupLabelKey: group}},
+		Status:     v1.PodStatus{PodIP: podIP},
+	}
+}
+
+func TestIsOperatorAutoscalingEnabled(t *testing.T) {
+	cluster := operatorAutoscaledCluster()
+	assert.True(t, IsOperatorAutoscalingEnabled(cluster))
+
+	sidecarAutoscaler := rayv1alpha1.SidecarAutoscaler
+	cluster.Spec.AutoscalerOptions.Type = &sidecarAutoscaler
+	assert.False(t, IsOperatorAutoscalingEnabled(cluster))
+
+	cluster = operatorAutoscaledCluster()
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(false)
+	assert.False(t, IsOperatorAutoscalingEnabled(cluster))
+}
+
+func TestValidateAutoscalerOptions(t *testing.T) {
+	cluster := operatorAutoscaledCluster()
+	cluster.Spec.AutoscalerOptions.UpscalingSpeed = pointer.Float64(2)
+	cluster.Spec.WorkerGroupSpecs[0].IdleTimeoutSeconds = pointer.Int32(600)
+	cluster.Spec.WorkerGroupSpecs[1].ScaleDownProtection = pointer.Bool(true)
+	assert.Nil(t, ValidateAutoscalerOptions(cluster))
+
+	// The Ray autoscaler of the sidecar and of the Deployment ignores the options of the operator autoscaler.
+	for _, autoscalerType := range []rayv1alpha1.AutoscalerType{rayv1alpha1.SidecarAutoscaler, rayv1alpha1.DeploymentAutoscaler} {
+		autoscalerType := autoscalerType
+		invalid := cluster.DeepCopy()
+		invalid.Spec.AutoscalerOptions.Type = &autoscalerType
+		assert.NotNil(t, ValidateAutoscalerOptions(*invalid))
+
+		invalid.Spec.AutoscalerOptions.UpscalingSpeed = nil
+		assert.NotNil(t, ValidateAutoscalerOptions(*invalid))
+		invalid.Spec.WorkerGroupSpecs[0].IdleTimeoutSeconds = nil
+		assert.NotNil(t, ValidateAutoscalerOptions(*invalid))
+		invalid.Spec.WorkerGroupSpecs[1].ScaleDownProtection = nil
+		assert.Nil(t, ValidateAutoscalerOptions(*invalid))
+	}
+}
+
+func TestDefaultHeadPodTemplateWithOperatorAutoscaling(t *testing.T) {
+	cluster := instance.DeepCopy()
+	operatorAutoscaler := rayv1alpha1.OperatorAutoscaler
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(true)
+	cluster.Spec.AutoscalerOptions = &rayv1alpha1.AutoscalerOptions{Type: &operatorAutoscaler}
+	podName := strings.ToLower(cluster.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
+
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	for _, container := range podTemplateSpec.Spec.Containers {
+		assert.NotEqual(t, AutoscalerContainerName, container.Name)
+	}
+	assert.Empty(t, podTemplateSpec.Spec.ServiceAccountName)
+	_, ok := cluster.Spec.HeadGroupSpec.RayStartParams["no-monitor"]
+	assert.False(t, ok)
+}
+
+func TestGetWorkerGroupRayResources(t *testing.T) {
+	cluster := operatorAutoscaledCluster()
+	assert.Equal(t, map[string]float64{"CPU": 2}, GetWorkerGroupRayResources(cluster.Spec.WorkerGroupSpecs[0]))
+	assert.Equal(t, map[string]float64{"CPU": 4, "GPU": 1}, GetWorkerGroupRayResources(cluster.Spec.WorkerGroupSpecs[1]))
+
+	// rayStartParams take precedence over the container limits.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.RayStartParams = map[string]string{
+		"num-cpus":  "8",
+		"memory":    "1000",
+		"resources": `'"{\"TPU\": 4}"'`,
+	}
+	assert.Equal(t, map[string]float64{"CPU": 8, "memory": 1000, "TPU": 4}, GetWorkerGroupRayResources(worker))
+}
+
+func TestUpdateIdleWorkers(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+	clusterStatus := &utils.ClusterStatus{LoadMetricsReport: utils.LoadMetricsReport{
+		UsageByNode: map[string]map[string][2]float64{
+			"10.0.0.1": {"CPU": {0, 2}, "object_store_memory": {100, 1000}, "node:10.0.0.1": {0.5, 1}},
+			"10.0.0.2": {"CPU": {0, 2}},
+			"10.0.0.3": {"CPU": {1, 2}},
+		},
+	}}
+	workerPods := []v1.Pod{
+		autoscalerWorkerPod("worker-1", "cpu-group", "10.0.0.1"),
+		autoscalerWorkerPod("worker-2", "cpu-group", "10.0.0.2"),
+		autoscalerWorkerPod("worker-3", "cpu-group", "10.0.0.3"),
+		autoscalerWorkerPod("worker-4", "cpu-group", ""),
+	}
+
+	idleSince := UpdateIdleWorkers(clusterStatus, workerPods, map[string]time.Time{"worker-1": earlier, "worker-3": earlier}, now)
+	assert.Equal(t, map[string]time.Time{"worker-1": earlier, "worker-2": now}, idleSince)
+}
+
+func TestComputeWorkerGroupScaling(t *testing.T) {
+	now := time.Now()
+	headNode := map[string][2]float64{"CPU": {0, 0}}
+
+	tests := map[string]struct {
+		upscalingMode       rayv1alpha1.UpscalingMode
+		upscalingSpeed      *float64
+		idleTimeoutSeconds  *int32
+		scaleDownProtection bool
+		minReplicas         int32
+		replicas            []int32
+		report              utils.LoadMetricsReport
+		workerPods          []v1.Pod
+		idleSince           map[string]time.Time
+		expectedScaling     map[string]WorkerGroupScaling
+	}{
+		"no demand": {
+			report: utils.LoadMetricsReport{UsageByNode: map[string]map[string][2]float64{"10.0.0.100": headNode}},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 0},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"CPU demand is not placed on GPU workers": {
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 3}},
+				UsageByNode:    map[string]map[string][2]float64{"10.0.0.100": headNode},
+			},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 2},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"GPU demand and placement groups": {
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"GPU": 1}, Count: 3}},
+				PlacementGroupDemand: []utils.PlacementGroupDemand{{
+					Strategy: "PACK",
+					Bundles:  []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 2}, Count: 2}},
+					Count:    1,
+				}},
+				UsageByNode: map[string]map[string][2]float64{"10.0.0.100": headNode},
+			},
+			// Only 2 GPU workers are allowed by maxReplicas; the CPU bundles also fill the free CPUs of the GPU workers.
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 0},
+				"gpu-group": {Replicas: 2},
+			},
+		},
+		"demand fits on the free capacity of existing and starting workers": {
+			replicas: []int32{2, 0},
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 3}},
+				UsageByNode: map[string]map[string][2]float64{
+					"10.0.0.100": headNode,
+					"10.0.0.1":   {"CPU": {1, 2}},
+				},
+			},
+			workerPods: []v1.Pod{
+				autoscalerWorkerPod("worker-1", "cpu-group", "10.0.0.1"),
+				autoscalerWorkerPod("worker-2", "cpu-group", ""),
+			},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 2},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"maxReplicas is honored": {
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 2}, Count: 20}},
+				UsageByNode:    map[string]map[string][2]float64{"10.0.0.100": headNode},
+			},
+			// 10 CPU workers at most, then the GPU workers as the last resort.
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 10},
+				"gpu-group": {Replicas: 2},
+			},
+		},
+		"conservative upscaling": {
+			upscalingMode: "Conservative",
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 2}, Count: 20}},
+				UsageByNode:    map[string]map[string][2]float64{"10.0.0.100": headNode},
+			},
+			// At most max(5, 1 * number of Ray nodes) pending workers.
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 5},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"upscaling speed takes precedence over the upscaling mode": {
+			upscalingMode:  "Conservative",
+			upscalingSpeed: pointer.Float64(8),
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 2}, Count: 20}},
+				UsageByNode:    map[string]map[string][2]float64{"10.0.0.100": headNode},
+			},
+			// At most max(5, 8 * number of Ray nodes) pending workers.
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 8},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"idle workers are removed after the idle timeout down to minReplicas": {
+			minReplicas: 2,
+			replicas:    []int32{3, 0},
+			report: utils.LoadMetricsReport{
+				UsageByNode: map[string]map[string][2]float64{
+					"10.0.0.100": headNode,
+					"10.0.0.1":   {"CPU": {0, 2}},
+					"10.0.0.2":   {"CPU": {0, 2}},
+					"10.0.0.3":   {"CPU": {0, 2}},
+				},
+			},
+			workerPods: []v1.Pod{
+				autoscalerWorkerPod("worker-1", "cpu-group", "10.0.0.1"),
+				autoscalerWorkerPod("worker-2", "cpu-group", "10.0.0.2"),
+				autoscalerWorkerPod("worker-3", "cpu-group", "10.0.0.3"),
+			},
+			idleSince: map[string]time.Time{
+				"worker-1": now.Add(-2 * time.Minute),
+				"worker-2": now.Add(-10 * time.Second),
+				"worker-3": now.Add(-2 * time.Minute),
+			},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 2, WorkersToDelete: []string{"worker-1"}},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"the idle timeout of the worker group takes precedence": {
+			idleTimeoutSeconds: pointer.Int32(5),
+			replicas:           []int32{2, 0},
+			report: utils.LoadMetricsReport{
+				UsageByNode: map[string]map[string][2]float64{
+					"10.0.0.100": headNode,
+					"10.0.0.1":   {"CPU": {0, 2}},
+					"10.0.0.2":   {"CPU": {0, 2}},
+				},
+			},
+			workerPods: []v1.Pod{
+				autoscalerWorkerPod("worker-1", "cpu-group", "10.0.0.1"),
+				autoscalerWorkerPod("worker-2", "cpu-group", "10.0.0.2"),
+			},
+			idleSince: map[string]time.Time{
+				"worker-1": now.Add(-2 * time.Minute),
+				"worker-2": now.Add(-10 * time.Second),
+			},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 0, WorkersToDelete: []string{"worker-1", "worker-2"}},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"idle workers of a group protected from scaling down are kept": {
+			scaleDownProtection: true,
+			replicas:            []int32{1, 0},
+			report: utils.LoadMetricsReport{
+				UsageByNode: map[string]map[string][2]float64{
+					"10.0.0.100": headNode,
+					"10.0.0.1":   {"CPU": {0, 2}},
+				},
+			},
+			workerPods: []v1.Pod{autoscalerWorkerPod("worker-1", "cpu-group", "10.0.0.1")},
+			idleSince:  map[string]time.Time{"worker-1": now.Add(-2 * time.Minute)},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 1},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+		"idle workers that can run the demand are kept": {
+			replicas: []int32{1, 0},
+			report: utils.LoadMetricsReport{
+				ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 1}},
+				UsageByNode: map[string]map[string][2]float64{
+					"10.0.0.100": headNode,
+					"10.0.0.1":   {"CPU": {0, 2}},
+				},
+			},
+			workerPods: []v1.Pod{autoscalerWorkerPod("worker-1", "cpu-group", "10.0.0.1")},
+			idleSince:  map[string]time.Time{"worker-1": now.Add(-2 * time.Minute)},
+			expectedScaling: map[string]WorkerGroupScaling{
+				"cpu-group": {Replicas: 1},
+				"gpu-group": {Replicas: 0},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := operatorAutoscaledCluster()
+			cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32(tc.minReplicas)
+			if tc.upscalingMode != "" {
+				cluster.Spec.AutoscalerOptions.UpscalingMode = &tc.upscalingMode
+			}
+			cluster.Spec.AutoscalerOptions.UpscalingSpeed = tc.upscalingSpeed
+			cluster.Spec.WorkerGroupSpecs[0].IdleTimeoutSeconds = tc.idleTimeoutSeconds
+			cluster.Spec.WorkerGroupSpecs[0].ScaleDownProtection = pointer.Bool(tc.scaleDownProtection)
+			for i, replicas := range tc.replicas {
+				cluster.Spec.WorkerGroupSpecs[i].Replicas = pointer.Int32(replicas)
+			}
+			clusterStatus := &utils.ClusterStatus{LoadMetricsReport: tc.report}
+
+			scaling := ComputeWorkerGroupScaling(cluster, clusterStatus, tc.workerPods, tc.idleSince, now)
+			assert.Equal(t, tc.expectedScaling, scaling)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"encoding/json"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+)
+
+// GetBatchSchedulerName returns the batch scheduler plugin of the RayCluster, taken from spec.batchScheduling, then from
+// the deprecated ray.io/scheduler-name label. It is empty when the RayCluster does not use a batch scheduler.
+func GetBatchSchedulerName(instance *rayv1alpha1.RayCluster) string {
+	if options := instance.Spec.BatchScheduling; options != nil {
+		return options.SchedulerName
+	}
+	return instance.Labels[RaySchedulerName]
+}
+
+// GetBatchSchedulingQueue returns the queue the Pods of the RayCluster are submitted to, taken from spec.batchScheduling,
+// then from the label of the RayCluster naming the queue of the batch scheduler.
+func GetBatchSchedulingQueue(instance *rayv1alpha1.RayCluster, queueLabelKey string) string {
+	if options := instance.Spec.BatchScheduling; options != nil && options.Queue != "" {
+		return options.Queue
+	}
+	return instance.Labels[queueLabelKey]
+}
+
+// GetBatchSchedulingPriorityClassName returns the PriorityClass of the Pods of the RayCluster, taken from
+// spec.batchScheduling, then from the deprecated ray.io/priority-class-name label.
+func GetBatchSchedulingPriorityClassName(instance *rayv1alpha1.RayCluster) string {
+	if options := instance.Spec.BatchScheduling; options != nil && options.PriorityClassName != "" {
+		return options.PriorityClassName
+	}
+	return instance.Labels[RayPriorityClassName]
+}
+
+// GetBatchSchedulingMinAvailable returns the number of Pods of the RayCluster that must be scheduled together, which
+// spec.batchScheduling.minAvailable overrides.
+func GetBatchSchedulingMinAvailable(instance *rayv1alpha1.RayCluster, defaultMinAvailable int32) int32 {
+	if options := instance.Spec.BatchScheduling; options != nil && options.MinAvailable != nil {
+		return *options.MinAvailable
+	}
+	return defaultMinAvailable
+}
+
+// GetBatchSchedulingTimeoutSeconds returns the scheduling timeout of the RayCluster, or nil to use the timeout of the
+// batch scheduler.
+func GetBatchSchedulingTimeoutSeconds(instance *rayv1alpha1.RayCluster) *int32 {
+	if options := instance.Spec.BatchScheduling; options != nil {
+		return options.ScheduleTimeoutSeconds
+	}
+	return nil
+}
+
+// SetRayJobSubmitterResources records the resources of the submitter Pod of a RayJob on the RayCluster created for the
+// RayJob, so that batch schedulers reserve them together with the resources of the Ray Pods.
+func SetRayJobSubmitterResources(instance *rayv1alpha1.RayCluster, submitterTemplate corev1.PodTemplateSpec) error {
+	resources, err := json.Marshal(utils.CalculatePodResource(submitterTemplate.Spec))
+	if err != nil {
+		return err
+	}
+	// The annotations of a RayCluster created for a RayJob are shared with the RayJob, so they are copied first.
+	annotations := map[string]string{}
+	for key, value := range instance.Annotations {
+		annotations[key] = value
+	}
+	annotations[RayJobSubmitterResourcesAnnotationKey] = string(resources)
+	instance.Annotations = annotations
+	return nil
+}
+
+// GetRayJobSubmitterResources returns the resources of the submitter Pod of the RayJob the RayCluster was created for, and
+// whether the RayCluster has a submitter Pod.
+func GetRayJobSubmitterResources(instance *rayv1alpha1.RayCluster) (corev1.ResourceList, bool) {
+	value, ok := instance.Annotations[RayJobSubmitterResourcesAnnotationKey]
+	if !ok {
+		return nil, false
+	}
+	resources := corev1.ResourceList{}
+	if err := json.Unmarshal([]byte(value), &resources); err != nil {
+		return nil, false
+	}
+	return resources, true
+}
+
+// GetGangSchedulingMinMemberAndResources returns the number of Pods of the RayCluster that must be scheduled together, and
+// the resources reserved for them. The desired replicas are used, or the minReplicas when autoscaling is enabled. The
+// resources of the submitter Pod of a RayJob are reserved as well, but the submitter Pod is not counted as a member: it
+// is only created once the RayCluster is ready, so waiting for it would never schedule the Ray Pods.
+func GetGangSchedulingMinMemberAndResources(app *rayv1alpha1.RayCluster) (int32, corev1.ResourceList) {
+	var minMember int32
+	var totalResource corev1.ResourceList
+	if app.Spec.EnableInTreeAutoscaling == nil || !*app.Spec.EnableInTreeAutoscaling {
+		minMember = utils.CalculateDesiredReplicas(app) + *app.Spec.HeadGroupSpec.Replicas
+		totalResource = utils.CalculateDesiredResources(app)
+	} else {
+		minMember = utils.CalculateMinReplicas(app) + *app.Spec.HeadGroupSpec.Replicas
+		totalResource = utils.CalculateMinResources(app)
+	}
+	if submitterResources, ok := GetRayJobSubmitterResources(app); ok {
+		totalResource = quotav1.Add(totalResource, submitterResources)
+	}
+	return GetBatchSchedulingMinAvailable(app, minMember), totalResource
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+func TestBatchSchedulingOptions(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Labels = map[string]string{
+		RaySchedulerName:        "volcano",
+		RayPriorityClassName:    "low-priority",
+		"volcano.sh/queue-name": "label-queue",
+	}
+
+	// The labels are used when spec.batchScheduling is not set.
+	assert.Equal(t, "volcano", GetBatchSchedulerName(cluster))
+	assert.Equal(t, "label-queue", GetBatchSchedulingQueue(cluster, "volcano.sh/queue-name"))
+	assert.Equal(t, "low-priority", GetBatchSchedulingPriorityClassName(cluster))
+	assert.Equal(t, int32(3), GetBatchSchedulingMinAvailable(cluster, 3))
+	assert.Nil(t, GetBatchSchedulingTimeoutSeconds(cluster))
+
+	// spec.batchScheduling takes precedence over the labels.
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{
+		SchedulerName:          "yunikorn",
+		Queue:                  "root.ray",
+		PriorityClassName:      "high-priority",
+		MinAvailable:           pointer.Int32Ptr(2),
+		ScheduleTimeoutSeconds: pointer.Int32Ptr(60),
+	}
+	assert.Equal(t, "yunikorn", GetBatchSchedulerName(cluster))
+	assert.Equal(t, "root.ray", GetBatchSchedulingQueue(cluster, "volcano.sh/queue-name"))
+	assert.Equal(t, "high-priority", GetBatchSchedulingPriorityClassName(cluster))
+	assert.Equal(t, int32(2), GetBatchSchedulingMinAvailable(cluster, 3))
+	assert.Equal(t, int32(60), *GetBatchSchedulingTimeoutSeconds(cluster))
+
+	// The labels are still used for the fields spec.batchScheduling leaves empty.
+	cluster.Spec.BatchScheduling = &rayv1alpha1.BatchSchedulingOptions{SchedulerName: "volcano"}
+	assert.Equal(t, "label-queue", GetBatchSchedulingQueue(cluster, "volcano.sh/queue-name"))
+	assert.Equal(t, "low-priority", GetBatchSchedulingPriorityClassName(cluster))
+}
+
+func TestGetGangSchedulingMinMemberAndResources(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+
+	// 1 head + 3 workers
+	minMember, totalResource := GetGangSchedulingMinMemberAndResources(cluster)
+	assert.Equal(t, int32(4), minMember)
+	assert.Equal(t, "4", totalResource.Cpu().String())
+
+	// The resources of the submitter Pod are reserved, but the submitter Pod is not a member.
+	rayJobAnnotations := map[string]string{"key": "value"}
+	cluster.Annotations = rayJobAnnotations
+	submitterTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "ray-job-submitter",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				},
+			}},
+		},
+	}
+	assert.Nil(t, SetRayJobSubmitterResources(cluster, submitterTemplate))
+	_, ok := rayJobAnnotations[RayJobSubmitterResourcesAnnotationKey]
+	assert.False(t, ok)
+	assert.Equal(t, "value", cluster.Annotations["key"])
+
+	submitterResources, ok := GetRayJobSubmitterResources(cluster)
+	assert.True(t, ok)
+	assert.Equal(t, "500m", submitterResources.Cpu().String())
+	minMember, totalResource = GetGangSchedulingMinMemberAndResources(cluster)
+	assert.Equal(t, int32(4), minMember)
+	assert.Equal(t, "4500m", totalResource.Cpu().String())
+}
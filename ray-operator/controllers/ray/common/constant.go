@@ -13,19 +13,49 @@ const (
 	RayIDLabelKey                    = "ray.io/identifier"
 	RayClusterServingServiceLabelKey = "ray.io/serve"
 	RayServiceClusterHashKey         = "ray.io/cluster-hash"
+	RayServeApplicationLabelKey      = "ray.io/serve-application"
+	RayAutoscalerLabelKey            = "ray.io/autoscaler"
 
 	// In KubeRay, the Ray container must be the first application container in a head or worker Pod.
 	RayContainerIndex = 0
 
-	// Batch scheduling labels
-	// TODO(tgaddair): consider making these part of the CRD
+	// Batch scheduling labels, deprecated in favor of spec.batchScheduling on the RayCluster
 	RaySchedulerName     = "ray.io/scheduler-name"
 	RayPriorityClassName = "ray.io/priority-class-name"
 
-	// Ray GCS FT related annotations
+	// RayCluster annotation with the resources of the submitter Pod of the RayJob the RayCluster was created for, which are
+	// reserved by batch schedulers together with the Ray Pods
+	RayJobSubmitterResourcesAnnotationKey = "ray.io/rayjob-submitter-resources"
+	// Value of the ray.io/group label of the submitter Pod of a RayJob gang scheduled with its RayCluster
+	RayJobSubmitterGroupName = "rayjob-submitter"
+
+	// RayWorkerGroup annotation with the replicas last synced between the RayWorkerGroup and its worker group in the RayCluster
+	RayWorkerGroupSyncedReplicasAnnotationKey = "ray.io/synced-replicas"
+
+	// Autoscaler Deployment annotation with the hash of the Pod template last applied by KubeRay
+	RayAutoscalerTemplateHashAnnotationKey = "ray.io/autoscaler-template-hash"
+
+	// RayCluster annotation asking KubeRay to wake up the RayCluster hibernated by its idle policy. KubeRay removes it
+	// once handled; on an awake RayCluster it only resets the idle timer.
+	RayClusterWakeUpAnnotationKey = "ray.io/wake-up"
+	// RayCluster annotation with the replicas of each worker group before KubeRay hibernated the RayCluster, as a JSON object
+	RayClusterHibernatedReplicasAnnotationKey = "ray.io/hibernated-replicas"
+	// RayCluster annotation with the replicas of each worker group before KubeRay preempted the RayCluster, as a JSON object
+	RayClusterPreemptedReplicasAnnotationKey = "ray.io/preempted-replicas"
+	// RayCluster annotation with the namespace/name of the RayCluster that preempted the RayCluster
+	RayClusterPreemptedByAnnotationKey = "ray.io/preempted-by"
+
+	// Ray GCS FT related annotations, deprecated in favor of spec.gcsFaultToleranceOptions on the RayCluster
 	RayFTEnabledAnnotationKey         = "ray.io/ft-enabled"
 	RayExternalStorageNSAnnotationKey = "ray.io/external-storage-namespace"
 
+	// Pod template annotation naming the Secret with the CA that issues the node certificates when TLS is enabled
+	RayTLSCASecretAnnotationKey = "ray.io/tls-ca-secret"
+	// Pod annotation naming the Secret with the node certificate KubeRay issues for the Pod when TLS is enabled
+	RayTLSNodeSecretAnnotationKey = "ray.io/tls-node-secret"
+	// Pod annotation with the time KubeRay issued the node certificate of the Pod
+	RayTLSNodeCertIssuedAnnotationKey = "ray.io/tls-node-cert-issued"
+
 	// Finalizers for GCS fault tolerance
 	GCSFaultToleranceRedisCleanupFinalizer = "ray.io/gcs-ft-redis-cleanup-finalizer"
 
@@ -38,6 +68,24 @@ const (
 	EnableRayClusterServingServiceTrue  = "true"
 	EnableRayClusterServingServiceFalse = "false"
 
+	// Pod readiness gate of RayService Pods. The RayService controller sets the condition to true
+	// once the Serve HTTP proxy on the Pod is healthy.
+	RayServeReadyConditionType = "ray.io/serve-ready"
+	// Maximum number of Serve HTTP proxies probed concurrently for one RayCluster.
+	DefaultServeProxyProbeConcurrency = 16
+	// Number of seconds the probes of a Pod must keep failing before the Pod is marked as not ready for Serve traffic.
+	DefaultServeProxyUnhealthyThresholdSeconds = 10
+	// Annotation of the Pod templates of RayService clusters that records what `addServeReadinessGate` added to the
+	// template, as a comma-separated list of `ServeReadinessGateAddedLabel` and `ServeReadinessGateAddedGate`, so that
+	// only those are stripped before hashing the RayCluster spec.
+	RayServeReadinessGateAddedAnnotationKey = "ray.io/serve-readiness-gate-added"
+	ServeReadinessGateAddedLabel            = "label"
+	ServeReadinessGateAddedGate             = "readinessGate"
+	// Replica state reported by the Serve details API for replicas that are serving traffic.
+	ServeReplicaStateRunning = "RUNNING"
+	// Maximum number of scale events kept per Serve deployment in the RayService status.
+	MaxServeDeploymentScaleEvents = 5
+
 	KubernetesApplicationNameLabelKey = "app.kubernetes.io/name"
 	KubernetesCreatedByLabelKey       = "app.kubernetes.io/created-by"
 
@@ -54,6 +102,7 @@ const (
 	DefaultMetricsPort              = 8080
 	DefaultDashboardAgentListenPort = 52365
 	DefaultServingPort              = 8000
+	DefaultServingGRPCPort          = 9000
 
 	DefaultClientPortName               = "client"
 	DefaultRedisPortName                = "redis"
@@ -61,9 +110,12 @@ const (
 	DefaultMetricsName                  = "metrics"
 	DefaultDashboardAgentListenPortName = "dashboard-agent"
 	DefaultServingPortName              = "serve"
+	DefaultServingGRPCPortName          = "serve-grpc"
 
 	// The default AppProtocol for Kubernetes service
 	DefaultServiceAppProtocol = "tcp"
+	// The AppProtocol of the gRPC serve port, i.e. HTTP/2 over cleartext
+	ServeGRPCAppProtocol = "kubernetes.io/h2c"
 
 	// The default application name
 	ApplicationName = "kuberay"
@@ -84,6 +136,7 @@ const (
 	RAY_PORT                                = "RAY_PORT"
 	RAY_ADDRESS                             = "RAY_ADDRESS"
 	REDIS_PASSWORD                          = "REDIS_PASSWORD"
+	RAY_REDIS_ADDRESS                       = "RAY_REDIS_ADDRESS"
 	RAY_DASHBOARD_ENABLE_K8S_DISK_USAGE     = "RAY_DASHBOARD_ENABLE_K8S_DISK_USAGE"
 	RAY_EXTERNAL_STORAGE_NS                 = "RAY_external_storage_namespace"
 	RAY_GCS_RPC_SERVER_RECONNECT_TIMEOUT_S  = "RAY_gcs_rpc_server_reconnect_timeout_s"
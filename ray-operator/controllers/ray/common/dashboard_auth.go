@@ -0,0 +1,201 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	DashboardAuthProxyContainerName  = "dashboard-auth-proxy"
+	DashboardAuthVolumeName          = "dashboard-auth"
+	DashboardAuthVolumeMountPath     = "/etc/ray/dashboard-auth"
+	DashboardAuthTokenKey            = utils.DashboardAuthTokenKey
+	DashboardAuthProxyPortName       = "dashboard-auth"
+	DashboardAuthProxyPort           = 8266
+	DashboardAgentAuthProxyPortName  = "agent-auth"
+	DashboardAgentAuthProxyPort      = 8267
+	DefaultDashboardAuthProxyImage   = "kuberay/operator:nightly"
+	dashboardAuthProxyBinary         = "/dashboard-auth-proxy"
+	dashboardAuthTokenBytes          = 32
+	DashboardAuthProxyImageEnvKey    = "DASHBOARD_AUTH_PROXY_IMAGE"
+	RAY_DASHBOARD_AUTH_TOKEN         = "RAY_DASHBOARD_AUTH_TOKEN"
+	RAY_JOB_HEADERS                  = "RAY_JOB_HEADERS"
+	dashboardAuthRayJobHeadersFormat = `{"Authorization": "Bearer $(%s)"}`
+)
+
+// IsDashboardAuthEnabled returns whether the dashboard of the RayCluster is protected by the authenticating proxy.
+func IsDashboardAuthEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.DashboardAuth != nil
+}
+
+// GetDashboardAuthProxyImage returns the image of the proxy container. The image defaults to the value of the
+// DASHBOARD_AUTH_PROXY_IMAGE environment variable of the operator, which the Helm chart sets to the operator image.
+func GetDashboardAuthProxyImage(options *rayv1alpha1.DashboardAuthOptions) string {
+	if options != nil && options.Image != nil {
+		return *options.Image
+	}
+	if image := os.Getenv(DashboardAuthProxyImageEnvKey); image != "" {
+		return image
+	}
+	return DefaultDashboardAuthProxyImage
+}
+
+// GenerateDashboardAuthToken returns a random bearer token for the dashboard proxy.
+func GenerateDashboardAuthToken() (string, error) {
+	return generateRandomHexString(dashboardAuthTokenBytes)
+}
+
+// generateRandomHexString returns the hex encoding of n cryptographically random bytes.
+func generateRandomHexString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// BuildDashboardAuthSecret builds the Secret holding the bearer token accepted by the dashboard proxy of the RayCluster.
+func BuildDashboardAuthSecret(instance rayv1alpha1.RayCluster, token string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateDashboardAuthSecretName(instance.Name),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                instance.Name,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			DashboardAuthTokenKey: []byte(token),
+		},
+	}
+}
+
+// BuildDashboardAuthProxyContainer builds the proxy container which forwards the authenticated requests to the
+// dashboard and the dashboard agent of the Ray container.
+func BuildDashboardAuthProxyContainer(instance rayv1alpha1.RayCluster, rayContainer *v1.Container) v1.Container {
+	options := instance.Spec.DashboardAuth
+	dashboardPort := utils.FindContainerPort(rayContainer, DefaultDashboardName, DefaultDashboardPort)
+	agentPort := utils.FindContainerPort(rayContainer, DefaultDashboardAgentListenPortName, DefaultDashboardAgentListenPort)
+	args := []string{
+		fmt.Sprintf("--token-file=%s/%s", DashboardAuthVolumeMountPath, DashboardAuthTokenKey),
+		fmt.Sprintf("--route=:%d=http://%s:%d", DashboardAuthProxyPort, LOCAL_HOST, dashboardPort),
+		fmt.Sprintf("--route=:%d=http://%s:%d", DashboardAgentAuthProxyPort, LOCAL_HOST, agentPort),
+	}
+	if options.Mode == rayv1alpha1.DashboardAuthModeOIDC && options.OIDC != nil {
+		args = append(args,
+			"--oidc-issuer-url="+options.OIDC.IssuerURL,
+			"--oidc-audience="+options.OIDC.Audience,
+		)
+	}
+
+	container := v1.Container{
+		Name:            DashboardAuthProxyContainerName,
+		Image:           GetDashboardAuthProxyImage(options),
+		ImagePullPolicy: v1.PullIfNotPresent,
+		Command:         []string{dashboardAuthProxyBinary},
+		Args:            args,
+		Ports: []v1.ContainerPort{
+			{Name: DashboardAuthProxyPortName, ContainerPort: DashboardAuthProxyPort},
+			{Name: DashboardAgentAuthProxyPortName, ContainerPort: DashboardAgentAuthProxyPort},
+		},
+		VolumeMounts: []v1.VolumeMount{
+			{Name: DashboardAuthVolumeName, MountPath: DashboardAuthVolumeMountPath, ReadOnly: true},
+		},
+		Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("100m"),
+				v1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("100m"),
+				v1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+	}
+	if options.Resources != nil {
+		container.Resources = *options.Resources
+	}
+	return container
+}
+
+// addDashboardAuthProxy injects the proxy container and the token volume into the head Pod template. BuildPod then
+// binds the dashboard to localhost, so that it can only be reached through the proxy.
+func addDashboardAuthProxy(instance rayv1alpha1.RayCluster, podTemplate *v1.PodTemplateSpec) {
+	proxyContainer := BuildDashboardAuthProxyContainer(instance, &podTemplate.Spec.Containers[RayContainerIndex])
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, proxyContainer)
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, v1.Volume{
+		Name: DashboardAuthVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: utils.GenerateDashboardAuthSecretName(instance.Name),
+				Items:      []v1.KeyToPath{{Key: DashboardAuthTokenKey, Path: DashboardAuthTokenKey}},
+			},
+		},
+	})
+}
+
+// hasDashboardAuthProxy returns whether the Pod runs the dashboard auth proxy container.
+func hasDashboardAuthProxy(pod v1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == DashboardAuthProxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// bindDashboardToLocalhost returns a copy of the rayStartParams of the head Pod in which the dashboard only listens on
+// localhost. The rayStartParams of the RayCluster are left unchanged.
+func bindDashboardToLocalhost(rayStartParams map[string]string) map[string]string {
+	if host, ok := rayStartParams["dashboard-host"]; ok && host != LOCAL_HOST {
+		log.Info("Overriding dashboard-host because the dashboard is only reachable through the authenticating proxy",
+			"dashboard-host", host)
+	}
+	params := make(map[string]string, len(rayStartParams)+1)
+	for key, value := range rayStartParams {
+		params[key] = value
+	}
+	params["dashboard-host"] = LOCAL_HOST
+	return params
+}
+
+// getDashboardAuthTargetPorts maps the head service ports that are served by the proxy to the proxy ports.
+func getDashboardAuthTargetPorts() map[string]intstr.IntOrString {
+	return map[string]intstr.IntOrString{
+		DefaultDashboardName:                intstr.FromString(DashboardAuthProxyPortName),
+		DefaultDashboardAgentListenPortName: intstr.FromString(DashboardAgentAuthProxyPortName),
+	}
+}
+
+// SetDashboardAuthEnvVars makes the Ray Job CLI in the container authenticate against the dashboard proxy of the
+// RayCluster with the token stored in its dashboard auth Secret.
+func SetDashboardAuthEnvVars(container *v1.Container, clusterName string) {
+	container.Env = append(container.Env,
+		v1.EnvVar{
+			Name: RAY_DASHBOARD_AUTH_TOKEN,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: utils.GenerateDashboardAuthSecretName(clusterName)},
+					Key:                  DashboardAuthTokenKey,
+				},
+			},
+		},
+		// RAY_JOB_HEADERS is read by the Ray Job CLI. It refers to the token through dependent environment variable expansion.
+		v1.EnvVar{
+			Name:  RAY_JOB_HEADERS,
+			Value: fmt.Sprintf(dashboardAuthRayJobHeadersFormat, RAY_DASHBOARD_AUTH_TOKEN),
+		},
+	)
+}
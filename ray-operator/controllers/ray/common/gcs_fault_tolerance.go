@@ -0,0 +1,91 @@
+package common
+
+import (
+	"fmt"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// GetExternalStorageNamespace returns the namespace of the GCS metadata of the RayCluster in Redis. It is taken from
+// spec.gcsFaultToleranceOptions, then from the deprecated ray.io/external-storage-namespace annotation, and defaults to
+// the UID of the RayCluster.
+func GetExternalStorageNamespace(instance rayv1alpha1.RayCluster) string {
+	if options := instance.Spec.GcsFaultToleranceOptions; options != nil && options.ExternalStorageNamespace != "" {
+		return options.ExternalStorageNamespace
+	}
+	if v, ok := instance.Annotations[RayExternalStorageNSAnnotationKey]; ok {
+		return v
+	}
+	return string(instance.UID)
+}
+
+// IsGCSFaultToleranceRedisCleanupEnabled returns whether the external storage namespace of the RayCluster is deleted from
+// Redis when the RayCluster is deleted.
+func IsGCSFaultToleranceRedisCleanupEnabled(instance rayv1alpha1.RayCluster) bool {
+	if !IsGCSFaultToleranceEnabled(instance) {
+		return false
+	}
+	options := instance.Spec.GcsFaultToleranceOptions
+	return options == nil || options.RedisCleanupPolicy != rayv1alpha1.RedisCleanupPolicyRetain
+}
+
+// ValidateGcsFaultToleranceOptions makes sure spec.gcsFaultToleranceOptions is complete and is not mixed with the deprecated
+// annotations, or with a Redis address or password set by hand in the head group.
+func ValidateGcsFaultToleranceOptions(instance rayv1alpha1.RayCluster) error {
+	options := instance.Spec.GcsFaultToleranceOptions
+	if options == nil {
+		return nil
+	}
+	if options.RedisAddress == "" {
+		return fmt.Errorf("spec.gcsFaultToleranceOptions.redisAddress is required")
+	}
+	if options.RedisPassword != nil && (options.RedisPassword.Name == "" || options.RedisPassword.Key == "") {
+		return fmt.Errorf("spec.gcsFaultToleranceOptions.redisPassword requires both name and key")
+	}
+	for _, key := range []string{RayFTEnabledAnnotationKey, RayExternalStorageNSAnnotationKey} {
+		if _, ok := instance.Annotations[key]; ok {
+			return fmt.Errorf("the annotation %s cannot be used with spec.gcsFaultToleranceOptions", key)
+		}
+	}
+	headSpec := instance.Spec.HeadGroupSpec
+	if len(headSpec.Template.Spec.Containers) > RayContainerIndex {
+		for _, env := range []string{RAY_REDIS_ADDRESS, REDIS_PASSWORD, RAY_EXTERNAL_STORAGE_NS} {
+			if envVarExists(env, headSpec.Template.Spec.Containers[RayContainerIndex].Env) {
+				return fmt.Errorf("the environment variable %s of the head container cannot be used with spec.gcsFaultToleranceOptions", env)
+			}
+		}
+	}
+	if _, ok := headSpec.RayStartParams["redis-password"]; ok {
+		return fmt.Errorf("the redis-password rayStartParam of the head group cannot be used with spec.gcsFaultToleranceOptions, use redisPassword instead")
+	}
+	return nil
+}
+
+// addGcsFaultToleranceEnvVars points the Ray container of the head Pod to the Redis in spec.gcsFaultToleranceOptions. The Redis
+// cleanup Job is built from the head Pod, so it inherits the same environment variables.
+func addGcsFaultToleranceEnvVars(instance rayv1alpha1.RayCluster, podTemplate *v1.PodTemplateSpec, rayStartParams map[string]string) {
+	options := instance.Spec.GcsFaultToleranceOptions
+	addRayContainerEnvVars(podTemplate, v1.EnvVar{Name: RAY_REDIS_ADDRESS, Value: options.RedisAddress})
+	if options.RedisPassword != nil {
+		addRedisPasswordEnvVar(podTemplate, rayStartParams, options.RedisPassword)
+	}
+}
+
+// addRedisPasswordEnvVar sets the REDIS_PASSWORD environment variable of the Ray container from the given Secret key. The
+// password is expanded by the shell running `ray start`, so it never appears in the Pod spec.
+func addRedisPasswordEnvVar(podTemplate *v1.PodTemplateSpec, rayStartParams map[string]string, secretKey *v1.SecretKeySelector) {
+	addRayContainerEnvVars(podTemplate, v1.EnvVar{
+		Name:      REDIS_PASSWORD,
+		ValueFrom: &v1.EnvVarSource{SecretKeyRef: secretKey.DeepCopy()},
+	})
+	rayStartParams["redis-password"] = fmt.Sprintf("$%s", REDIS_PASSWORD)
+}
+
+// addRayContainerEnvVars appends environment variables to the Ray container of the pod template. The containers are copied
+// so that the pod template of the RayCluster is not modified.
+func addRayContainerEnvVars(podTemplate *v1.PodTemplateSpec, envVars ...v1.EnvVar) {
+	podTemplate.Spec.Containers = append([]v1.Container{}, podTemplate.Spec.Containers...)
+	container := &podTemplate.Spec.Containers[RayContainerIndex]
+	container.Env = append(append([]v1.EnvVar{}, container.Env...), envVars...)
+}
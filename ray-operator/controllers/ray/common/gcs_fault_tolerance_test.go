@@ -0,0 +1,109 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestBuildPod_WithGcsFaultToleranceOptions(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.UID = "cluster-uid"
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{
+		RedisAddress: "redis:6379",
+		RedisPassword: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "redis-password-secret"},
+			Key:                  "password",
+		},
+	}
+	assert.Nil(t, ValidateGcsFaultToleranceOptions(*cluster))
+	assert.True(t, IsGCSFaultToleranceEnabled(*cluster))
+	assert.True(t, IsGCSFaultToleranceRedisCleanupEnabled(*cluster))
+
+	podName := strings.ToLower(cluster.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
+	headSpec := *cluster.Spec.HeadGroupSpec.DeepCopy()
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, headSpec, podName, "6379")
+	pod := BuildPod(podTemplateSpec, rayv1alpha1.HeadNode, headSpec.RayStartParams, "6379", nil, "", "")
+	rayContainer := pod.Spec.Containers[RayContainerIndex]
+
+	checkContainerEnv(t, rayContainer, RAY_REDIS_ADDRESS, "redis:6379")
+	checkContainerEnv(t, rayContainer, RAY_EXTERNAL_STORAGE_NS, "cluster-uid")
+	assert.Equal(t, "true", pod.Annotations[RayFTEnabledAnnotationKey])
+	for _, env := range rayContainer.Env {
+		if env.Name == REDIS_PASSWORD {
+			assert.Empty(t, env.Value)
+			assert.Equal(t, "redis-password-secret", env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	// The password is only referenced through the environment variable.
+	assert.Contains(t, rayContainer.Args[0], "--redis-password=$REDIS_PASSWORD")
+	// The pod template of the RayCluster is not modified.
+	assert.False(t, envVarExists(RAY_REDIS_ADDRESS, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex].Env))
+	assert.Nil(t, ValidateGcsFaultToleranceOptions(*cluster))
+
+	// A custom external storage namespace is used, and Redis cleanup can be disabled.
+	cluster.Spec.GcsFaultToleranceOptions.ExternalStorageNamespace = "my-storage-namespace"
+	cluster.Spec.GcsFaultToleranceOptions.RedisCleanupPolicy = rayv1alpha1.RedisCleanupPolicyRetain
+	assert.False(t, IsGCSFaultToleranceRedisCleanupEnabled(*cluster))
+	podTemplateSpec = DefaultHeadPodTemplate(*cluster, *cluster.Spec.HeadGroupSpec.DeepCopy(), podName, "6379")
+	assert.Equal(t, "my-storage-namespace", podTemplateSpec.Annotations[RayExternalStorageNSAnnotationKey])
+}
+
+func TestValidateGcsFaultToleranceOptions(t *testing.T) {
+	validOptions := &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	tests := map[string]struct {
+		mutate      func(cluster *rayv1alpha1.RayCluster)
+		expectError bool
+	}{
+		"no options": {
+			mutate:      func(cluster *rayv1alpha1.RayCluster) { cluster.Spec.GcsFaultToleranceOptions = nil },
+			expectError: false,
+		},
+		"valid options": {
+			mutate:      func(cluster *rayv1alpha1.RayCluster) {},
+			expectError: false,
+		},
+		"missing Redis address": {
+			mutate:      func(cluster *rayv1alpha1.RayCluster) { cluster.Spec.GcsFaultToleranceOptions.RedisAddress = "" },
+			expectError: true,
+		},
+		"incomplete password reference": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				cluster.Spec.GcsFaultToleranceOptions.RedisPassword = &v1.SecretKeySelector{Key: "password"}
+			},
+			expectError: true,
+		},
+		"deprecated annotation": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				cluster.Annotations = map[string]string{RayFTEnabledAnnotationKey: "true"}
+			},
+			expectError: true,
+		},
+		"Redis address in the head container": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				container := &cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex]
+				container.Env = append(container.Env, v1.EnvVar{Name: RAY_REDIS_ADDRESS, Value: "redis:6379"})
+			},
+			expectError: true,
+		},
+		"Redis password in rayStartParams": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				cluster.Spec.HeadGroupSpec.RayStartParams["redis-password"] = "password"
+			},
+			expectError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := instance.DeepCopy()
+			cluster.Spec.GcsFaultToleranceOptions = validOptions.DeepCopy()
+			tc.mutate(cluster)
+			err := ValidateGcsFaultToleranceOptions(*cluster)
+			assert.Equal(t, tc.expectError, err != nil, err)
+		})
+	}
+}
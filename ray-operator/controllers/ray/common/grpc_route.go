@@ -0,0 +1,72 @@
+package common
+
+import (
+	"fmt"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GRPCRouteGVK is the Gateway API GRPCRoute. It is handled as an unstructured object so that KubeRay does not depend on
+// the Gateway API.
+var GRPCRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GRPCRoute"}
+
+// IsGRPCRouteEnabled returns whether the RayService asks for a GRPCRoute.
+func IsGRPCRouteEnabled(service rayv1alpha1.RayService) bool {
+	return service.Spec.GRPCRoute != nil
+}
+
+// BuildServeGRPCRouteForRayService builds the GRPCRoute that attaches the gRPC port of the serve service of a RayService
+// to the Gateways of spec.grpcRoute.parentRefs. Unlike an ingress, a GRPCRoute tells the Gateway to use HTTP/2 towards
+// the backend, so no controller-specific annotation is needed.
+func BuildServeGRPCRouteForRayService(service rayv1alpha1.RayService, cluster rayv1alpha1.RayCluster) (*unstructured.Unstructured, error) {
+	grpcPort, ok := getServicePorts(cluster)[DefaultServingGRPCPortName]
+	if !ok {
+		return nil, fmt.Errorf("Please specify the port named 'serve-grpc' in the Ray head container; " +
+			"otherwise, the GRPCRoute for the Ray Serve gRPC proxy will not be created.")
+	}
+
+	parentRefs := make([]interface{}, 0, len(service.Spec.GRPCRoute.ParentRefs))
+	for _, ref := range service.Spec.GRPCRoute.ParentRefs {
+		parentRef := map[string]interface{}{"name": ref.Name}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": utils.GenerateServeServiceName(service.Name),
+						"port": int64(grpcPort),
+					},
+				},
+			},
+		},
+	}
+	if len(service.Spec.GRPCRoute.Hostnames) > 0 {
+		hostnames := make([]interface{}, 0, len(service.Spec.GRPCRoute.Hostnames))
+		for _, hostname := range service.Spec.GRPCRoute.Hostnames {
+			hostnames = append(hostnames, hostname)
+		}
+		spec["hostnames"] = hostnames
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	route.SetGroupVersionKind(GRPCRouteGVK)
+	route.SetName(utils.GenerateServeGRPCRouteName(service.Name))
+	route.SetNamespace(service.Namespace)
+	route.SetLabels(map[string]string{
+		RayServiceLabelKey:               service.Name,
+		RayClusterServingServiceLabelKey: utils.GenerateServeServiceLabel(service.Name),
+	})
+	return route, nil
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildServeGRPCRouteForRayService(t *testing.T) {
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayservice-sample",
+			Namespace: "default",
+		},
+		Spec: rayv1alpha1.RayServiceSpec{
+			GRPCRoute: &rayv1alpha1.GRPCRouteOptions{
+				ParentRefs: []rayv1alpha1.GatewayParentReference{
+					{Name: "gateway", Namespace: "gateway-system", SectionName: "grpc"},
+					{Name: "internal-gateway"},
+				},
+				Hostnames: []string{"inference.example.com"},
+			},
+		},
+	}
+	assert.True(t, IsGRPCRouteEnabled(rayService))
+	cluster := *instanceWithIngressEnabled.DeepCopy()
+
+	// The Ray head container doesn't have a port named "serve-grpc".
+	route, err := BuildServeGRPCRouteForRayService(rayService, cluster)
+	assert.NotNil(t, err)
+	assert.Nil(t, route)
+
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultServingPortName, ContainerPort: 8000},
+		{Name: DefaultServingGRPCPortName, ContainerPort: 9001},
+	}
+	route, err = BuildServeGRPCRouteForRayService(rayService, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, GRPCRouteGVK, route.GroupVersionKind())
+	assert.Equal(t, "rayservice-sample-serve-grpc-route", route.GetName())
+	assert.Equal(t, "default", route.GetNamespace())
+	assert.Equal(t, rayService.Name, route.GetLabels()[RayServiceLabelKey])
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "gateway", "namespace": "gateway-system", "sectionName": "grpc"},
+		map[string]interface{}{"name": "internal-gateway"},
+	}, parentRefs)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"inference.example.com"}, hostnames)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"backendRefs": []interface{}{
+			map[string]interface{}{"name": utils.GenerateServeServiceName(rayService.Name), "port": int64(9001)},
+		}},
+	}, rules)
+
+	rayService.Spec.GRPCRoute = nil
+	assert.False(t, IsGRPCRouteEnabled(rayService))
+}
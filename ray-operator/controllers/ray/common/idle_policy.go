@@ -0,0 +1,176 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// DefaultIdleTimeoutSeconds is the idle timeout used when spec.idlePolicy.idleTimeoutSeconds is not set.
+	DefaultIdleTimeoutSeconds = 1800
+	// IdlePolicyCheckPeriod is how often KubeRay checks the activity of a RayCluster with an idle policy.
+	IdlePolicyCheckPeriod = 1 * time.Minute
+)
+
+// IsIdlePolicyEnabled returns true if KubeRay hibernates the RayCluster when it is idle.
+func IsIdlePolicyEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.IdlePolicy != nil
+}
+
+// GetIdleTimeout returns how long the RayCluster must stay idle before KubeRay hibernates it.
+func GetIdleTimeout(instance rayv1alpha1.RayCluster) time.Duration {
+	if instance.Spec.IdlePolicy != nil && instance.Spec.IdlePolicy.IdleTimeoutSeconds != nil {
+		return time.Duration(*instance.Spec.IdlePolicy.IdleTimeoutSeconds) * time.Second
+	}
+	return DefaultIdleTimeoutSeconds * time.Second
+}
+
+// ValidateIdlePolicy makes sure a hibernated RayCluster stays scaled down. The autoscaler sidecar keeps running in the
+// head Pod unless the idle policy stops the head, and it would scale the worker groups back up to their minReplicas.
+func ValidateIdlePolicy(instance rayv1alpha1.RayCluster) error {
+	if !IsIdlePolicyEnabled(instance) || instance.Spec.IdlePolicy.StopHead {
+		return nil
+	}
+	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling ||
+		IsOperatorAutoscalingEnabled(instance) || IsDeploymentAutoscalerEnabled(instance) {
+		return nil
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.MinReplicas != nil && *worker.MinReplicas > 0 {
+			return fmt.Errorf("spec.idlePolicy requires stopHead or the minReplicas of worker group %s to be 0, "+
+				"because the autoscaler sidecar would scale the hibernated worker group back up", worker.GroupName)
+		}
+	}
+	return nil
+}
+
+// IsRayClusterHibernated returns true if KubeRay hibernated the RayCluster and has not woken it up yet.
+func IsRayClusterHibernated(instance rayv1alpha1.RayCluster) bool {
+	_, ok := instance.Annotations[RayClusterHibernatedReplicasAnnotationKey]
+	return ok
+}
+
+// IsHeadStopped returns true if the head Pod of the RayCluster must not run because the RayCluster is hibernated and
+// its idle policy stops the head.
+func IsHeadStopped(instance rayv1alpha1.RayCluster) bool {
+	return IsRayClusterHibernated(instance) && instance.Spec.IdlePolicy != nil && instance.Spec.IdlePolicy.StopHead
+}
+
+// IsWakeUpRequested returns true if the ray.io/wake-up annotation is set on the RayCluster.
+func IsWakeUpRequested(instance rayv1alpha1.RayCluster) bool {
+	_, ok := instance.Annotations[RayClusterWakeUpAnnotationKey]
+	return ok
+}
+
+// RequestWakeUp sets the ray.io/wake-up annotation on the RayCluster.
+func RequestWakeUp(instance *rayv1alpha1.RayCluster, now time.Time) {
+	if instance.Annotations == nil {
+		instance.Annotations = map[string]string{}
+	}
+	instance.Annotations[RayClusterWakeUpAnnotationKey] = now.UTC().Format(time.RFC3339)
+}
+
+// HasActiveJobs returns true if a submitted job or a driver, such as the driver of a connected Ray client, is pending
+// or running.
+func HasActiveJobs(jobInfos []utils.RayJobInfo) bool {
+	for _, jobInfo := range jobInfos {
+		if jobInfo.JobStatus == rayv1alpha1.JobStatusPending || jobInfo.JobStatus == rayv1alpha1.JobStatusRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRayClusterBusy returns true if a Ray node uses Ray resources, or if tasks, actors, or placement groups wait for
+// Ray resources.
+func IsRayClusterBusy(clusterStatus *utils.ClusterStatus) bool {
+	if clusterStatus == nil {
+		return false
+	}
+	report := clusterStatus.LoadMetricsReport
+	if len(report.ResourceDemand) > 0 || len(report.PlacementGroupDemand) > 0 {
+		return true
+	}
+	for _, usage := range report.UsageByNode {
+		if !IsRayNodeIdle(usage) {
+			return true
+		}
+	}
+	return false
+}
+
+// HibernateRayCluster scales all the worker groups of the RayCluster to zero, and records their replicas in the
+// ray.io/hibernated-replicas annotation.
+func HibernateRayCluster(instance *rayv1alpha1.RayCluster, workerPods []v1.Pod) error {
+	replicas := ScaleWorkerGroupsToZero(instance, workerPods)
+	value, err := json.Marshal(replicas)
+	if err != nil {
+		return err
+	}
+	if instance.Annotations == nil {
+		instance.Annotations = map[string]string{}
+	}
+	instance.Annotations[RayClusterHibernatedReplicasAnnotationKey] = string(value)
+	return nil
+}
+
+// ScaleWorkerGroupsToZero sets the replicas of all the worker groups of the RayCluster to zero and adds the given worker
+// Pods to the WorkersToDelete of their group, so that they are deleted even if in-tree autoscaling disables the random
+// deletion of Pods. It returns the previous replicas of each worker group.
+func ScaleWorkerGroupsToZero(instance *rayv1alpha1.RayCluster, workerPods []v1.Pod) map[string]int32 {
+	replicas := make(map[string]int32, len(instance.Spec.WorkerGroupSpecs))
+	for i := range instance.Spec.WorkerGroupSpecs {
+		worker := &instance.Spec.WorkerGroupSpecs[i]
+		replicas[worker.GroupName] = pointer.Int32Deref(worker.Replicas, 0)
+		worker.Replicas = pointer.Int32(0)
+		worker.ScaleStrategy.WorkersToDelete = nil
+		for _, pod := range workerPods {
+			if pod.Labels[RayNodeGroupLabelKey] == worker.GroupName && pod.DeletionTimestamp == nil {
+				worker.ScaleStrategy.WorkersToDelete = append(worker.ScaleStrategy.WorkersToDelete, pod.Name)
+			}
+		}
+	}
+	return replicas
+}
+
+// GetHibernatedReplicas returns the replicas of the worker groups recorded when the RayCluster was hibernated.
+func GetHibernatedReplicas(instance rayv1alpha1.RayCluster) (map[string]int32, error) {
+	replicas := map[string]int32{}
+	value, ok := instance.Annotations[RayClusterHibernatedReplicasAnnotationKey]
+	if !ok {
+		return replicas, nil
+	}
+	if err := json.Unmarshal([]byte(value), &replicas); err != nil {
+		return nil, err
+	}
+	return replicas, nil
+}
+
+// WakeUpRayCluster gives the worker groups their replicas back and removes the ray.io/hibernated-replicas and
+// ray.io/wake-up annotations. The worker groups missing from replicas, for example because they were added after the
+// hibernation, keep their replicas, raised to their minReplicas.
+func WakeUpRayCluster(instance *rayv1alpha1.RayCluster, replicas map[string]int32) {
+	restoreWorkerGroupReplicas(instance, replicas)
+	delete(instance.Annotations, RayClusterHibernatedReplicasAnnotationKey)
+	delete(instance.Annotations, RayClusterWakeUpAnnotationKey)
+}
+
+// restoreWorkerGroupReplicas gives the worker groups the replicas recorded when they were scaled to zero. The worker
+// groups missing from replicas keep their replicas, raised to their minReplicas.
+func restoreWorkerGroupReplicas(instance *rayv1alpha1.RayCluster, replicas map[string]int32) {
+	for i := range instance.Spec.WorkerGroupSpecs {
+		worker := &instance.Spec.WorkerGroupSpecs[i]
+		if value, ok := replicas[worker.GroupName]; ok {
+			worker.Replicas = pointer.Int32(value)
+		} else if worker.MinReplicas != nil && pointer.Int32Deref(worker.Replicas, 0) < *worker.MinReplicas {
+			worker.Replicas = pointer.Int32(*worker.MinReplicas)
+		}
+		worker.ScaleStrategy.WorkersToDelete = nil
+	}
+}
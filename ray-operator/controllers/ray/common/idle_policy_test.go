@@ -0,0 +1,121 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetIdleTimeout(t *testing.T) {
+	cluster := instance.DeepCopy()
+	assert.False(t, IsIdlePolicyEnabled(*cluster))
+
+	cluster.Spec.IdlePolicy = &rayv1alpha1.IdlePolicy{}
+	assert.True(t, IsIdlePolicyEnabled(*cluster))
+	assert.Equal(t, 30*time.Minute, GetIdleTimeout(*cluster))
+
+	cluster.Spec.IdlePolicy.IdleTimeoutSeconds = pointer.Int32(300)
+	assert.Equal(t, 5*time.Minute, GetIdleTimeout(*cluster))
+}
+
+func TestValidateIdlePolicy(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32(1)
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(true)
+	assert.Nil(t, ValidateIdlePolicy(*cluster))
+
+	cluster.Spec.IdlePolicy = &rayv1alpha1.IdlePolicy{}
+	assert.NotNil(t, ValidateIdlePolicy(*cluster))
+
+	cluster.Spec.IdlePolicy.StopHead = true
+	assert.Nil(t, ValidateIdlePolicy(*cluster))
+
+	cluster.Spec.IdlePolicy.StopHead = false
+	autoscalerType := rayv1alpha1.DeploymentAutoscaler
+	cluster.Spec.AutoscalerOptions = &rayv1alpha1.AutoscalerOptions{Type: &autoscalerType}
+	assert.Nil(t, ValidateIdlePolicy(*cluster))
+
+	cluster.Spec.AutoscalerOptions = nil
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32(0)
+	assert.Nil(t, ValidateIdlePolicy(*cluster))
+
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32(1)
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	assert.Nil(t, ValidateIdlePolicy(*cluster))
+}
+
+func TestHasActiveJobs(t *testing.T) {
+	assert.False(t, HasActiveJobs(nil))
+	assert.False(t, HasActiveJobs([]utils.RayJobInfo{{JobStatus: rayv1alpha1.JobStatusSucceeded}, {JobStatus: rayv1alpha1.JobStatusStopped}}))
+	assert.True(t, HasActiveJobs([]utils.RayJobInfo{{JobStatus: rayv1alpha1.JobStatusFailed}, {JobStatus: rayv1alpha1.JobStatusPending}}))
+	assert.True(t, HasActiveJobs([]utils.RayJobInfo{{JobStatus: rayv1alpha1.JobStatusRunning}}))
+}
+
+func TestIsRayClusterBusy(t *testing.T) {
+	assert.False(t, IsRayClusterBusy(nil))
+
+	idleUsage := map[string][2]float64{"CPU": {0, 4}, "object_store_memory": {1000, 2000}, "node:10.0.0.1": {0.01, 1}}
+	clusterStatus := &utils.ClusterStatus{LoadMetricsReport: utils.LoadMetricsReport{UsageByNode: map[string]map[string][2]float64{
+		"10.0.0.1": idleUsage,
+	}}}
+	assert.False(t, IsRayClusterBusy(clusterStatus))
+
+	clusterStatus.LoadMetricsReport.ResourceDemand = []utils.ResourceDemand{{Resources: map[string]float64{"GPU": 1}, Count: 1}}
+	assert.True(t, IsRayClusterBusy(clusterStatus))
+
+	clusterStatus.LoadMetricsReport.ResourceDemand = nil
+	clusterStatus.LoadMetricsReport.UsageByNode["10.0.0.2"] = map[string][2]float64{"CPU": {1, 4}}
+	assert.True(t, IsRayClusterBusy(clusterStatus))
+}
+
+func TestHibernateAndWakeUpRayCluster(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.IdlePolicy = &rayv1alpha1.IdlePolicy{StopHead: true}
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"stale-worker"}
+	workerPods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{RayNodeGroupLabelKey: "small-group"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-2", Labels: map[string]string{RayNodeGroupLabelKey: "small-group"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-worker", Labels: map[string]string{RayNodeGroupLabelKey: "other-group"}}},
+	}
+	assert.False(t, IsRayClusterHibernated(*cluster))
+	assert.False(t, IsHeadStopped(*cluster))
+
+	err := HibernateRayCluster(cluster, workerPods)
+	assert.Nil(t, err)
+	assert.True(t, IsRayClusterHibernated(*cluster))
+	assert.True(t, IsHeadStopped(*cluster))
+	assert.Equal(t, int32(0), *cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Equal(t, []string{"worker-1", "worker-2"}, cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+	replicas, err := GetHibernatedReplicas(*cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int32{"small-group": 3}, replicas)
+
+	// A worker group added while the RayCluster is hibernated keeps its replicas, raised to its minReplicas.
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, rayv1alpha1.WorkerGroupSpec{
+		GroupName:   "new-group",
+		Replicas:    pointer.Int32(0),
+		MinReplicas: pointer.Int32(2),
+	})
+	RequestWakeUp(cluster, time.Now())
+	assert.True(t, IsWakeUpRequested(*cluster))
+
+	WakeUpRayCluster(cluster, replicas)
+	assert.False(t, IsRayClusterHibernated(*cluster))
+	assert.False(t, IsWakeUpRequested(*cluster))
+	assert.Equal(t, int32(3), *cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Empty(t, cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+	assert.Equal(t, int32(2), *cluster.Spec.WorkerGroupSpecs[1].Replicas)
+}
+
+func TestGetHibernatedReplicasInvalid(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Annotations = map[string]string{RayClusterHibernatedReplicasAnnotationKey: "not json"}
+	_, err := GetHibernatedReplicas(*cluster)
+	assert.NotNil(t, err)
+}
@@ -11,7 +11,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const IngressClassAnnotationKey = "kubernetes.io/ingress.class"
+const (
+	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
+	// NginxBackendProtocolAnnotationKey sets the protocol that ingress-nginx uses to talk to the backend service.
+	NginxBackendProtocolAnnotationKey = "nginx.ingress.kubernetes.io/backend-protocol"
+)
 
 // BuildIngressForHeadService Builds the ingress for head service dashboard.
 // This is used to expose dashboard for external traffic.
@@ -119,3 +123,141 @@ func BuildIngressForRayService(service rayv1alpha1.RayService, cluster rayv1alph
 
 	return ingress, nil
 }
+
+// BuildServeIngressForApplications Builds the ingress for the per-application serve services of a RayService.
+// Each Serve application is exposed under its route prefix and is backed by the application's own service.
+func BuildServeIngressForApplications(service rayv1alpha1.RayService, cluster rayv1alpha1.RayCluster) (*networkingv1.Ingress, error) {
+	apps, err := utils.ParseServeConfigV2Applications(service.Spec.ServeConfigV2)
+	if err != nil {
+		return nil, err
+	}
+
+	servingPort := int32(DefaultServingPort)
+	if port, ok := getServicePorts(cluster)[DefaultServingPortName]; ok {
+		servingPort = port
+	}
+
+	annotation := map[string]string{}
+	for key, value := range service.Annotations {
+		if key != IngressClassAnnotationKey {
+			annotation[key] = value
+		}
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	paths := make([]networkingv1.HTTPIngressPath, 0, len(apps))
+	for _, app := range apps {
+		routePrefix := app.RoutePrefix
+		if routePrefix == "" {
+			routePrefix = "/"
+		}
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     routePrefix,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: utils.GenerateServeApplicationServiceName(service.Name, app.Name),
+					Port: networkingv1.ServiceBackendPort{
+						Number: servingPort,
+					},
+				},
+			},
+		})
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateServeApplicationIngressName(service.Name),
+			Namespace: service.Namespace,
+			Labels: map[string]string{
+				RayServiceLabelKey:               service.Name,
+				RayClusterServingServiceLabelKey: utils.GenerateServeServiceLabel(service.Name),
+			},
+			Annotations: annotation,
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: paths,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if ingressClassName, ok := service.Annotations[IngressClassAnnotationKey]; ok {
+		ingress.Spec.IngressClassName = &ingressClassName
+	} else {
+		logrus.Warn(fmt.Sprintf("ingress class annotation is not set for RayService %s/%s", service.Namespace, service.Name))
+	}
+
+	return ingress, nil
+}
+
+// BuildServeGRPCIngressForRayService Builds the ingress for the gRPC port of the serve service of a RayService.
+// gRPC requires HTTP/2 between the ingress controller and the Ray Serve gRPC proxy, so the ingress carries the
+// annotations that switch the backend protocol of common ingress controllers to gRPC.
+func BuildServeGRPCIngressForRayService(service rayv1alpha1.RayService, cluster rayv1alpha1.RayCluster) (*networkingv1.Ingress, error) {
+	grpcPort, ok := getServicePorts(cluster)[DefaultServingGRPCPortName]
+	if !ok {
+		return nil, fmt.Errorf("Please specify the port named 'serve-grpc' in the Ray head container; " +
+			"otherwise, the ingress for the Ray Serve gRPC proxy will not be created.")
+	}
+
+	annotation := map[string]string{
+		NginxBackendProtocolAnnotationKey: "GRPC",
+	}
+	for key, value := range service.Annotations {
+		if key != IngressClassAnnotationKey {
+			annotation[key] = value
+		}
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateServeGRPCIngressName(service.Name),
+			Namespace: service.Namespace,
+			Labels: map[string]string{
+				RayServiceLabelKey:               service.Name,
+				RayClusterServingServiceLabelKey: utils.GenerateServeServiceLabel(service.Name),
+			},
+			Annotations: annotation,
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: utils.GenerateServeServiceName(service.Name),
+											Port: networkingv1.ServiceBackendPort{
+												Number: grpcPort,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if ingressClassName, ok := service.Annotations[IngressClassAnnotationKey]; ok {
+		ingress.Spec.IngressClassName = &ingressClassName
+	} else {
+		logrus.Warn(fmt.Sprintf("ingress class annotation is not set for RayService %s/%s", service.Namespace, service.Name))
+	}
+
+	return ingress, nil
+}
@@ -133,3 +133,81 @@ func TestBuildIngressForHeadService(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildServeIngressForApplications(t *testing.T) {
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayservice-sample",
+			Namespace: "default",
+			Annotations: map[string]string{
+				IngressClassAnnotationKey: "nginx",
+			},
+		},
+		Spec: rayv1alpha1.RayServiceSpec{
+			ServeConfigV2: `
+applications:
+  - name: text_ml_app
+    route_prefix: /summarize_translate
+    import_path: text_ml.app
+  - name: fruit_app
+    import_path: fruit.deployment_graph
+`,
+		},
+	}
+	cluster := *instanceWithIngressEnabled.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultServingPortName, ContainerPort: 8001},
+	}
+
+	ingress, err := BuildServeIngressForApplications(rayService, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, "rayservice-sample-serve-ingress", ingress.Name)
+	assert.Equal(t, rayService.Name, ingress.Labels[RayServiceLabelKey])
+	assert.Equal(t, "", ingress.Annotations[IngressClassAnnotationKey])
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+
+	assert.Equal(t, 1, len(ingress.Spec.Rules))
+	paths := ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths
+	assert.Equal(t, 2, len(paths))
+	assert.Equal(t, "/summarize_translate", paths[0].Path)
+	assert.Equal(t, "rayservice-sample-text-ml-app-serve-svc", paths[0].Backend.Service.Name)
+	assert.Equal(t, "/", paths[1].Path)
+	assert.Equal(t, "rayservice-sample-fruit-app-serve-svc", paths[1].Backend.Service.Name)
+	for _, path := range paths {
+		assert.Equal(t, int32(8001), path.Backend.Service.Port.Number)
+	}
+}
+
+func TestBuildServeGRPCIngressForRayService(t *testing.T) {
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayservice-sample",
+			Namespace: "default",
+			Annotations: map[string]string{
+				IngressClassAnnotationKey: "nginx",
+			},
+		},
+	}
+	cluster := *instanceWithIngressEnabled.DeepCopy()
+
+	// The Ray head container doesn't have a port named "serve-grpc".
+	ingress, err := BuildServeGRPCIngressForRayService(rayService, cluster)
+	assert.NotNil(t, err)
+	assert.Nil(t, ingress)
+
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultServingPortName, ContainerPort: 8000},
+		{Name: DefaultServingGRPCPortName, ContainerPort: 9001},
+	}
+	ingress, err = BuildServeGRPCIngressForRayService(rayService, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, "rayservice-sample-serve-grpc-ingress", ingress.Name)
+	assert.Equal(t, "GRPC", ingress.Annotations[NginxBackendProtocolAnnotationKey])
+	assert.Equal(t, "", ingress.Annotations[IngressClassAnnotationKey])
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+
+	paths := ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths
+	assert.Equal(t, 1, len(paths))
+	assert.Equal(t, utils.GenerateServeServiceName(rayService.Name), paths[0].Backend.Service.Name)
+	assert.Equal(t, int32(9001), paths[0].Backend.Service.Port.Number)
+}
@@ -0,0 +1,288 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// KueueQueueNameLabelKey is the label of a RayJob naming the Kueue LocalQueue the RayJob is submitted to.
+	KueueQueueNameLabelKey = "kueue.x-k8s.io/queue-name"
+	// KueueAdmittedCondition is the condition of a Workload admitted by Kueue.
+	KueueAdmittedCondition = "Admitted"
+	// KueueFinishedCondition is the condition of a Workload whose job finished, which releases its quota.
+	KueueFinishedCondition = "Finished"
+	// KueueHeadPodSetName is the name of the pod set of the head group.
+	KueueHeadPodSetName = "head"
+	// KueuePodSetsHashAnnotationKey is the annotation of a Workload recording the hash of its pod sets, so that the
+	// Workload is recreated when the RayCluster spec it was built from changes.
+	KueuePodSetsHashAnnotationKey = "ray.io/kueue-pod-sets-hash"
+)
+
+var (
+	// Workload and ResourceFlavor are custom resources of Kueue. They are handled as unstructured objects so that KubeRay
+	// does not depend on the Kueue API.
+	WorkloadGVK       = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "Workload"}
+	ResourceFlavorGVK = schema.GroupVersionKind{Group: "kueue.x-k8s.io", Version: "v1beta1", Kind: "ResourceFlavor"}
+)
+
+// KueuePodSetInfo is the scheduling information Kueue assigns to a pod set when it admits a Workload.
+type KueuePodSetInfo struct {
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+}
+
+// IsKueueManaged returns whether the RayJob is queued by Kueue.
+func IsKueueManaged(rayJob *rayv1alpha1.RayJob) bool {
+	return rayJob.Labels[KueueQueueNameLabelKey] != "" && len(rayJob.Spec.ClusterSelector) == 0 && rayJob.Spec.RayClusterSpec != nil
+}
+
+// IsKueueManagedRayCluster returns whether the RayCluster is queued by Kueue. The RayClusters of RayJobs and RayServices
+// copy the labels of their owner, and are queued through their owner instead.
+func IsKueueManagedRayCluster(rayCluster *rayv1alpha1.RayCluster) bool {
+	return rayCluster.Labels[KueueQueueNameLabelKey] != "" && metav1.GetControllerOf(rayCluster) == nil
+}
+
+// BuildWorkloadForRayJob builds the Kueue Workload requesting the quota of the RayCluster of the RayJob.
+func BuildWorkloadForRayJob(rayJob *rayv1alpha1.RayJob) (*unstructured.Unstructured, error) {
+	cluster := rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: rayJob.Name, Namespace: rayJob.Namespace},
+		Spec:       *rayJob.Spec.RayClusterSpec.DeepCopy(),
+	}
+	return buildWorkload(utils.GenerateWorkloadName(rayJob.Name), rayJob.Labels[KueueQueueNameLabelKey], cluster)
+}
+
+// BuildWorkloadForRayCluster builds the Kueue Workload requesting the quota of a standalone RayCluster.
+func BuildWorkloadForRayCluster(rayCluster *rayv1alpha1.RayCluster) (*unstructured.Unstructured, error) {
+	return buildWorkload(utils.GenerateRayClusterWorkloadName(rayCluster.Name), rayCluster.Labels[KueueQueueNameLabelKey], *rayCluster.DeepCopy())
+}
+
+func buildWorkload(name string, queueName string, cluster rayv1alpha1.RayCluster) (*unstructured.Unstructured, error) {
+	hash, err := hashPodSetsSpec(cluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+	podSets, err := buildPodSets(cluster)
+	if err != nil {
+		return nil, err
+	}
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(WorkloadGVK)
+	workload.SetName(name)
+	workload.SetNamespace(cluster.Namespace)
+	workload.SetLabels(map[string]string{
+		KubernetesApplicationNameLabelKey: ApplicationName,
+		KubernetesCreatedByLabelKey:       ComponentName,
+	})
+	workload.SetAnnotations(map[string]string{KueuePodSetsHashAnnotationKey: hash})
+	workload.Object["spec"] = map[string]interface{}{
+		"queueName": queueName,
+		"podSets":   podSets,
+	}
+	return workload, nil
+}
+
+// IsWorkloadOutdated returns whether the pod sets of the Workload differ from the ones of the RayCluster spec. The pod
+// sets of a Workload cannot be updated once Kueue admits it, so an outdated Workload is recreated.
+func IsWorkloadOutdated(workload *unstructured.Unstructured, spec rayv1alpha1.RayClusterSpec) (bool, error) {
+	hash, err := hashPodSetsSpec(spec)
+	if err != nil {
+		return false, err
+	}
+	return workload.GetAnnotations()[KueuePodSetsHashAnnotationKey] != hash, nil
+}
+
+// ValidateKueuePodSets makes sure every worker group can be a pod set of a Kueue Workload. Kueue requires the names of
+// the pod sets to be unique DNS labels, and the head group uses the name KueueHeadPodSetName.
+func ValidateKueuePodSets(spec rayv1alpha1.RayClusterSpec) error {
+	names := map[string]bool{KueueHeadPodSetName: true}
+	for _, worker := range spec.WorkerGroupSpecs {
+		if errs := validation.IsDNS1123Label(worker.GroupName); len(errs) > 0 {
+			return fmt.Errorf("the worker group name %s is not a valid Kueue pod set name: %s", worker.GroupName, strings.Join(errs, ", "))
+		}
+		if names[worker.GroupName] {
+			return fmt.Errorf("the worker group name %s is already used by another Kueue pod set, rename the worker group", worker.GroupName)
+		}
+		names[worker.GroupName] = true
+	}
+	return nil
+}
+
+// buildPodSets returns a pod set for the head group and for each worker group of the RayCluster. The templates of the
+// pod sets are the Pods KubeRay creates, so that the containers added by KubeRay, such as the autoscaler sidecar, are
+// charged to the quota. The count of a worker group is its replicas, or its minReplicas when autoscaling is enabled,
+// the same as for gang scheduling with Volcano.
+func buildPodSets(cluster rayv1alpha1.RayCluster) ([]interface{}, error) {
+	if err := ValidateKueuePodSets(cluster.Spec); err != nil {
+		return nil, err
+	}
+	headPodSet, err := buildPodSet(KueueHeadPodSetName, getHeadPodSetCount(cluster.Spec), BuildHeadPod(*cluster.DeepCopy()).Spec)
+	if err != nil {
+		return nil, err
+	}
+	podSets := []interface{}{headPodSet}
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		podSet, err := buildPodSet(worker.GroupName, getWorkerPodSetCount(cluster.Spec, worker), BuildWorkerPod(*cluster.DeepCopy(), *worker.DeepCopy()).Spec)
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, podSet)
+	}
+	return podSets, nil
+}
+
+// hashPodSetsSpec returns the hash of the parts of the RayCluster spec the pod sets are built from. It is computed from
+// the templates of the spec rather than from the Pods KubeRay builds, so that the Workloads are not recreated when a new
+// version of KubeRay builds different Pods, and the generated Pod names do not change the hash.
+func hashPodSetsSpec(spec rayv1alpha1.RayClusterSpec) (string, error) {
+	podSets := []interface{}{}
+	headPodSet, err := buildPodSet(KueueHeadPodSetName, getHeadPodSetCount(spec), spec.HeadGroupSpec.Template.Spec)
+	if err != nil {
+		return "", err
+	}
+	podSets = append(podSets, headPodSet)
+	for _, worker := range spec.WorkerGroupSpecs {
+		podSet, err := buildPodSet(worker.GroupName, getWorkerPodSetCount(spec, worker), worker.Template.Spec)
+		if err != nil {
+			return "", err
+		}
+		podSets = append(podSets, podSet)
+	}
+	return utils.GenerateJsonHash(podSets)
+}
+
+func getHeadPodSetCount(spec rayv1alpha1.RayClusterSpec) int32 {
+	if spec.HeadGroupSpec.Replicas != nil {
+		return *spec.HeadGroupSpec.Replicas
+	}
+	return 1
+}
+
+func getWorkerPodSetCount(spec rayv1alpha1.RayClusterSpec, worker rayv1alpha1.WorkerGroupSpec) int32 {
+	count := worker.Replicas
+	if spec.EnableInTreeAutoscaling != nil && *spec.EnableInTreeAutoscaling {
+		count = worker.MinReplicas
+	}
+	if count == nil {
+		return 0
+	}
+	return *count
+}
+
+func buildPodSet(name string, count int32, podSpec corev1.PodSpec) (map[string]interface{}, error) {
+	// Kueue only reads the spec of the template, the metadata is dropped to keep the Workload small. The hostname
+	// generated for the worker Pods is specific to each Pod.
+	podSpec.Hostname = ""
+	podSpec.Subdomain = ""
+	unstructuredTemplate, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.PodTemplateSpec{Spec: podSpec})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":     name,
+		"count":    int64(count),
+		"template": unstructuredTemplate,
+	}, nil
+}
+
+// IsWorkloadAdmitted returns whether Kueue admitted the Workload.
+func IsWorkloadAdmitted(workload *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == KueueAdmittedCondition {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+// GetWorkloadFlavors returns the names of the ResourceFlavors Kueue assigned to each pod set of an admitted Workload.
+func GetWorkloadFlavors(workload *unstructured.Unstructured) map[string][]string {
+	flavors := map[string][]string{}
+	assignments, _, _ := unstructured.NestedSlice(workload.Object, "status", "admission", "podSetAssignments")
+	for _, a := range assignments {
+		assignment, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(assignment, "name")
+		resourceFlavors, _, _ := unstructured.NestedStringMap(assignment, "flavors")
+		seen := map[string]bool{}
+		for _, flavor := range resourceFlavors {
+			if !seen[flavor] {
+				seen[flavor] = true
+				flavors[name] = append(flavors[name], flavor)
+			}
+		}
+	}
+	return flavors
+}
+
+// GetResourceFlavorPodSetInfo returns the node labels and tolerations of a ResourceFlavor, which the Pods assigned to
+// the flavor need to be scheduled on its nodes.
+func GetResourceFlavorPodSetInfo(flavor *unstructured.Unstructured) (KueuePodSetInfo, error) {
+	info := KueuePodSetInfo{}
+	nodeLabels, _, err := unstructured.NestedStringMap(flavor.Object, "spec", "nodeLabels")
+	if err != nil {
+		return info, err
+	}
+	info.NodeSelector = nodeLabels
+	tolerations, _, err := unstructured.NestedSlice(flavor.Object, "spec", "tolerations")
+	if err != nil {
+		return info, err
+	}
+	for _, t := range tolerations {
+		toleration := corev1.Toleration{}
+		if m, ok := t.(map[string]interface{}); ok {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &toleration); err != nil {
+				return info, err
+			}
+			info.Tolerations = append(info.Tolerations, toleration)
+		}
+	}
+	return info, nil
+}
+
+// InjectKueuePodSetInfo adds the node selectors and tolerations of the flavors Kueue admitted each pod set with to the
+// Pod templates of the RayCluster.
+func InjectKueuePodSetInfo(spec *rayv1alpha1.RayClusterSpec, podSetInfos map[string]KueuePodSetInfo) {
+	if info, ok := podSetInfos[KueueHeadPodSetName]; ok {
+		injectPodSetInfo(&spec.HeadGroupSpec.Template.Spec, info)
+	}
+	for i := range spec.WorkerGroupSpecs {
+		if info, ok := podSetInfos[spec.WorkerGroupSpecs[i].GroupName]; ok {
+			injectPodSetInfo(&spec.WorkerGroupSpecs[i].Template.Spec, info)
+		}
+	}
+}
+
+func injectPodSetInfo(podSpec *corev1.PodSpec, info KueuePodSetInfo) {
+	if len(info.NodeSelector) > 0 && podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+	for key, value := range info.NodeSelector {
+		podSpec.NodeSelector[key] = value
+	}
+	for _, toleration := range info.Tolerations {
+		found := false
+		for _, existing := range podSpec.Tolerations {
+			if existing.MatchToleration(&toleration) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package common
+
+import (
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+)
+
+func TestBuildWorkloadForRayJob(t *testing.T) {
+	rayJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayjob-sample",
+			Namespace: "default",
+			Labels:    map[string]string{KueueQueueNameLabelKey: "user-queue"},
+		},
+		Spec: rayv1alpha1.RayJobSpec{
+			RayClusterSpec: instance.Spec.DeepCopy(),
+		},
+	}
+	assert.True(t, IsKueueManaged(rayJob))
+
+	workload, err := BuildWorkloadForRayJob(rayJob)
+	assert.Nil(t, err)
+	assert.Equal(t, WorkloadGVK, workload.GroupVersionKind())
+	assert.Equal(t, "rayjob-rayjob-sample", workload.GetName())
+	queueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+	assert.Equal(t, "user-queue", queueName)
+
+	podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	assert.Len(t, podSets, 2)
+	head := podSets[0].(map[string]interface{})
+	assert.Equal(t, KueueHeadPodSetName, head["name"])
+	assert.Equal(t, int64(1), head["count"])
+	containers, _, _ := unstructured.NestedSlice(head, "template", "spec", "containers")
+	assert.Len(t, containers, 1)
+	worker := podSets[1].(map[string]interface{})
+	assert.Equal(t, "small-group", worker["name"])
+	assert.Equal(t, int64(3), worker["count"])
+
+	// The minReplicas of the worker groups are requested when autoscaling is enabled.
+	rayJob.Spec.RayClusterSpec.EnableInTreeAutoscaling = pointer.BoolPtr(true)
+	rayJob.Spec.RayClusterSpec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32Ptr(1)
+	workload, err = BuildWorkloadForRayJob(rayJob)
+	assert.Nil(t, err)
+	podSets, _, _ = unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	assert.Equal(t, int64(1), podSets[1].(map[string]interface{})["count"])
+	// The autoscaler sidecar KubeRay adds to the head Pod is charged to the quota.
+	containers, _, _ = unstructured.NestedSlice(podSets[0].(map[string]interface{}), "template", "spec", "containers")
+	assert.Len(t, containers, 2)
+
+	// RayJobs submitted to an existing RayCluster are not queued.
+	rayJob.Spec.ClusterSelector = map[string]string{RayClusterLabelKey: "raycluster-sample"}
+	assert.False(t, IsKueueManaged(rayJob))
+}
+
+func TestBuildWorkloadForRayCluster(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Labels = map[string]string{KueueQueueNameLabelKey: "user-queue"}
+	assert.True(t, IsKueueManagedRayCluster(cluster))
+
+	workload, err := BuildWorkloadForRayCluster(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, "raycluster-raycluster-sample", workload.GetName())
+	queueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+	assert.Equal(t, "user-queue", queueName)
+	podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	assert.Len(t, podSets, 2)
+
+	// The Workload is outdated once the pod sets of the RayCluster change.
+	outdated, err := IsWorkloadOutdated(workload, cluster.Spec)
+	assert.Nil(t, err)
+	assert.False(t, outdated)
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "rayproject/ray:2.7.0"
+	outdated, err = IsWorkloadOutdated(workload, cluster.Spec)
+	assert.Nil(t, err)
+	assert.True(t, outdated)
+
+	// Changes outside of the pod sets do not requeue the RayCluster.
+	workload, err = BuildWorkloadForRayCluster(cluster)
+	assert.Nil(t, err)
+	cluster.Spec.HeadGroupSpec.ServiceType = corev1.ServiceTypeNodePort
+	outdated, err = IsWorkloadOutdated(workload, cluster.Spec)
+	assert.Nil(t, err)
+	assert.False(t, outdated)
+}
+
+func TestValidateKueuePodSets(t *testing.T) {
+	cluster := instance.DeepCopy()
+	assert.Nil(t, ValidateKueuePodSets(cluster.Spec))
+
+	// A worker group named like the head pod set would get the flavors of the head.
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = KueueHeadPodSetName
+	assert.NotNil(t, ValidateKueuePodSets(cluster.Spec))
+	_, err := BuildWorkloadForRayCluster(cluster)
+	assert.NotNil(t, err)
+
+	// Kueue rejects pod set names which are not DNS labels.
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = "Small_Group"
+	assert.NotNil(t, ValidateKueuePodSets(cluster.Spec))
+
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = "small-group"
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, cluster.Spec.WorkerGroupSpecs[0])
+	assert.NotNil(t, ValidateKueuePodSets(cluster.Spec))
+}
+
+func TestKueueAdmission(t *testing.T) {
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": KueueAdmittedCondition, "status": "True"},
+			},
+			"admission": map[string]interface{}{
+				"podSetAssignments": []interface{}{
+					map[string]interface{}{
+						"name":    KueueHeadPodSetName,
+						"flavors": map[string]interface{}{"cpu": "on-demand", "memory": "on-demand"},
+					},
+					map[string]interface{}{
+						"name":    "small-group",
+						"flavors": map[string]interface{}{"cpu": "spot"},
+					},
+				},
+			},
+		},
+	}}
+	assert.True(t, IsWorkloadAdmitted(workload))
+	assert.Equal(t, map[string][]string{
+		KueueHeadPodSetName: {"on-demand"},
+		"small-group":       {"spot"},
+	}, GetWorkloadFlavors(workload))
+
+	flavor := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"nodeLabels": map[string]interface{}{"instance-type": "spot"},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "spot", "operator": "Exists", "effect": "NoSchedule"},
+			},
+		},
+	}}
+	info, err := GetResourceFlavorPodSetInfo(flavor)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"instance-type": "spot"}, info.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{{Key: "spot", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}, info.Tolerations)
+
+	// Injecting the same flavor twice does not duplicate the tolerations.
+	spec := instance.Spec.DeepCopy()
+	InjectKueuePodSetInfo(spec, map[string]KueuePodSetInfo{"small-group": info})
+	InjectKueuePodSetInfo(spec, map[string]KueuePodSetInfo{"small-group": info})
+	assert.Equal(t, "spot", spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector["instance-type"])
+	assert.Len(t, spec.WorkerGroupSpecs[0].Template.Spec.Tolerations, 1)
+	assert.Nil(t, spec.HeadGroupSpec.Template.Spec.NodeSelector)
+
+	assert.Nil(t, unstructured.SetNestedSlice(workload.Object, []interface{}{}, "status", "conditions"))
+	assert.False(t, IsWorkloadAdmitted(workload))
+}
@@ -1,8 +1,12 @@
 package common
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -38,19 +42,209 @@ var (
 	)
 )
 
+// Define the prometheus metrics for the lifecycle of RayClusters, RayJobs and RayServices
+var (
+	clusterProvisioningDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_cluster_provisioning_duration_seconds",
+			Help:    "Time from the creation of a RayCluster until it becomes ready for the first time",
+			Buckets: []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		},
+		[]string{"namespace"},
+	)
+	rayJobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_rayjob_duration_seconds",
+			Help:    "Duration of Ray jobs that reached a terminal status, by outcome",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 12),
+		},
+		[]string{"namespace", "job_status"},
+	)
+	rayJobDeploymentStatusDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_rayjob_deployment_status_duration_seconds",
+			Help:    "Time RayJobs spent in a JobDeploymentStatus before moving to another one",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+		},
+		[]string{"namespace", "deployment_status"},
+	)
+	rayServiceUpgradesCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_rayservice_upgrades_total",
+			Help: "Counts number of RayCluster upgrades triggered by changes of the RayCluster spec of a RayService",
+		},
+		[]string{"namespace", "rayservice"},
+	)
+	rayServiceUpgradeDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_rayservice_upgrade_duration_seconds",
+			Help:    "Time from the creation of the pending RayCluster of a RayService until it replaces the active RayCluster",
+			Buckets: []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		},
+		[]string{"namespace"},
+	)
+	rayServiceUnhealthyRestartsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_rayservice_unhealthy_restarts_total",
+			Help: "Counts number of RayCluster restarts of a RayService because the dashboard or the Serve applications are unhealthy",
+		},
+		[]string{"namespace", "rayservice", "reason"},
+	)
+)
+
+// Reasons of the unhealthy restarts of a RayService.
+const (
+	RayServiceRestartReasonDashboardUnhealthy = "dashboard_unhealthy"
+	RayServiceRestartReasonServeUnhealthy     = "serve_unhealthy"
+)
+
+// Define the prometheus gauges for the states of all RayClusters
+var rayClusterMetrics = newRayClusterCollector()
+
+// rayClusterCollector exports the number of RayClusters in each state, and remembers which RayClusters are still
+// being provisioned so that their provisioning latency is observed once.
+type rayClusterCollector struct {
+	clustersDesc *prometheus.Desc
+
+	mu sync.Mutex
+	// Key is the namespace/name of the RayCluster.
+	rayClusters map[string]*rayClusterMetricsState
+}
+
+type rayClusterMetricsState struct {
+	namespace string
+	state     rayv1alpha1.ClusterState
+	// provisioning is true if the RayCluster had no state when it was first observed and has not been ready since.
+	provisioning bool
+}
+
+func newRayClusterCollector() *rayClusterCollector {
+	return &rayClusterCollector{
+		clustersDesc: prometheus.NewDesc(
+			"ray_operator_clusters",
+			"Number of RayClusters in each state",
+			[]string{"namespace", "state"}, nil,
+		),
+		rayClusters: make(map[string]*rayClusterMetricsState),
+	}
+}
+
+func (c *rayClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clustersDesc
+}
+
+func (c *rayClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[[2]string]int)
+	for _, cluster := range c.rayClusters {
+		if cluster.state == "" {
+			continue
+		}
+		counts[[2]string{cluster.namespace, string(cluster.state)}]++
+	}
+	for labelValues, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.clustersDesc, prometheus.GaugeValue, float64(count), labelValues[0], labelValues[1])
+	}
+}
+
+// rayJobDeploymentStatusSince remembers when each RayJob entered its current JobDeploymentStatus.
+var rayJobDeploymentStatusSince = struct {
+	mu sync.Mutex
+	// Key is the namespace/name of the RayJob.
+	rayJobs map[string]rayJobDeploymentStatusEntry
+}{rayJobs: make(map[string]rayJobDeploymentStatusEntry)}
+
+// rayServiceRestartedClusters remembers the last RayCluster each RayService restarted because it was unhealthy. The
+// RayCluster stays unhealthy until it is replaced, and its restart must only be counted once.
+var rayServiceRestartedClusters = struct {
+	mu sync.Mutex
+	// Key is the namespace/name of the RayService, value is the name of the RayCluster.
+	rayServices map[string]string
+}{rayServices: make(map[string]string)}
+
+type rayJobDeploymentStatusEntry struct {
+	status rayv1alpha1.JobDeploymentStatus
+	since  time.Time
+}
+
+// Define the prometheus gauges for the Serve deployments of all RayServices
+var serveDeploymentMetrics = newServeDeploymentCollector()
+
+// serveDeploymentCollector exports the replica counts of Serve deployments as reported in the latest
+// observed status of each RayService. Deployments that disappear from the status stop being exported.
+type serveDeploymentCollector struct {
+	targetReplicasDesc  *prometheus.Desc
+	runningReplicasDesc *prometheus.Desc
+
+	mu sync.Mutex
+	// Key is the namespace/name of the RayService.
+	rayServices map[string][]serveDeploymentReplicas
+}
+
+type serveDeploymentReplicas struct {
+	labelValues     []string
+	targetReplicas  *int32
+	runningReplicas *int32
+}
+
+func newServeDeploymentCollector() *serveDeploymentCollector {
+	labels := []string{"namespace", "rayservice", "application", "deployment"}
+	return &serveDeploymentCollector{
+		targetReplicasDesc: prometheus.NewDesc(
+			"ray_operator_serve_deployment_target_replicas",
+			"Target number of replicas of a Serve deployment",
+			labels, nil,
+		),
+		runningReplicasDesc: prometheus.NewDesc(
+			"ray_operator_serve_deployment_running_replicas",
+			"Number of running replicas of a Serve deployment",
+			labels, nil,
+		),
+		rayServices: make(map[string][]serveDeploymentReplicas),
+	}
+}
+
+func (c *serveDeploymentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.targetReplicasDesc
+	ch <- c.runningReplicasDesc
+}
+
+func (c *serveDeploymentCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, deployments := range c.rayServices {
+		for _, deployment := range deployments {
+			if deployment.targetReplicas != nil {
+				ch <- prometheus.MustNewConstMetric(c.targetReplicasDesc, prometheus.GaugeValue, float64(*deployment.targetReplicas), deployment.labelValues...)
+			}
+			if deployment.runningReplicas != nil {
+				ch <- prometheus.MustNewConstMetric(c.runningReplicasDesc, prometheus.GaugeValue, float64(*deployment.runningReplicas), deployment.labelValues...)
+			}
+		}
+	}
+}
+
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(clustersCreatedCount,
 		clustersDeletedCount,
 		clustersSuccessfulCount,
-		clustersFailedCount)
+		clustersFailedCount,
+		clusterProvisioningDuration,
+		rayJobDuration,
+		rayJobDeploymentStatusDuration,
+		rayServiceUpgradesCount,
+		rayServiceUpgradeDuration,
+		rayServiceUnhealthyRestartsCount,
+		rayClusterMetrics,
+		serveDeploymentMetrics)
 }
 
 func CreatedClustersCounterInc(namespace string) {
 	clustersCreatedCount.WithLabelValues(namespace).Inc()
 }
 
-// TODO: We don't handle the delete events in new reconciler mode, how to emit deletion metrics?
 func DeletedClustersCounterInc(namespace string) {
 	clustersDeletedCount.WithLabelValues(namespace).Inc()
 }
@@ -62,3 +256,129 @@ func SuccessfulClustersCounterInc(namespace string) {
 func FailedClustersCounterInc(namespace string) {
 	clustersFailedCount.WithLabelValues(namespace).Inc()
 }
+
+// ObserveRayClusterState records the state of the RayCluster. The provisioning latency is observed when a RayCluster
+// that had no state when it was first observed becomes ready.
+func ObserveRayClusterState(cluster *rayv1alpha1.RayCluster) {
+	rayClusterMetrics.mu.Lock()
+	defer rayClusterMetrics.mu.Unlock()
+	key := cluster.Namespace + "/" + cluster.Name
+	state, ok := rayClusterMetrics.rayClusters[key]
+	if !ok {
+		state = &rayClusterMetricsState{namespace: cluster.Namespace, provisioning: cluster.Status.State == ""}
+		rayClusterMetrics.rayClusters[key] = state
+	}
+	state.state = cluster.Status.State
+	if state.provisioning && state.state == rayv1alpha1.Ready {
+		state.provisioning = false
+		clusterProvisioningDuration.WithLabelValues(cluster.Namespace).Observe(time.Since(cluster.CreationTimestamp.Time).Seconds())
+	}
+}
+
+// DeleteRayClusterMetrics stops exporting the state of a deleted RayCluster and counts its deletion.
+func DeleteRayClusterMetrics(namespace string, name string) {
+	rayClusterMetrics.mu.Lock()
+	defer rayClusterMetrics.mu.Unlock()
+	key := namespace + "/" + name
+	if _, ok := rayClusterMetrics.rayClusters[key]; ok {
+		delete(rayClusterMetrics.rayClusters, key)
+		DeletedClustersCounterInc(namespace)
+	}
+}
+
+// ObserveRayJobStatus observes the time the RayJob spent in its previous JobDeploymentStatus, and the duration of the
+// Ray job once it reaches a terminal status. It is called after the status of the RayJob has been updated.
+func ObserveRayJobStatus(rayJob *rayv1alpha1.RayJob, oldJobStatus rayv1alpha1.JobStatus, oldJobDeploymentStatus rayv1alpha1.JobDeploymentStatus) {
+	now := time.Now()
+	if rayJob.Status.JobDeploymentStatus != oldJobDeploymentStatus {
+		rayJobDeploymentStatusSince.mu.Lock()
+		key := rayJob.Namespace + "/" + rayJob.Name
+		if entry, ok := rayJobDeploymentStatusSince.rayJobs[key]; ok && entry.status == oldJobDeploymentStatus {
+			rayJobDeploymentStatusDuration.WithLabelValues(rayJob.Namespace, string(oldJobDeploymentStatus)).Observe(now.Sub(entry.since).Seconds())
+		}
+		rayJobDeploymentStatusSince.rayJobs[key] = rayJobDeploymentStatusEntry{status: rayJob.Status.JobDeploymentStatus, since: now}
+		rayJobDeploymentStatusSince.mu.Unlock()
+	}
+
+	if !rayv1alpha1.IsJobTerminal(oldJobStatus) && rayv1alpha1.IsJobTerminal(rayJob.Status.JobStatus) {
+		// Use the start and end time reported by Ray if possible, and the lifetime of the RayJob otherwise.
+		start, end := rayJob.CreationTimestamp.Time, now
+		if rayJob.Status.StartTime != nil && rayJob.Status.EndTime != nil {
+			start, end = rayJob.Status.StartTime.Time, rayJob.Status.EndTime.Time
+		}
+		rayJobDuration.WithLabelValues(rayJob.Namespace, string(rayJob.Status.JobStatus)).Observe(end.Sub(start).Seconds())
+	}
+}
+
+// DeleteRayJobMetrics forgets the JobDeploymentStatus of a deleted RayJob.
+func DeleteRayJobMetrics(namespace string, name string) {
+	rayJobDeploymentStatusSince.mu.Lock()
+	defer rayJobDeploymentStatusSince.mu.Unlock()
+	delete(rayJobDeploymentStatusSince.rayJobs, namespace+"/"+name)
+}
+
+func RayServiceUpgradesCounterInc(namespace string, name string) {
+	rayServiceUpgradesCount.WithLabelValues(namespace, name).Inc()
+}
+
+// RayServiceUnhealthyRestartsCounterInc counts the restart of the unhealthy RayCluster clusterName of the RayService,
+// unless the RayService already restarted it.
+func RayServiceUnhealthyRestartsCounterInc(namespace string, name string, clusterName string, reason string) {
+	rayServiceRestartedClusters.mu.Lock()
+	defer rayServiceRestartedClusters.mu.Unlock()
+	key := namespace + "/" + name
+	if rayServiceRestartedClusters.rayServices[key] == clusterName {
+		return
+	}
+	rayServiceRestartedClusters.rayServices[key] = clusterName
+	rayServiceUnhealthyRestartsCount.WithLabelValues(namespace, name, reason).Inc()
+}
+
+// ObserveRayServiceUpgradeDuration observes the time the pending RayCluster took to replace the active RayCluster. It
+// must not be called when the pending RayCluster replaces an unhealthy RayCluster with the same spec.
+func ObserveRayServiceUpgradeDuration(namespace string, pendingCluster *rayv1alpha1.RayCluster) {
+	rayServiceUpgradeDuration.WithLabelValues(namespace).Observe(time.Since(pendingCluster.CreationTimestamp.Time).Seconds())
+}
+
+// DeleteRayServiceMetrics stops exporting all the metrics of a deleted RayService.
+func DeleteRayServiceMetrics(namespace string, name string) {
+	rayServiceUpgradesCount.DeleteLabelValues(namespace, name)
+	for _, reason := range []string{RayServiceRestartReasonDashboardUnhealthy, RayServiceRestartReasonServeUnhealthy} {
+		rayServiceUnhealthyRestartsCount.DeleteLabelValues(namespace, name, reason)
+	}
+	rayServiceRestartedClusters.mu.Lock()
+	delete(rayServiceRestartedClusters.rayServices, namespace+"/"+name)
+	rayServiceRestartedClusters.mu.Unlock()
+	DeleteServeDeploymentMetrics(namespace, name)
+}
+
+// UpdateServeDeploymentMetrics replaces the exported Serve deployment gauges of the RayService with the
+// deployments of its active cluster, or of its pending cluster if there is no active cluster yet.
+func UpdateServeDeploymentMetrics(rayService *rayv1alpha1.RayService) {
+	serveStatus := rayService.Status.ActiveServiceStatus
+	if serveStatus.RayClusterName == "" {
+		serveStatus = rayService.Status.PendingServiceStatus
+	}
+
+	var deployments []serveDeploymentReplicas
+	for appName, appStatus := range serveStatus.Applications {
+		for deploymentName, deploymentStatus := range appStatus.Deployments {
+			deployments = append(deployments, serveDeploymentReplicas{
+				labelValues:     []string{rayService.Namespace, rayService.Name, appName, deploymentName},
+				targetReplicas:  deploymentStatus.TargetReplicas,
+				runningReplicas: deploymentStatus.RunningReplicas,
+			})
+		}
+	}
+
+	serveDeploymentMetrics.mu.Lock()
+	defer serveDeploymentMetrics.mu.Unlock()
+	serveDeploymentMetrics.rayServices[rayService.Namespace+"/"+rayService.Name] = deployments
+}
+
+// DeleteServeDeploymentMetrics stops exporting the Serve deployment gauges of a deleted RayService.
+func DeleteServeDeploymentMetrics(namespace string, name string) {
+	serveDeploymentMetrics.mu.Lock()
+	defer serveDeploymentMetrics.mu.Unlock()
+	delete(serveDeploymentMetrics.rayServices, namespace+"/"+name)
+}
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestServeDeploymentMetrics(t *testing.T) {
+	rayService := &rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayservice-sample",
+			Namespace: "default",
+		},
+		Status: rayv1alpha1.RayServiceStatuses{
+			PendingServiceStatus: rayv1alpha1.RayServiceStatus{
+				RayClusterName: "rayservice-sample-raycluster-pending",
+				Applications: map[string]rayv1alpha1.AppStatus{
+					"app1": {
+						Deployments: map[string]rayv1alpha1.ServeDeploymentStatus{
+							"deployment1": {TargetReplicas: pointer.Int32(3), RunningReplicas: pointer.Int32(1)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Without an active cluster, the deployments of the pending cluster are exported.
+	UpdateServeDeploymentMetrics(rayService)
+	expected := `
+# HELP ray_operator_serve_deployment_running_replicas Number of running replicas of a Serve deployment
+# TYPE ray_operator_serve_deployment_running_replicas gauge
+ray_operator_serve_deployment_running_replicas{application="app1",deployment="deployment1",namespace="default",rayservice="rayservice-sample"} 1
+# HELP ray_operator_serve_deployment_target_replicas Target number of replicas of a Serve deployment
+# TYPE ray_operator_serve_deployment_target_replicas gauge
+ray_operator_serve_deployment_target_replicas{application="app1",deployment="deployment1",namespace="default",rayservice="rayservice-sample"} 3
+`
+	assert.Nil(t, testutil.CollectAndCompare(serveDeploymentMetrics, strings.NewReader(expected)))
+
+	// Once the cluster becomes active, deployments that are gone are no longer exported.
+	rayService.Status.ActiveServiceStatus = rayv1alpha1.RayServiceStatus{
+		RayClusterName: "rayservice-sample-raycluster-active",
+		Applications: map[string]rayv1alpha1.AppStatus{
+			"app1": {
+				Deployments: map[string]rayv1alpha1.ServeDeploymentStatus{
+					"deployment2": {TargetReplicas: pointer.Int32(2), RunningReplicas: pointer.Int32(2)},
+				},
+			},
+		},
+	}
+	rayService.Status.PendingServiceStatus = rayv1alpha1.RayServiceStatus{}
+	UpdateServeDeploymentMetrics(rayService)
+	assert.Equal(t, 2, testutil.CollectAndCount(serveDeploymentMetrics))
+	assert.Equal(t, 1, testutil.CollectAndCount(serveDeploymentMetrics, "ray_operator_serve_deployment_target_replicas"))
+
+	DeleteServeDeploymentMetrics(rayService.Namespace, rayService.Name)
+	assert.Equal(t, 0, testutil.CollectAndCount(serveDeploymentMetrics))
+}
+
+func TestRayClusterMetrics(t *testing.T) {
+	cluster := &rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "raycluster-metrics",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+		},
+	}
+
+	// A RayCluster without a state is provisioning and is not counted in any state.
+	ObserveRayClusterState(cluster)
+	assert.Equal(t, 0, testutil.CollectAndCount(rayClusterMetrics))
+
+	// The provisioning latency is observed once when the RayCluster becomes ready.
+	cluster.Status.State = rayv1alpha1.Ready
+	ObserveRayClusterState(cluster)
+	cluster.Status.State = rayv1alpha1.Unhealthy
+	ObserveRayClusterState(cluster)
+	cluster.Status.State = rayv1alpha1.Ready
+	ObserveRayClusterState(cluster)
+	assert.Equal(t, 1, testutil.CollectAndCount(clusterProvisioningDuration))
+	expected := `
+# HELP ray_operator_clusters Number of RayClusters in each state
+# TYPE ray_operator_clusters gauge
+ray_operator_clusters{namespace="default",state="ready"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(rayClusterMetrics, strings.NewReader(expected)))
+
+	// The deletion is counted once and the RayCluster is no longer exported.
+	deleted := testutil.ToFloat64(clustersDeletedCount.WithLabelValues("default"))
+	DeleteRayClusterMetrics(cluster.Namespace, cluster.Name)
+	DeleteRayClusterMetrics(cluster.Namespace, cluster.Name)
+	assert.Equal(t, deleted+1, testutil.ToFloat64(clustersDeletedCount.WithLabelValues("default")))
+	assert.Equal(t, 0, testutil.CollectAndCount(rayClusterMetrics))
+}
+
+func TestRayJobMetrics(t *testing.T) {
+	rayJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rayjob-metrics",
+			Namespace: "default",
+		},
+	}
+
+	// The time spent in a JobDeploymentStatus is observed when the RayJob leaves it.
+	rayJob.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusInitializing
+	ObserveRayJobStatus(rayJob, "", "")
+	assert.Equal(t, 0, testutil.CollectAndCount(rayJobDeploymentStatusDuration))
+	rayJob.Status.JobDeploymentStatus = rayv1alpha1.JobDeploymentStatusRunning
+	rayJob.Status.JobStatus = rayv1alpha1.JobStatusRunning
+	ObserveRayJobStatus(rayJob, "", rayv1alpha1.JobDeploymentStatusInitializing)
+	assert.Equal(t, 1, testutil.CollectAndCount(rayJobDeploymentStatusDuration))
+
+	// The duration reported by Ray is observed when the Ray job reaches a terminal status.
+	start := metav1.NewTime(time.Now().Add(-time.Hour))
+	end := metav1.NewTime(start.Add(30 * time.Minute))
+	rayJob.Status.StartTime, rayJob.Status.EndTime = &start, &end
+	rayJob.Status.JobStatus = rayv1alpha1.JobStatusSucceeded
+	ObserveRayJobStatus(rayJob, rayv1alpha1.JobStatusRunning, rayv1alpha1.JobDeploymentStatusRunning)
+	assert.Equal(t, 1, testutil.CollectAndCount(rayJobDuration))
+
+	DeleteRayJobMetrics(rayJob.Namespace, rayJob.Name)
+	assert.Empty(t, rayJobDeploymentStatusSince.rayJobs)
+}
+
+func TestRayServiceMetrics(t *testing.T) {
+	RayServiceUpgradesCounterInc("default", "rayservice-metrics")
+	assert.Equal(t, float64(1), testutil.ToFloat64(rayServiceUpgradesCount.WithLabelValues("default", "rayservice-metrics")))
+
+	// The restart of an unhealthy RayCluster is counted once.
+	RayServiceUnhealthyRestartsCounterInc("default", "rayservice-metrics", "cluster-1", RayServiceRestartReasonServeUnhealthy)
+	RayServiceUnhealthyRestartsCounterInc("default", "rayservice-metrics", "cluster-1", RayServiceRestartReasonServeUnhealthy)
+	assert.Equal(t, float64(1), testutil.ToFloat64(rayServiceUnhealthyRestartsCount.WithLabelValues("default", "rayservice-metrics", RayServiceRestartReasonServeUnhealthy)))
+	RayServiceUnhealthyRestartsCounterInc("default", "rayservice-metrics", "cluster-2", RayServiceRestartReasonServeUnhealthy)
+	assert.Equal(t, float64(2), testutil.ToFloat64(rayServiceUnhealthyRestartsCount.WithLabelValues("default", "rayservice-metrics", RayServiceRestartReasonServeUnhealthy)))
+
+	DeleteRayServiceMetrics("default", "rayservice-metrics")
+	assert.Equal(t, 0, testutil.CollectAndCount(rayServiceUpgradesCount))
+	assert.Equal(t, 0, testutil.CollectAndCount(rayServiceUnhealthyRestartsCount))
+	assert.Empty(t, rayServiceRestartedClusters.rayServices)
+}
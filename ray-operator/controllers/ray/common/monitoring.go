@@ -0,0 +1,79 @@
+package common
+
+import (
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// PodMonitor and ServiceMonitor are custom resources of the Prometheus Operator. They are handled as unstructured objects
+	// so that KubeRay does not depend on the Prometheus Operator API.
+	PodMonitorGVK     = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+	ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+)
+
+// IsMonitoringEnabled returns whether KubeRay creates a Prometheus Operator monitor for the RayCluster.
+func IsMonitoringEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.Monitoring != nil
+}
+
+// GetMonitorGVK returns the kind of monitor KubeRay creates for the RayCluster.
+func GetMonitorGVK(instance rayv1alpha1.RayCluster) schema.GroupVersionKind {
+	if instance.Spec.Monitoring != nil && instance.Spec.Monitoring.Type == rayv1alpha1.MonitorTypeServiceMonitor {
+		return ServiceMonitorGVK
+	}
+	return PodMonitorGVK
+}
+
+// BuildMonitor builds the PodMonitor scraping the metrics port of every Ray Pod of the RayCluster, or the ServiceMonitor
+// scraping the metrics port of its head service. The endpoints refer to the metrics port by name, which DefaultHeadPodTemplate
+// and DefaultWorkerPodTemplate add to every Ray container, so a customized port number is followed without changing the monitor.
+func BuildMonitor(instance rayv1alpha1.RayCluster) *unstructured.Unstructured {
+	options := instance.Spec.Monitoring
+	endpoint := map[string]interface{}{"port": DefaultMetricsName}
+	if options.Interval != "" {
+		endpoint["interval"] = options.Interval
+	}
+
+	gvk := GetMonitorGVK(instance)
+	var spec map[string]interface{}
+	if gvk == ServiceMonitorGVK {
+		spec = map[string]interface{}{
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					RayClusterLabelKey: instance.Name,
+					RayIDLabelKey:      utils.CheckLabel(utils.GenerateIdentifier(instance.Name, rayv1alpha1.HeadNode)),
+				},
+			},
+			"endpoints":    []interface{}{endpoint},
+			"targetLabels": []interface{}{RayClusterLabelKey},
+		}
+	} else {
+		spec = map[string]interface{}{
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					RayClusterLabelKey: instance.Name,
+				},
+			},
+			"podMetricsEndpoints": []interface{}{endpoint},
+			"podTargetLabels":     []interface{}{RayClusterLabelKey, RayNodeTypeLabelKey, RayNodeGroupLabelKey},
+		}
+	}
+
+	labels := map[string]string{}
+	for k, v := range options.Labels {
+		labels[k] = v
+	}
+	labels[RayClusterLabelKey] = instance.Name
+	labels[KubernetesApplicationNameLabelKey] = ApplicationName
+	labels[KubernetesCreatedByLabelKey] = ComponentName
+
+	monitor := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	monitor.SetGroupVersionKind(gvk)
+	monitor.SetName(utils.GenerateMonitorName(instance.Name))
+	monitor.SetNamespace(instance.Namespace)
+	monitor.SetLabels(labels)
+	return monitor
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuildMonitor(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.Monitoring = &rayv1alpha1.MonitoringOptions{
+		Labels:   map[string]string{"release": "prometheus", RayClusterLabelKey: "overridden"},
+		Interval: "30s",
+	}
+
+	// PodMonitor is the default.
+	monitor := BuildMonitor(*cluster)
+	assert.Equal(t, PodMonitorGVK, monitor.GroupVersionKind())
+	assert.Equal(t, "raycluster-sample-monitor", monitor.GetName())
+	assert.Equal(t, cluster.Namespace, monitor.GetNamespace())
+	assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+	assert.Equal(t, cluster.Name, monitor.GetLabels()[RayClusterLabelKey])
+	selector, _, _ := unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{RayClusterLabelKey: cluster.Name}, selector)
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": DefaultMetricsName, "interval": "30s"}}, endpoints)
+
+	// ServiceMonitor selects the head service.
+	cluster.Spec.Monitoring.Type = rayv1alpha1.MonitorTypeServiceMonitor
+	cluster.Spec.Monitoring.Interval = ""
+	monitor = BuildMonitor(*cluster)
+	assert.Equal(t, ServiceMonitorGVK, monitor.GroupVersionKind())
+	selector, _, _ = unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, "raycluster-sample-head", selector[RayIDLabelKey])
+	endpoints, _, _ = unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": DefaultMetricsName}}, endpoints)
+}
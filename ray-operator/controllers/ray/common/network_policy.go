@@ -0,0 +1,196 @@
+package common
+
+import (
+	"sort"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// Label set by Kubernetes on every namespace since v1.21.
+	KubernetesNamespaceNameLabelKey = "kubernetes.io/metadata.name"
+	// Label set by the Job controller on the Pods of a Job.
+	KubernetesJobNameLabelKey = "job-name"
+
+	maxPort = 65535
+)
+
+// IsNetworkIsolationEnabled returns whether the NetworkPolicy of the RayCluster only allows the peers in spec.networkIsolation.
+func IsNetworkIsolationEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.NetworkIsolation != nil
+}
+
+// IsNetworkPolicyEnabled returns whether KubeRay manages a NetworkPolicy for the Pods of the RayCluster, either to isolate
+// them or to keep the dashboard agent behind the dashboard auth proxy.
+func IsNetworkPolicyEnabled(instance rayv1alpha1.RayCluster) bool {
+	return IsNetworkIsolationEnabled(instance) || IsDashboardAuthEnabled(instance)
+}
+
+// BuildNetworkPolicy builds the NetworkPolicy that only allows the Pods of the RayCluster to reach each other, and the peers in
+// spec.networkIsolation to reach the dashboard, client, serve, and metrics ports. The operator namespace, if not empty, and the
+// submitter Pods of the RayJob owning the RayCluster can always reach the dashboard and serve ports, and the operator namespace
+// reaches the dashboard metrics port when spec.idlePolicy is set. Without
+// spec.networkIsolation, the NetworkPolicy only closes the dashboard and dashboard agent ports of the Ray Pods, which are
+// served by the dashboard auth proxy instead.
+func BuildNetworkPolicy(cluster rayv1alpha1.RayCluster, operatorNamespace string) *networkingv1.NetworkPolicy {
+	options := cluster.Spec.NetworkIsolation
+	clusterSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{RayClusterLabelKey: cluster.Name},
+	}
+	intraClusterRule := buildIntraClusterIngressRule(cluster, clusterSelector)
+	if options == nil {
+		return newNetworkPolicy(cluster, clusterSelector, buildDashboardAuthIngress(cluster, intraClusterRule))
+	}
+
+	var operatorNamespacePeers []networkingv1.NetworkPolicyPeer
+	if operatorNamespace != "" {
+		operatorNamespacePeers = append(operatorNamespacePeers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{KubernetesNamespaceNameLabelKey: operatorNamespace},
+			},
+		})
+	}
+	operatorPeers := append([]networkingv1.NetworkPolicyPeer{}, operatorNamespacePeers...)
+	for _, owner := range cluster.OwnerReferences {
+		if owner.Kind == string(utils.RayJobCRD) {
+			// The submitter Job of a RayJob has the same name as the RayJob.
+			operatorPeers = append(operatorPeers, networkingv1.NetworkPolicyPeer{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{KubernetesJobNameLabelKey: owner.Name},
+				},
+			})
+		}
+	}
+
+	servicePorts := getServicePorts(cluster)
+	portsOf := func(names ...string) []int32 {
+		var ports []int32
+		for _, name := range names {
+			if port, ok := servicePorts[name]; ok {
+				ports = append(ports, port)
+			}
+		}
+		return ports
+	}
+
+	// The dashboard agent listens on every Ray Pod, and the RayService controller talks to it through the head service.
+	var dashboardPorts []int32
+	if IsDashboardAuthEnabled(cluster) {
+		// The dashboard and the dashboard agent are only reachable through the authenticating proxy.
+		dashboardPorts = []int32{DashboardAuthProxyPort, DashboardAgentAuthProxyPort}
+	} else {
+		rayContainer := &cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex]
+		dashboardPorts = []int32{
+			int32(utils.FindContainerPort(rayContainer, DefaultDashboardName, DefaultDashboardPort)),
+			int32(utils.FindContainerPort(rayContainer, DefaultDashboardAgentListenPortName, DefaultDashboardAgentListenPort)),
+		}
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{intraClusterRule}
+	addRule := func(peers []networkingv1.NetworkPolicyPeer, ports []int32) {
+		// A rule without peers would allow all sources, so it is omitted instead.
+		if len(peers) == 0 || len(ports) == 0 {
+			return
+		}
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  peers,
+			Ports: buildNetworkPolicyPorts(ports),
+		})
+	}
+	addRule(append(append([]networkingv1.NetworkPolicyPeer{}, options.DashboardPeers...), operatorPeers...), dashboardPorts)
+	addRule(options.ClientPeers, portsOf(DefaultClientPortName))
+	addRule(append(append([]networkingv1.NetworkPolicyPeer{}, options.ServePeers...), operatorPeers...), portsOf(DefaultServingPortName, DefaultServingGRPCPortName))
+	addRule(options.MetricsPeers, portsOf(DefaultMetricsName))
+	if IsIdlePolicyEnabled(cluster) {
+		// The operator counts the user requests served by the dashboard from its own metrics to check the idle policy.
+		addRule(operatorNamespacePeers, []int32{utils.DefaultDashboardMetricsPort})
+	}
+
+	return newNetworkPolicy(cluster, clusterSelector, ingress)
+}
+
+// buildIntraClusterIngressRule builds the ingress rule that lets the Pods of the RayCluster reach each other on every port.
+func buildIntraClusterIngressRule(cluster rayv1alpha1.RayCluster, clusterSelector metav1.LabelSelector) networkingv1.NetworkPolicyIngressRule {
+	rule := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{PodSelector: clusterSelector.DeepCopy()}},
+	}
+	if IsDeploymentAutoscalerEnabled(cluster) {
+		// The standalone autoscaler connects to the GCS and reads the Ray cluster status like the Pods of the RayCluster.
+		rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{RayAutoscalerLabelKey: cluster.Name}},
+		})
+	}
+	return rule
+}
+
+// buildDashboardAuthIngress builds the ingress rules that let anyone reach every other port than the dashboard and
+// dashboard agent ports. The dashboard agent listens on all interfaces, so that it would otherwise be reachable without
+// going through the dashboard auth proxy.
+func buildDashboardAuthIngress(cluster rayv1alpha1.RayCluster, intraClusterRule networkingv1.NetworkPolicyIngressRule) []networkingv1.NetworkPolicyIngressRule {
+	rayContainer := &cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex]
+	closedPorts := []int32{
+		int32(utils.FindContainerPort(rayContainer, DefaultDashboardName, DefaultDashboardPort)),
+		int32(utils.FindContainerPort(rayContainer, DefaultDashboardAgentListenPortName, DefaultDashboardAgentListenPort)),
+	}
+	return []networkingv1.NetworkPolicyIngressRule{
+		intraClusterRule,
+		{Ports: buildNetworkPolicyPortRangesExcept(closedPorts)},
+	}
+}
+
+func newNetworkPolicy(cluster rayv1alpha1.RayCluster, podSelector metav1.LabelSelector, ingress []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateNetworkPolicyName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                cluster.Name,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: podSelector,
+			Ingress:     ingress,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func buildNetworkPolicyPorts(ports []int32) []networkingv1.NetworkPolicyPort {
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	protocol := corev1.ProtocolTCP
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		p := intstr.FromInt(int(port))
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p})
+	}
+	return policyPorts
+}
+
+// buildNetworkPolicyPortRangesExcept returns the TCP port ranges that cover every port but the given ones.
+func buildNetworkPolicyPortRangesExcept(ports []int32) []networkingv1.NetworkPolicyPort {
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	protocol := corev1.ProtocolTCP
+	var policyPorts []networkingv1.NetworkPolicyPort
+	start := int32(1)
+	for _, port := range append(ports, maxPort+1) {
+		if port > start {
+			p := intstr.FromInt(int(start))
+			policyPort := networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p}
+			if end := port - 1; end > start {
+				policyPort.EndPort = &end
+			}
+			policyPorts = append(policyPorts, policyPort)
+		}
+		if port >= start {
+			start = port + 1
+		}
+	}
+	return policyPorts
+}
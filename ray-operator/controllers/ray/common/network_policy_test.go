@@ -0,0 +1,119 @@
+package common
+
+import (
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func getNetworkPolicyRulePorts(rule networkingv1.NetworkPolicyIngressRule) []int {
+	ports := []int{}
+	for _, port := range rule.Ports {
+		ports = append(ports, port.Port.IntValue())
+	}
+	return ports
+}
+
+func TestBuildNetworkPolicy(t *testing.T) {
+	cluster := instanceWithIngressEnabled.DeepCopy()
+	monitoringPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{KubernetesNamespaceNameLabelKey: "monitoring"}},
+	}
+	cluster.Spec.NetworkIsolation = &rayv1alpha1.NetworkIsolationOptions{
+		MetricsPeers: []networkingv1.NetworkPolicyPeer{monitoringPeer},
+	}
+
+	networkPolicy := BuildNetworkPolicy(*cluster, "ray-system")
+	assert.Equal(t, "raycluster-sample-network-policy", networkPolicy.Name)
+	assert.Equal(t, cluster.Name, networkPolicy.Spec.PodSelector.MatchLabels[RayClusterLabelKey])
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+
+	// Rules: intra-cluster traffic, operator to the dashboard, operator to serve, and monitoring to metrics.
+	// The client port has no peers, so it is only reachable from the RayCluster.
+	rules := networkPolicy.Spec.Ingress
+	assert.Equal(t, 4, len(rules))
+	assert.Empty(t, rules[0].Ports)
+	assert.Equal(t, cluster.Name, rules[0].From[0].PodSelector.MatchLabels[RayClusterLabelKey])
+
+	operatorPeer := rules[1].From[0]
+	assert.Equal(t, "ray-system", operatorPeer.NamespaceSelector.MatchLabels[KubernetesNamespaceNameLabelKey])
+	assert.Equal(t, []int{DefaultDashboardPort, DefaultDashboardAgentListenPort}, getNetworkPolicyRulePorts(rules[1]))
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{operatorPeer}, rules[2].From)
+	assert.Equal(t, []int{DefaultServingPort}, getNetworkPolicyRulePorts(rules[2]))
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{monitoringPeer}, rules[3].From)
+	assert.Equal(t, []int{DefaultMetricsPort}, getNetworkPolicyRulePorts(rules[3]))
+
+	// With dashboard auth, only the proxy ports are open. The submitter Pods of the owning RayJob reach the dashboard.
+	clientPeer := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "notebook"}}}
+	cluster.Spec.NetworkIsolation.ClientPeers = []networkingv1.NetworkPolicyPeer{clientPeer}
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeToken}
+	cluster.OwnerReferences = []metav1.OwnerReference{{Kind: "RayJob", Name: "rayjob-sample"}}
+	networkPolicy = BuildNetworkPolicy(*cluster, "")
+	rules = networkPolicy.Spec.Ingress
+	assert.Equal(t, 5, len(rules))
+	assert.Equal(t, 1, len(rules[1].From))
+	assert.Equal(t, "rayjob-sample", rules[1].From[0].PodSelector.MatchLabels[KubernetesJobNameLabelKey])
+	assert.Equal(t, []int{DashboardAuthProxyPort, DashboardAgentAuthProxyPort}, getNetworkPolicyRulePorts(rules[1]))
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{clientPeer}, rules[2].From)
+	assert.Equal(t, []int{DefaultClientPort}, getNetworkPolicyRulePorts(rules[2]))
+
+	// With an idle policy, the operator scrapes the metrics of the dashboard.
+	cluster.Spec.IdlePolicy = &rayv1alpha1.IdlePolicy{}
+	networkPolicy = BuildNetworkPolicy(*cluster, "ray-system")
+	rules = networkPolicy.Spec.Ingress
+	assert.Equal(t, 6, len(rules))
+	assert.Equal(t, []networkingv1.NetworkPolicyPeer{operatorPeer}, rules[5].From)
+	assert.Equal(t, []int{utils.DefaultDashboardMetricsPort}, getNetworkPolicyRulePorts(rules[5]))
+}
+
+func TestBuildNetworkPolicyWithDeploymentAutoscaler(t *testing.T) {
+	cluster := instance.DeepCopy()
+	deploymentAutoscaler := rayv1alpha1.DeploymentAutoscaler
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(true)
+	cluster.Spec.AutoscalerOptions = &rayv1alpha1.AutoscalerOptions{Type: &deploymentAutoscaler}
+	cluster.Spec.NetworkIsolation = &rayv1alpha1.NetworkIsolationOptions{}
+
+	networkPolicy := BuildNetworkPolicy(*cluster, "")
+	intraClusterPeers := networkPolicy.Spec.Ingress[0].From
+	assert.Equal(t, 2, len(intraClusterPeers))
+	assert.Equal(t, map[string]string{RayAutoscalerLabelKey: cluster.Name}, intraClusterPeers[1].PodSelector.MatchLabels)
+}
+
+func TestBuildNetworkPolicyWithDashboardAuth(t *testing.T) {
+	cluster := instance.DeepCopy()
+	assert.False(t, IsNetworkPolicyEnabled(*cluster))
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeToken}
+	assert.True(t, IsNetworkPolicyEnabled(*cluster))
+
+	// Without network isolation, every port but the dashboard and dashboard agent ports stays open, and those are only
+	// reachable from the Pods of the RayCluster.
+	networkPolicy := BuildNetworkPolicy(*cluster, "ray-system")
+	rules := networkPolicy.Spec.Ingress
+	assert.Equal(t, 2, len(rules))
+	assert.Empty(t, rules[0].Ports)
+	assert.Equal(t, cluster.Name, rules[0].From[0].PodSelector.MatchLabels[RayClusterLabelKey])
+	assert.Empty(t, rules[1].From)
+	portRanges := [][2]int32{}
+	for _, port := range rules[1].Ports {
+		end := int32(port.Port.IntValue())
+		if port.EndPort != nil {
+			end = *port.EndPort
+		}
+		portRanges = append(portRanges, [2]int32{int32(port.Port.IntValue()), end})
+	}
+	assert.Equal(t, [][2]int32{{1, DefaultDashboardPort - 1}, {DefaultDashboardPort + 1, DefaultDashboardAgentListenPort - 1}, {DefaultDashboardAgentListenPort + 1, 65535}}, portRanges)
+}
+
+func TestBuildNetworkPolicyPortRangesExcept(t *testing.T) {
+	ports := buildNetworkPolicyPortRangesExcept([]int32{2, 1, 4, 65535})
+	assert.Equal(t, 2, len(ports))
+	assert.Equal(t, 3, ports[0].Port.IntValue())
+	assert.Nil(t, ports[0].EndPort)
+	assert.Equal(t, 5, ports[1].Port.IntValue())
+	assert.Equal(t, int32(65534), *ports[1].EndPort)
+}
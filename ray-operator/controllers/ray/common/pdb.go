@@ -0,0 +1,129 @@
+package common
+
+import (
+	"fmt"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// HeadGroupName is the value of the ray.io/group label of head Pods.
+const HeadGroupName = "headgroup"
+
+// DefaultWorkerMaxUnavailable is the default number of Pods of a worker group that can be evicted at the same time.
+var DefaultWorkerMaxUnavailable = intstr.FromInt(1)
+
+// IsPodDisruptionBudgetEnabled returns whether KubeRay manages PodDisruptionBudgets for the RayCluster.
+func IsPodDisruptionBudgetEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.PodDisruptionBudget != nil
+}
+
+// ValidatePodDisruptionBudgets makes sure the PodDisruptionBudgets of the head and worker groups have different names. A
+// worker group named like the head group would share its PodDisruptionBudget, and the names are truncated to the maximum
+// length of Kubernetes names, so long group names can collide.
+func ValidatePodDisruptionBudgets(instance rayv1alpha1.RayCluster) error {
+	if !IsPodDisruptionBudgetEnabled(instance) {
+		return nil
+	}
+	groupNames := map[string]string{
+		utils.GeneratePodDisruptionBudgetName(instance.Name, HeadGroupName): HeadGroupName,
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.GroupName == HeadGroupName {
+			return fmt.Errorf("the worker group name %s is reserved for the head group, rename the worker group", HeadGroupName)
+		}
+		name := utils.GeneratePodDisruptionBudgetName(instance.Name, worker.GroupName)
+		if groupName, ok := groupNames[name]; ok {
+			return fmt.Errorf("the PodDisruptionBudgets of groups %s and %s would both be named %s, shorten the group names",
+				groupName, worker.GroupName, name)
+		}
+		groupNames[name] = worker.GroupName
+	}
+	return nil
+}
+
+// GetWorkerGroupTargetReplicas returns the number of Pods the RayCluster controller maintains for the worker group.
+func GetWorkerGroupTargetReplicas(worker rayv1alpha1.WorkerGroupSpec) int32 {
+	if worker.Replicas == nil {
+		return 0
+	}
+	if worker.MaxReplicas != nil && *worker.MaxReplicas < *worker.Replicas {
+		return *worker.MaxReplicas
+	}
+	return *worker.Replicas
+}
+
+// BuildHeadPodDisruptionBudget builds the PodDisruptionBudget that prevents the eviction of the head Pod.
+func BuildHeadPodDisruptionBudget(cluster rayv1alpha1.RayCluster) *policyv1.PodDisruptionBudget {
+	selector := map[string]string{
+		RayClusterLabelKey:  cluster.Name,
+		RayNodeTypeLabelKey: string(rayv1alpha1.HeadNode),
+	}
+	return buildPodDisruptionBudget(cluster, HeadGroupName, selector, intstr.FromInt(1))
+}
+
+// IsServeUnreadyPod returns true if the Pod has the Serve readiness gate, is running, and its containers are ready, but
+// its Serve readiness condition is not true. Kubernetes counts such a Pod as disrupted in the PodDisruptionBudgets.
+func IsServeUnreadyPod(pod *corev1.Pod) bool {
+	if !utils.HasReadinessGate(pod.Spec, RayServeReadyConditionType) || !utils.IsRunningAndContainersReady(pod) {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == RayServeReadyConditionType {
+			return cond.Status != corev1.ConditionTrue
+		}
+	}
+	return true
+}
+
+// BuildWorkerPodDisruptionBudget builds the PodDisruptionBudget of a worker group. Kubernetes can only honor minAvailable
+// as a number for Pods that are not managed by a workload controller with a scale subresource, so minAvailable is derived
+// from the replicas and maxUnavailable of the group, and needs to be updated whenever the replicas change. The
+// serveUnreadyPods of the group, which Kubernetes counts as disrupted, are subtracted from minAvailable, so that a Serve
+// application that is down does not block node drains.
+func BuildWorkerPodDisruptionBudget(cluster rayv1alpha1.RayCluster, worker rayv1alpha1.WorkerGroupSpec, serveUnreadyPods int) (*policyv1.PodDisruptionBudget, error) {
+	replicas := int(GetWorkerGroupTargetReplicas(worker))
+	maxUnavailable := DefaultWorkerMaxUnavailable
+	if worker.MaxUnavailable != nil {
+		maxUnavailable = *worker.MaxUnavailable
+	} else if cluster.Spec.PodDisruptionBudget.WorkerMaxUnavailable != nil {
+		maxUnavailable = *cluster.Spec.PodDisruptionBudget.WorkerMaxUnavailable
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, replicas, true)
+	if err != nil {
+		return nil, err
+	}
+	minAvailable := replicas - unavailable - serveUnreadyPods
+	if minAvailable < 0 {
+		minAvailable = 0
+	}
+	selector := map[string]string{
+		RayClusterLabelKey:   cluster.Name,
+		RayNodeGroupLabelKey: worker.GroupName,
+		RayNodeTypeLabelKey:  string(rayv1alpha1.WorkerNode),
+	}
+	return buildPodDisruptionBudget(cluster, worker.GroupName, selector, intstr.FromInt(minAvailable)), nil
+}
+
+func buildPodDisruptionBudget(cluster rayv1alpha1.RayCluster, groupName string, selector map[string]string, minAvailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GeneratePodDisruptionBudgetName(cluster.Name, groupName),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                cluster.Name,
+				RayNodeGroupLabelKey:              groupName,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: selector},
+		},
+	}
+}
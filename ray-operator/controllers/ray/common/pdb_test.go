@@ -0,0 +1,114 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+func TestBuildHeadPodDisruptionBudget(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.PodDisruptionBudget = &rayv1alpha1.PodDisruptionBudgetOptions{}
+
+	pdb := BuildHeadPodDisruptionBudget(*cluster)
+	assert.Equal(t, "raycluster-sample-headgroup-pdb", pdb.Name)
+	assert.Equal(t, 1, pdb.Spec.MinAvailable.IntValue())
+	assert.Equal(t, string(rayv1alpha1.HeadNode), pdb.Spec.Selector.MatchLabels[RayNodeTypeLabelKey])
+	assert.Equal(t, cluster.Name, pdb.Spec.Selector.MatchLabels[RayClusterLabelKey])
+}
+
+func TestIsServeUnreadyPod(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: RayServeReadyConditionType}}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	assert.True(t, IsServeUnreadyPod(pod))
+
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{Type: RayServeReadyConditionType, Status: corev1.ConditionTrue})
+	assert.False(t, IsServeUnreadyPod(pod))
+
+	// A Pod whose containers are not ready is disrupted regardless of Serve.
+	pod.Status.Conditions = []corev1.PodCondition{{Type: RayServeReadyConditionType, Status: corev1.ConditionFalse}}
+	assert.False(t, IsServeUnreadyPod(pod))
+
+	// So is a Pod without the Serve readiness gate.
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}}
+	pod.Spec.ReadinessGates = nil
+	assert.False(t, IsServeUnreadyPod(pod))
+}
+
+func TestValidatePodDisruptionBudgets(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = HeadGroupName
+	assert.Nil(t, ValidatePodDisruptionBudgets(*cluster))
+
+	// A worker group named like the head group would share the PodDisruptionBudget of the head Pod.
+	cluster.Spec.PodDisruptionBudget = &rayv1alpha1.PodDisruptionBudgetOptions{}
+	assert.NotNil(t, ValidatePodDisruptionBudgets(*cluster))
+
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = "small-group"
+	assert.Nil(t, ValidatePodDisruptionBudgets(*cluster))
+
+	// The PodDisruptionBudget names keep the end of long group names, which collide.
+	suffix := strings.Repeat("x", 60)
+	otherWorker := cluster.Spec.WorkerGroupSpecs[0]
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = "a-" + suffix
+	otherWorker.GroupName = "b-" + suffix
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, otherWorker)
+	assert.NotNil(t, ValidatePodDisruptionBudgets(*cluster))
+}
+
+func TestBuildWorkerPodDisruptionBudget(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.PodDisruptionBudget = &rayv1alpha1.PodDisruptionBudgetOptions{}
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Replicas = pointer.Int32Ptr(10)
+	worker.MaxReplicas = pointer.Int32Ptr(10)
+
+	tests := map[string]struct {
+		clusterMaxUnavailable *intstr.IntOrString
+		groupMaxUnavailable   *intstr.IntOrString
+		replicas              int32
+		serveUnreadyPods      int
+		expectedMinAvailable  int
+	}{
+		"default":                   {nil, nil, 10, 0, 9},
+		"cluster default":           {intStrPtr(intstr.FromInt(3)), nil, 10, 0, 7},
+		"group overrides cluster":   {intStrPtr(intstr.FromInt(3)), intStrPtr(intstr.FromInt(2)), 10, 0, 8},
+		"percentage rounds up":      {intStrPtr(intstr.FromString("25%")), nil, 10, 0, 7},
+		"more than replicas":        {intStrPtr(intstr.FromInt(5)), nil, 2, 0, 0},
+		"replicas capped by max":    {nil, nil, 20, 0, 9},
+		"no replicas":               {nil, nil, 0, 0, 0},
+		"percentage of no replicas": {intStrPtr(intstr.FromString("50%")), nil, 0, 0, 0},
+		"serve unready pods":        {nil, nil, 10, 3, 6},
+		"all pods serve unready":    {nil, nil, 10, 10, 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster.Spec.PodDisruptionBudget.WorkerMaxUnavailable = tc.clusterMaxUnavailable
+			worker.MaxUnavailable = tc.groupMaxUnavailable
+			worker.Replicas = pointer.Int32Ptr(tc.replicas)
+			pdb, err := BuildWorkerPodDisruptionBudget(*cluster, worker, tc.serveUnreadyPods)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedMinAvailable, pdb.Spec.MinAvailable.IntValue())
+			assert.Equal(t, worker.GroupName, pdb.Spec.Selector.MatchLabels[RayNodeGroupLabelKey])
+			assert.Equal(t, string(rayv1alpha1.WorkerNode), pdb.Spec.Selector.MatchLabels[RayNodeTypeLabelKey])
+		})
+	}
+
+	worker.MaxUnavailable = intStrPtr(intstr.FromString("many"))
+	_, err := BuildWorkerPodDisruptionBudget(*cluster, worker, 0)
+	assert.NotNil(t, err)
+}
+
+func intStrPtr(value intstr.IntOrString) *intstr.IntOrString {
+	return &value
+}
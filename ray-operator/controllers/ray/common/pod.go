@@ -12,11 +12,14 @@ import (
 	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 const (
@@ -51,8 +54,12 @@ func GetHeadPort(headStartParams map[string]string) string {
 	return headPort
 }
 
-// Check if the RayCluster has GCS fault tolerance enabled.
+// Check if the RayCluster has GCS fault tolerance enabled, either by spec.gcsFaultToleranceOptions or by the deprecated
+// ray.io/ft-enabled annotation.
 func IsGCSFaultToleranceEnabled(instance rayv1alpha1.RayCluster) bool {
+	if instance.Spec.GcsFaultToleranceOptions != nil {
+		return true
+	}
 	v, ok := instance.Annotations[RayFTEnabledAnnotationKey]
 	return ok && strings.ToLower(v) == "true"
 }
@@ -66,18 +73,19 @@ func initTemplateAnnotations(instance rayv1alpha1.RayCluster, podTemplate *v1.Po
 	// This may need to be updated in the future.
 	if IsGCSFaultToleranceEnabled(instance) {
 		podTemplate.Annotations[RayFTEnabledAnnotationKey] = "true"
-		// if we have FT enabled, we need to set up a default external storage namespace.
-		podTemplate.Annotations[RayExternalStorageNSAnnotationKey] = string(instance.UID)
+		podTemplate.Annotations[RayExternalStorageNSAnnotationKey] = GetExternalStorageNamespace(instance)
 	} else {
 		podTemplate.Annotations[RayFTEnabledAnnotationKey] = "false"
-	}
-
-	// set ray external storage namespace if user specified one.
-	if instance.Annotations != nil {
+		// set ray external storage namespace if user specified one.
 		if v, ok := instance.Annotations[RayExternalStorageNSAnnotationKey]; ok {
 			podTemplate.Annotations[RayExternalStorageNSAnnotationKey] = v
 		}
 	}
+
+	// BuildPod mounts the node certificate KubeRay issues for the Pod if TLS is enabled.
+	if IsTLSEnabled(instance) {
+		podTemplate.Annotations[RayTLSCASecretAnnotationKey] = GetTLSCASecretName(instance)
+	}
 }
 
 // DefaultHeadPodTemplate sets the config values
@@ -95,16 +103,21 @@ func DefaultHeadPodTemplate(instance rayv1alpha1.RayCluster, headSpec rayv1alpha
 	if podTemplate.Labels == nil {
 		podTemplate.Labels = make(map[string]string)
 	}
-	podTemplate.Labels = labelPod(rayv1alpha1.HeadNode, instance.Name, "headgroup", instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
+	podTemplate.Labels = labelPod(rayv1alpha1.HeadNode, instance.Name, HeadGroupName, instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
 	headSpec.RayStartParams = setMissingRayStartParams(headSpec.RayStartParams, rayv1alpha1.HeadNode, headPort, "", instance.Annotations)
 
 	initTemplateAnnotations(instance, &podTemplate)
 
 	// if in-tree autoscaling is enabled, then autoscaler container should be injected into head pod.
-	if instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling {
+	// The operator autoscaling loop reads the status published by the monitor process, so it is kept in that case.
+	if instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling && !IsOperatorAutoscalingEnabled(instance) {
 		// The default autoscaler is not compatible with Kubernetes. As a result, we disable
 		// the monitor process by default and inject a KubeRay autoscaler side container into the head pod.
 		headSpec.RayStartParams["no-monitor"] = "true"
+	}
+	// The standalone autoscaler runs in its own Deployment.
+	if instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling && !IsOperatorAutoscalingEnabled(instance) &&
+		!IsDeploymentAutoscalerEnabled(instance) {
 		// set custom service account with proper roles bound.
 		// utils.CheckName clips the name to match the behavior of reconcileAutoscalerServiceAccount
 		podTemplate.Spec.ServiceAccountName = utils.CheckName(utils.GetHeadGroupServiceAccountName(&instance))
@@ -117,6 +130,20 @@ func DefaultHeadPodTemplate(instance rayv1alpha1.RayCluster, headSpec rayv1alpha
 		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, autoscalerContainer)
 	}
 
+	// The Ray container of the head Pod connects to the Redis configured in spec.gcsFaultToleranceOptions.
+	if instance.Spec.GcsFaultToleranceOptions != nil {
+		addGcsFaultToleranceEnvVars(instance, &podTemplate, headSpec.RayStartParams)
+	}
+
+	if IsManagedRedisPasswordEnabled(instance) {
+		addManagedRedisPassword(instance, &podTemplate, headSpec.RayStartParams)
+	}
+
+	// If dashboard auth is enabled, the dashboard and the dashboard agent are only exposed through an authenticating proxy container.
+	if IsDashboardAuthEnabled(instance) {
+		addDashboardAuthProxy(instance, &podTemplate)
+	}
+
 	// If the metrics port does not exist in the Ray container, add a default one for Promethues.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[RayContainerIndex], DefaultMetricsName, -1) != -1
 	if !isMetricsPortExists {
@@ -193,6 +220,9 @@ func DefaultWorkerPodTemplate(instance rayv1alpha1.RayCluster, workerSpec rayv1a
 	// If the replica of workers is more than 1, `ObjectMeta.Name` may cause name conflict errors.
 	// Hence, we set `ObjectMeta.Name` to an empty string, and use GenerateName to prevent name conflicts.
 	podTemplate.ObjectMeta.Name = ""
+	if IsWorkerHeadlessServiceEnabled(instance) {
+		setWorkerPodHostname(instance, &podTemplate)
+	}
 	if podTemplate.Labels == nil {
 		podTemplate.Labels = make(map[string]string)
 	}
@@ -201,6 +231,10 @@ func DefaultWorkerPodTemplate(instance rayv1alpha1.RayCluster, workerSpec rayv1a
 
 	initTemplateAnnotations(instance, &podTemplate)
 
+	if IsManagedRedisPasswordEnabled(instance) {
+		addManagedRedisPassword(instance, &podTemplate, workerSpec.RayStartParams)
+	}
+
 	// If the metrics port does not exist in the Ray container, add a default one for Promethues.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[RayContainerIndex], DefaultMetricsName, -1) != -1
 	if !isMetricsPortExists {
@@ -244,6 +278,56 @@ func initHealthProbe(probe *v1.Probe, rayNodeType rayv1alpha1.RayNodeType) {
 	}
 }
 
+// BuildHeadPod builds the head Pod of the RayCluster from the template of the head group, with the defaults and the
+// containers added by KubeRay, such as the autoscaler sidecar.
+func BuildHeadPod(instance rayv1alpha1.RayCluster) v1.Pod {
+	if instance.Spec.HeadGroupSpec.RayStartParams == nil {
+		instance.Spec.HeadGroupSpec.RayStartParams = map[string]string{}
+	}
+	podName := strings.ToLower(instance.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol)
+	podName = utils.CheckName(podName)                                       // making sure the name is valid
+	fqdnRayIP := utils.GenerateFQDNServiceName(instance, instance.Namespace) // Fully Qualified Domain Name
+	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
+	headPort := GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
+	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
+	if IsOperatorAutoscalingEnabled(instance) || IsDeploymentAutoscalerEnabled(instance) {
+		// There is no autoscaler container to share the Ray logs with.
+		autoscalingEnabled = pointer.Bool(false)
+	}
+	podConf := DefaultHeadPodTemplate(instance, instance.Spec.HeadGroupSpec, podName, headPort)
+	return BuildPod(podConf, rayv1alpha1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, headPort, autoscalingEnabled, getCreator(instance), fqdnRayIP)
+}
+
+// BuildWorkerPod builds a Pod of the worker group from its template, with the defaults and the containers added by
+// KubeRay, such as the init container waiting for the GCS.
+func BuildWorkerPod(instance rayv1alpha1.RayCluster, worker rayv1alpha1.WorkerGroupSpec) v1.Pod {
+	if worker.RayStartParams == nil {
+		worker.RayStartParams = map[string]string{}
+	}
+	podName := strings.ToLower(instance.Name + DashSymbol + string(rayv1alpha1.WorkerNode) + DashSymbol + worker.GroupName + DashSymbol)
+	podName = utils.CheckName(podName)                                       // making sure the name is valid
+	fqdnRayIP := utils.GenerateFQDNServiceName(instance, instance.Namespace) // Fully Qualified Domain Name
+
+	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
+	headPort := GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
+	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
+	podTemplateSpec := DefaultWorkerPodTemplate(instance, worker, podName, fqdnRayIP, headPort)
+	return BuildPod(podTemplateSpec, rayv1alpha1.WorkerNode, worker.RayStartParams, headPort, autoscalingEnabled, getCreator(instance), fqdnRayIP)
+}
+
+func getCreator(instance rayv1alpha1.RayCluster) string {
+	if instance.Labels == nil {
+		return ""
+	}
+	creatorName, exist := instance.Labels[KubernetesCreatedByLabelKey]
+
+	if !exist {
+		return ""
+	}
+
+	return creatorName
+}
+
 // BuildPod a pod config
 func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayv1alpha1.RayNodeType, rayStartParams map[string]string, headPort string, enableRayAutoscaler *bool, creator string, fqdnRayIP string) (aPod v1.Pod) {
 	pod := v1.Pod{
@@ -266,6 +350,12 @@ func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayv1alpha1.RayNod
 		addEmptyDir(&pod.Spec.Containers[RayContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, v1.StorageMediumDefault)
 		addEmptyDir(&pod.Spec.Containers[autoscalerContainerIndex], &pod, RayLogVolumeName, RayLogVolumeMountPath, v1.StorageMediumDefault)
 	}
+	if _, ok := pod.Annotations[RayTLSCASecretAnnotationKey]; ok {
+		addTLSVolumes(&pod)
+	}
+	if rayNodeType == rayv1alpha1.HeadNode && hasDashboardAuthProxy(pod) {
+		rayStartParams = bindDashboardToLocalhost(rayStartParams)
+	}
 	cleanupInvalidVolumeMounts(&pod.Spec.Containers[RayContainerIndex], &pod)
 	if len(pod.Spec.InitContainers) > RayContainerIndex {
 		cleanupInvalidVolumeMounts(&pod.Spec.InitContainers[RayContainerIndex], &pod)
@@ -598,6 +688,10 @@ func setContainerEnvVars(pod *v1.Pod, rayNodeType rayv1alpha1.RayNodeType, raySt
 		// This flag enables the display of disk usage. Without this flag, the dashboard will not show disk usage.
 		container.Env = append(container.Env, v1.EnvVar{Name: RAY_DASHBOARD_ENABLE_K8S_DISK_USAGE, Value: "1"})
 	}
+	if _, ok := pod.Annotations[RayTLSCASecretAnnotationKey]; ok {
+		// The node certificate is mounted by addTLSVolumes.
+		setTLSEnvVars(container)
+	}
 }
 
 func envVarExists(envName string, envVars []v1.EnvVar) bool {
@@ -857,3 +951,18 @@ func ValidateHeadRayStartParams(rayHeadGroupSpec rayv1alpha1.HeadGroupSpec) (isV
 	// default return
 	return true, nil
 }
+
+// setWorkerPodHostname makes the worker Pod resolvable through the headless service of the worker Pods. Kubernetes only
+// publishes a DNS record for Pods whose hostname is set, so the Pod name is generated here, the same way the API server
+// does for GenerateName, and used as the hostname. If the name is already taken, createWorkerPod builds the Pod again
+// with another name.
+func setWorkerPodHostname(instance rayv1alpha1.RayCluster, podTemplate *v1.PodTemplateSpec) {
+	name := podTemplate.GenerateName + utilrand.String(5)
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		log.Info("The worker Pod name is not a valid hostname. The Pod will not have a DNS name.", "name", name, "errors", errs)
+		return
+	}
+	podTemplate.Name = name
+	podTemplate.Spec.Hostname = name
+	podTemplate.Spec.Subdomain = utils.GenerateWorkerHeadlessServiceName(instance.Name)
+}
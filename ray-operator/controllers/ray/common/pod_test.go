@@ -321,6 +321,111 @@ func checkContainerEnv(t *testing.T, container v1.Container, envName string, exp
 	}
 }
 
+func TestBuildPodWithTLS(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{}
+	caSecretName := GetTLSCASecretName(*cluster)
+	assert.Equal(t, "raycluster-sample-tls-ca", caSecretName)
+
+	// Test worker pod. The TLS init container must run before the injected `wait-gcs-ready` init container.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := cluster.Name + DashSymbol + string(rayv1alpha1.WorkerNode) + DashSymbol + worker.GroupName + DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(*cluster, cluster.Namespace)
+	podTemplateSpec := DefaultWorkerPodTemplate(*cluster, worker, podName, fqdnRayIP, "6379")
+	assert.Equal(t, caSecretName, podTemplateSpec.Annotations[RayTLSCASecretAnnotationKey])
+	pod := BuildPod(podTemplateSpec, rayv1alpha1.WorkerNode, worker.RayStartParams, "6379", nil, "", fqdnRayIP)
+
+	nodeSecretName := pod.Annotations[RayTLSNodeSecretAnnotationKey]
+	assert.True(t, strings.HasPrefix(nodeSecretName, "raycluster-sample-tls-"))
+	assert.Empty(t, podTemplateSpec.Annotations[RayTLSNodeSecretAnnotationKey], "The annotations of the template must not be modified")
+	assert.Equal(t, RayTLSInitContainerName, pod.Spec.InitContainers[0].Name)
+	assert.Equal(t, pod.Spec.Containers[RayContainerIndex].Image, pod.Spec.InitContainers[0].Image)
+
+	for _, container := range []v1.Container{pod.Spec.Containers[RayContainerIndex], pod.Spec.InitContainers[0], pod.Spec.InitContainers[1]} {
+		assert.True(t, checkIfVolumeMounted(&container, &pod, RayTLSVolumeMountPath))
+	}
+	for _, container := range []v1.Container{pod.Spec.Containers[RayContainerIndex], pod.Spec.InitContainers[1]} {
+		checkContainerEnv(t, container, RAY_USE_TLS, "1")
+		checkContainerEnv(t, container, RAY_TLS_SERVER_CERT, "/etc/ray/tls/tls.crt")
+		checkContainerEnv(t, container, RAY_TLS_SERVER_KEY, "/etc/ray/tls/tls.key")
+		checkContainerEnv(t, container, RAY_TLS_CA_CERT, "/etc/ray/tls/ca.crt")
+	}
+
+	// Only the node certificate Secret of the Pod is mounted, never the CA Secret.
+	secretVolumes := 0
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil {
+			secretVolumes++
+			assert.Equal(t, nodeSecretName, volume.Secret.SecretName)
+			assert.True(t, *volume.Secret.Optional)
+		}
+	}
+	assert.Equal(t, 1, secretVolumes)
+
+	// Test head pod with the autoscaler. Each Pod gets its own node certificate Secret.
+	cluster.Spec.EnableInTreeAutoscaling = &trueFlag
+	podName = strings.ToLower(cluster.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec = DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod = BuildPod(podTemplateSpec, rayv1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", &trueFlag, "", fqdnRayIP)
+
+	assert.NotEqual(t, nodeSecretName, pod.Annotations[RayTLSNodeSecretAnnotationKey])
+	assert.Equal(t, RayTLSInitContainerName, pod.Spec.InitContainers[0].Name)
+	autoscalerContainer := pod.Spec.Containers[getAutoscalerContainerIndex(pod)]
+	checkContainerEnv(t, autoscalerContainer, RAY_USE_TLS, "1")
+	assert.True(t, checkIfVolumeMounted(&autoscalerContainer, &pod, RayTLSVolumeMountPath))
+
+	// User-specified TLS env vars are kept.
+	cluster = instance.DeepCopy()
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex].Env = append(
+		cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex].Env, v1.EnvVar{Name: RAY_TLS_CA_CERT, Value: "/custom/ca.crt"})
+	podTemplateSpec = DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod = BuildPod(podTemplateSpec, rayv1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, "", fqdnRayIP)
+	checkContainerEnv(t, pod.Spec.Containers[RayContainerIndex], RAY_TLS_CA_CERT, "/custom/ca.crt")
+}
+
+func TestHeadPodTemplate_WithDashboardAuth(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{
+		Mode: rayv1alpha1.DashboardAuthModeOIDC,
+		OIDC: &rayv1alpha1.DashboardOIDCOptions{IssuerURL: "https://issuer.example.com", Audience: "ray"},
+	}
+	cluster.Spec.HeadGroupSpec.RayStartParams["dashboard-host"] = "0.0.0.0"
+	podName := strings.ToLower(cluster.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	// The dashboard is only reachable through the proxy, and the rayStartParams of the RayCluster are left unchanged.
+	pod := BuildPod(podTemplateSpec, rayv1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, "", "")
+	assert.Contains(t, pod.Spec.Containers[RayContainerIndex].Args[0], "--dashboard-host=127.0.0.1")
+	assert.Equal(t, "0.0.0.0", cluster.Spec.HeadGroupSpec.RayStartParams["dashboard-host"])
+	assert.Equal(t, 2, len(podTemplateSpec.Spec.Containers))
+	proxy := podTemplateSpec.Spec.Containers[1]
+	assert.Equal(t, DashboardAuthProxyContainerName, proxy.Name)
+	assert.Equal(t, DefaultDashboardAuthProxyImage, proxy.Image)
+	assert.Equal(t, []string{
+		"--token-file=/etc/ray/dashboard-auth/token",
+		"--route=:8266=http://127.0.0.1:8265",
+		"--route=:8267=http://127.0.0.1:52365",
+		"--oidc-issuer-url=https://issuer.example.com",
+		"--oidc-audience=ray",
+	}, proxy.Args)
+
+	secretName := ""
+	for _, volume := range podTemplateSpec.Spec.Volumes {
+		if volume.Name == DashboardAuthVolumeName {
+			secretName = volume.Secret.SecretName
+		}
+	}
+	assert.Equal(t, "raycluster-sample-dashboard-auth", secretName)
+
+	// The image can be overridden.
+	image := "custom/proxy:v1"
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeToken, Image: &image}
+	podTemplateSpec = DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.Equal(t, image, podTemplateSpec.Spec.Containers[1].Image)
+	assert.Equal(t, 3, len(podTemplateSpec.Spec.Containers[1].Args))
+}
+
 func TestBuildPod(t *testing.T) {
 	cluster := instance.DeepCopy()
 
@@ -813,6 +918,29 @@ func TestDefaultWorkerPodTemplateWithName(t *testing.T) {
 	assert.Equal(t, worker, expectedWorker)
 }
 
+func TestDefaultWorkerPodTemplateWithHeadlessService(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableWorkerHeadlessService = pointer.BoolPtr(true)
+	fqdnRayIP := utils.GenerateFQDNServiceName(*cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := utils.CheckName(cluster.Name + DashSymbol + string(rayv1alpha1.WorkerNode) + DashSymbol + worker.GroupName + DashSymbol)
+
+	// The Pod name is generated so that it can be used as the hostname of the Pod.
+	podTemplateSpec := DefaultWorkerPodTemplate(*cluster, worker, podName, fqdnRayIP, "6379")
+	assert.True(t, strings.HasPrefix(podTemplateSpec.Name, podName))
+	assert.Len(t, podTemplateSpec.Name, len(podName)+5)
+	assert.Equal(t, podTemplateSpec.Name, podTemplateSpec.Spec.Hostname)
+	assert.Equal(t, "raycluster-sample-workers", podTemplateSpec.Spec.Subdomain)
+	assert.NotEqual(t, podTemplateSpec.Name, DefaultWorkerPodTemplate(*cluster, worker, podName, fqdnRayIP, "6379").Name)
+
+	// The hostname is not set when the headless service is disabled.
+	cluster.Spec.EnableWorkerHeadlessService = nil
+	podTemplateSpec = DefaultWorkerPodTemplate(*cluster, worker, podName, fqdnRayIP, "6379")
+	assert.Empty(t, podTemplateSpec.Name)
+	assert.Empty(t, podTemplateSpec.Spec.Hostname)
+	assert.Empty(t, podTemplateSpec.Spec.Subdomain)
+}
+
 func containerPortExists(ports []v1.ContainerPort, name string, containerPort int32) error {
 	for _, port := range ports {
 		if port.Name == name {
@@ -0,0 +1,225 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/utils/pointer"
+)
+
+// DefaultPreemptionPendingThreshold is how long Pods of a RayCluster must stay unschedulable before KubeRay preempts
+// RayClusters with a lower priority, unless --preemption-pending-threshold is set.
+const DefaultPreemptionPendingThreshold = 5 * time.Minute
+
+// PreemptionCheckPeriod bounds the requeue period of the RayClusters with spec.preemption, so that unschedulable Pods
+// are noticed soon after they reach the pending threshold.
+const PreemptionCheckPeriod = 30 * time.Second
+
+// PreemptionCandidate is a RayCluster that may be preempted, with the resources its preemption would release.
+type PreemptionCandidate struct {
+	Cluster rayv1alpha1.RayCluster
+	// RayJob is the name of the RayJob the RayCluster was created for. The RayJob is suspended instead of the workers
+	// of the RayCluster being scaled down.
+	RayJob    string
+	Resources corev1.ResourceList
+}
+
+// GetPreemptionPriority returns the priority of the RayCluster, 0 if spec.preemption is not set.
+func GetPreemptionPriority(instance rayv1alpha1.RayCluster) int32 {
+	if instance.Spec.Preemption == nil {
+		return 0
+	}
+	return instance.Spec.Preemption.Priority
+}
+
+// IsPreemptible returns true if KubeRay may preempt the RayCluster in favor of RayClusters with a higher priority.
+func IsPreemptible(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.Preemption != nil && instance.Spec.Preemption.Preemptible
+}
+
+// ParsePreemptionQuotaGroups parses the --preemption-quota-groups flag of the operator, such as
+// "team-a=ns-1,ns-2;team-b=ns-3", into the quota group of each namespace. A namespace belongs to at most one quota group.
+func ParsePreemptionQuotaGroups(value string) (map[string]string, error) {
+	quotaGroups := map[string]string{}
+	for _, group := range strings.Split(value, ";") {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+		name, namespaces, ok := strings.Cut(group, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid quota group %q, expected <group>=<namespace>[,<namespace>...]", group)
+		}
+		for _, namespace := range strings.Split(namespaces, ",") {
+			namespace = strings.TrimSpace(namespace)
+			if namespace == "" {
+				continue
+			}
+			if other, exists := quotaGroups[namespace]; exists && other != name {
+				return nil, fmt.Errorf("the namespace %s belongs to both quota groups %s and %s", namespace, other, name)
+			}
+			quotaGroups[namespace] = name
+		}
+	}
+	return quotaGroups, nil
+}
+
+// InSamePreemptionScope returns true if the RayClusters are in the same namespace, or in namespaces that the operator
+// configuration puts in the same quota group. The priorities are set by the users, so a RayCluster never preempts the
+// RayClusters of another namespace unless an administrator grouped the namespaces.
+func InSamePreemptionScope(quotaGroups map[string]string, a rayv1alpha1.RayCluster, b rayv1alpha1.RayCluster) bool {
+	if a.Namespace == b.Namespace {
+		return true
+	}
+	quotaGroup, ok := quotaGroups[a.Namespace]
+	return ok && quotaGroups[b.Namespace] == quotaGroup
+}
+
+// IsRayClusterPreempted returns true if KubeRay scaled the worker groups of the RayCluster to zero in favor of a
+// RayCluster with a higher priority and has not restored them yet.
+func IsRayClusterPreempted(instance rayv1alpha1.RayCluster) bool {
+	_, ok := instance.Annotations[RayClusterPreemptedReplicasAnnotationKey]
+	return ok
+}
+
+// IsWorkerScalingSuspended returns true if the worker groups of the RayCluster must stay at zero replicas because it is
+// hibernated or preempted. The autoscalers must not scale them up.
+func IsWorkerScalingSuspended(instance rayv1alpha1.RayCluster) bool {
+	return IsRayClusterHibernated(instance) || IsRayClusterPreempted(instance)
+}
+
+// HasWorkerReplicas returns true if a worker group of the RayCluster has replicas.
+func HasWorkerReplicas(instance rayv1alpha1.RayCluster) bool {
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if pointer.Int32Deref(worker.Replicas, 0) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PreemptRayCluster scales all the worker groups of the RayCluster to zero, and records their replicas and the
+// preemptor in the ray.io/preempted-replicas and ray.io/preempted-by annotations.
+func PreemptRayCluster(instance *rayv1alpha1.RayCluster, workerPods []corev1.Pod, preemptedBy string) error {
+	replicas := ScaleWorkerGroupsToZero(instance, workerPods)
+	value, err := json.Marshal(replicas)
+	if err != nil {
+		return err
+	}
+	if instance.Annotations == nil {
+		instance.Annotations = map[string]string{}
+	}
+	instance.Annotations[RayClusterPreemptedReplicasAnnotationKey] = string(value)
+	instance.Annotations[RayClusterPreemptedByAnnotationKey] = preemptedBy
+	return nil
+}
+
+// GetPreemptedReplicas returns the replicas of the worker groups recorded when the RayCluster was preempted.
+func GetPreemptedReplicas(instance rayv1alpha1.RayCluster) (map[string]int32, error) {
+	replicas := map[string]int32{}
+	value, ok := instance.Annotations[RayClusterPreemptedReplicasAnnotationKey]
+	if !ok {
+		return replicas, nil
+	}
+	if err := json.Unmarshal([]byte(value), &replicas); err != nil {
+		return nil, err
+	}
+	return replicas, nil
+}
+
+// RestorePreemptedRayCluster gives the worker groups their replicas back and removes the ray.io/preempted-replicas and
+// ray.io/preempted-by annotations.
+func RestorePreemptedRayCluster(instance *rayv1alpha1.RayCluster, replicas map[string]int32) {
+	restoreWorkerGroupReplicas(instance, replicas)
+	delete(instance.Annotations, RayClusterPreemptedReplicasAnnotationKey)
+	delete(instance.Annotations, RayClusterPreemptedByAnnotationKey)
+}
+
+// GetOwnerRayJobName returns the name of the RayJob that created the RayCluster, if any.
+func GetOwnerRayJobName(instance rayv1alpha1.RayCluster) (string, bool) {
+	owner := metav1.GetControllerOf(&instance)
+	if owner == nil || owner.Kind != string(utils.RayJobCRD) {
+		return "", false
+	}
+	return owner.Name, true
+}
+
+// GetUnschedulablePods returns the earliest time one of the Pods was found unschedulable by the Kubernetes scheduler,
+// and the total resources requested by the unschedulable Pods. The time is zero if no Pod is unschedulable.
+func GetUnschedulablePods(pods []corev1.Pod) (time.Time, corev1.ResourceList) {
+	var since time.Time
+	requests := corev1.ResourceList{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Spec.NodeName != "" || pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionFalse || condition.Reason != corev1.PodReasonUnschedulable {
+				continue
+			}
+			if since.IsZero() || condition.LastTransitionTime.Time.Before(since) {
+				since = condition.LastTransitionTime.Time
+			}
+			requests = quotav1.Add(requests, utils.CalculatePodResource(pod.Spec))
+		}
+	}
+	return since, requests
+}
+
+// GetScheduledPodsResources returns the total resources requested by the Pods that are bound to a node and not being
+// deleted, which are the resources released by deleting them.
+func GetScheduledPodsResources(pods []corev1.Pod) corev1.ResourceList {
+	resources := corev1.ResourceList{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		resources = quotav1.Add(resources, utils.CalculatePodResource(pod.Spec))
+	}
+	return resources
+}
+
+// SelectPreemptionVictims returns the candidates to preempt so that the resources they release cover requests. Only the
+// preemptible candidates of the same scope with a lower priority than the preemptor are considered, the lowest priority
+// first and, for the same priority, the most recently created first. No candidate is returned if all of them together
+// cannot cover requests, as the preemption would not make the Pods of the preemptor schedulable.
+func SelectPreemptionVictims(quotaGroups map[string]string, preemptor rayv1alpha1.RayCluster, candidates []PreemptionCandidate, requests corev1.ResourceList) []PreemptionCandidate {
+	priority := GetPreemptionPriority(preemptor)
+	var eligible []PreemptionCandidate
+	for _, candidate := range candidates {
+		if candidate.Cluster.UID == preemptor.UID || !IsPreemptible(candidate.Cluster) ||
+			GetPreemptionPriority(candidate.Cluster) >= priority || !InSamePreemptionScope(quotaGroups, preemptor, candidate.Cluster) ||
+			quotav1.IsZero(candidate.Resources) {
+			continue
+		}
+		eligible = append(eligible, candidate)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		a, b := eligible[i].Cluster, eligible[j].Cluster
+		if GetPreemptionPriority(a) != GetPreemptionPriority(b) {
+			return GetPreemptionPriority(a) < GetPreemptionPriority(b)
+		}
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return b.CreationTimestamp.Before(&a.CreationTimestamp)
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	released := corev1.ResourceList{}
+	for i, candidate := range eligible {
+		released = quotav1.Add(released, candidate.Resources)
+		if quotav1.IsZero(quotav1.SubtractWithNonNegativeResult(requests, released)) {
+			return eligible[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func preemptionCluster(name string, namespace string, options *rayv1alpha1.PreemptionOptions, created time.Time) rayv1alpha1.RayCluster {
+	return rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			UID:               types.UID(namespace + "/" + name),
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Spec: rayv1alpha1.RayClusterSpec{Preemption: options},
+	}
+}
+
+func cpuResources(cpu string) v1.ResourceList {
+	return v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}
+}
+
+func TestPreemptionScope(t *testing.T) {
+	now := time.Now()
+	a := preemptionCluster("a", "ns-1", &rayv1alpha1.PreemptionOptions{Priority: 10}, now)
+	assert.Equal(t, int32(10), GetPreemptionPriority(a))
+	assert.False(t, IsPreemptible(a))
+	assert.Equal(t, int32(0), GetPreemptionPriority(preemptionCluster("b", "ns-1", nil, now)))
+
+	assert.True(t, InSamePreemptionScope(nil, a, preemptionCluster("b", "ns-1", nil, now)))
+	assert.False(t, InSamePreemptionScope(nil, a, preemptionCluster("b", "ns-2", nil, now)))
+
+	// Only the quota groups of the operator configuration span namespaces.
+	quotaGroups := map[string]string{"ns-1": "team", "ns-2": "team", "ns-3": "other-team"}
+	assert.True(t, InSamePreemptionScope(quotaGroups, a, preemptionCluster("b", "ns-2", nil, now)))
+	assert.False(t, InSamePreemptionScope(quotaGroups, a, preemptionCluster("b", "ns-3", nil, now)))
+	assert.False(t, InSamePreemptionScope(quotaGroups, a, preemptionCluster("b", "ns-4", nil, now)))
+	assert.False(t, InSamePreemptionScope(quotaGroups, preemptionCluster("b", "ns-4", nil, now), preemptionCluster("c", "ns-5", nil, now)))
+}
+
+func TestParsePreemptionQuotaGroups(t *testing.T) {
+	quotaGroups, err := ParsePreemptionQuotaGroups("")
+	assert.Nil(t, err)
+	assert.Empty(t, quotaGroups)
+
+	quotaGroups, err = ParsePreemptionQuotaGroups("team-a=ns-1, ns-2;team-b=ns-3;")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"ns-1": "team-a", "ns-2": "team-a", "ns-3": "team-b"}, quotaGroups)
+
+	_, err = ParsePreemptionQuotaGroups("ns-1,ns-2")
+	assert.NotNil(t, err)
+	_, err = ParsePreemptionQuotaGroups("team-a=ns-1;team-b=ns-1")
+	assert.NotNil(t, err)
+}
+
+func TestPreemptRayCluster(t *testing.T) {
+	cluster := preemptionCluster("a", "ns", nil, time.Now())
+	cluster.Spec.WorkerGroupSpecs = []rayv1alpha1.WorkerGroupSpec{
+		{GroupName: "small", Replicas: pointer.Int32(2), MinReplicas: pointer.Int32(0)},
+		{GroupName: "large", Replicas: pointer.Int32(1), MinReplicas: pointer.Int32(1)},
+	}
+	workerPod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "small-1", Labels: map[string]string{RayNodeGroupLabelKey: "small"}}}
+	assert.False(t, IsWorkerScalingSuspended(cluster))
+
+	err := PreemptRayCluster(&cluster, []v1.Pod{workerPod}, "ns/preemptor")
+	assert.Nil(t, err)
+	assert.True(t, IsRayClusterPreempted(cluster))
+	assert.True(t, IsWorkerScalingSuspended(cluster))
+	assert.False(t, HasWorkerReplicas(cluster))
+	assert.Equal(t, "ns/preemptor", cluster.Annotations[RayClusterPreemptedByAnnotationKey])
+	assert.Equal(t, []string{"small-1"}, cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+
+	replicas, err := GetPreemptedReplicas(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int32{"small": 2, "large": 1}, replicas)
+	RestorePreemptedRayCluster(&cluster, replicas)
+	assert.False(t, IsRayClusterPreempted(cluster))
+	assert.Empty(t, cluster.Annotations[RayClusterPreemptedByAnnotationKey])
+	assert.Equal(t, int32(2), *cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Equal(t, int32(1), *cluster.Spec.WorkerGroupSpecs[1].Replicas)
+	assert.Empty(t, cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+}
+
+func TestGetOwnerRayJobName(t *testing.T) {
+	cluster := preemptionCluster("a", "ns", nil, time.Now())
+	_, ok := GetOwnerRayJobName(cluster)
+	assert.False(t, ok)
+
+	cluster.OwnerReferences = []metav1.OwnerReference{{Kind: string(utils.RayJobCRD), Name: "my-job", Controller: pointer.Bool(true)}}
+	name, ok := GetOwnerRayJobName(cluster)
+	assert.True(t, ok)
+	assert.Equal(t, "my-job", name)
+}
+
+func TestGetUnschedulablePods(t *testing.T) {
+	now := time.Now()
+	pod := func(phase v1.PodPhase, nodeName string, conditions ...v1.PodCondition) v1.Pod {
+		return v1.Pod{
+			Spec: v1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: cpuResources("2")}}},
+			},
+			Status: v1.PodStatus{Phase: phase, Conditions: conditions},
+		}
+	}
+	unschedulable := func(since time.Time) v1.PodCondition {
+		return v1.PodCondition{
+			Type:               v1.PodScheduled,
+			Status:             v1.ConditionFalse,
+			Reason:             v1.PodReasonUnschedulable,
+			LastTransitionTime: metav1.Time{Time: since},
+		}
+	}
+
+	since, requests := GetUnschedulablePods([]v1.Pod{
+		pod(v1.PodRunning, "node-1", v1.PodCondition{Type: v1.PodScheduled, Status: v1.ConditionTrue}),
+		pod(v1.PodPending, ""),
+	})
+	assert.True(t, since.IsZero())
+	assert.True(t, requests.Cpu().IsZero())
+
+	since, requests = GetUnschedulablePods([]v1.Pod{
+		pod(v1.PodRunning, "node-1", v1.PodCondition{Type: v1.PodScheduled, Status: v1.ConditionTrue}),
+		pod(v1.PodPending, "", unschedulable(now.Add(-time.Minute))),
+		pod(v1.PodPending, "", unschedulable(now.Add(-10*time.Minute))),
+	})
+	assert.Equal(t, now.Add(-10*time.Minute), since)
+	assert.Equal(t, int64(4), requests.Cpu().Value())
+}
+
+func TestGetScheduledPodsResources(t *testing.T) {
+	pods := []v1.Pod{
+		{Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: cpuResources("2")}}}}},
+		{Spec: v1.PodSpec{NodeName: "node-2", Containers: []v1.Container{{Resources: v1.ResourceRequirements{Limits: cpuResources("1")}}}}},
+		{Spec: v1.PodSpec{Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: cpuResources("8")}}}}},
+		{
+			Spec:   v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: cpuResources("8")}}}},
+			Status: v1.PodStatus{Phase: v1.PodSucceeded},
+		},
+	}
+	resources := GetScheduledPodsResources(pods)
+	assert.Equal(t, int64(3), resources.Cpu().Value())
+}
+
+func TestSelectPreemptionVictims(t *testing.T) {
+	now := time.Now()
+	preemptor := preemptionCluster("preemptor", "ns", &rayv1alpha1.PreemptionOptions{Priority: 10}, now)
+	candidate := func(name string, namespace string, priority int32, preemptible bool, created time.Time, cpu string) PreemptionCandidate {
+		options := &rayv1alpha1.PreemptionOptions{Priority: priority, Preemptible: preemptible}
+		return PreemptionCandidate{Cluster: preemptionCluster(name, namespace, options, created), Resources: cpuResources(cpu)}
+	}
+	names := func(victims []PreemptionCandidate) []string {
+		var result []string
+		for _, victim := range victims {
+			result = append(result, victim.Cluster.Name)
+		}
+		return result
+	}
+
+	candidates := []PreemptionCandidate{
+		{Cluster: preemptor, Resources: cpuResources("16")},
+		candidate("not-preemptible", "ns", 0, false, now, "16"),
+		candidate("same-priority", "ns", 10, true, now, "16"),
+		candidate("other-namespace", "other-ns", 0, true, now, "16"),
+		candidate("idle", "ns", 0, true, now, "0"),
+		candidate("priority-5", "ns", 5, true, now, "4"),
+		candidate("old-priority-1", "ns", 1, true, now.Add(-time.Hour), "2"),
+		candidate("new-priority-1", "ns", 1, true, now, "2"),
+	}
+	assert.Equal(t, []string{"new-priority-1"}, names(SelectPreemptionVictims(nil, preemptor, candidates, cpuResources("2"))))
+	assert.Equal(t, []string{"new-priority-1", "old-priority-1"}, names(SelectPreemptionVictims(nil, preemptor, candidates, cpuResources("3"))))
+	assert.Equal(t, []string{"new-priority-1", "old-priority-1", "priority-5"}, names(SelectPreemptionVictims(nil, preemptor, candidates, cpuResources("8"))))
+	// Preempting all the candidates would not be enough.
+	assert.Empty(t, SelectPreemptionVictims(nil, preemptor, candidates, cpuResources("9")))
+	assert.Empty(t, SelectPreemptionVictims(nil, preemptor, candidates, v1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")}))
+
+	// A quota group of the operator configuration spans namespaces.
+	quotaGroups := map[string]string{"ns": "team", "other-ns": "team"}
+	assert.Equal(t, []string{"other-namespace"}, names(SelectPreemptionVictims(quotaGroups, preemptor, candidates, cpuResources("9"))))
+	assert.Empty(t, SelectPreemptionVictims(map[string]string{"other-ns": "team"}, preemptor, candidates, cpuResources("9")))
+}
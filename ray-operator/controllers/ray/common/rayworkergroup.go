@@ -0,0 +1,111 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// IsRayWorkerGroupsEnabled returns whether KubeRay manages a RayWorkerGroup for each worker group of the RayCluster.
+func IsRayWorkerGroupsEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.EnableRayWorkerGroups != nil && *instance.Spec.EnableRayWorkerGroups
+}
+
+// ValidateRayWorkerGroups makes sure the RayWorkerGroups of the worker groups have different names. The names are
+// truncated to the maximum length of Kubernetes names, and long group names can collide.
+func ValidateRayWorkerGroups(instance rayv1alpha1.RayCluster) error {
+	if !IsRayWorkerGroupsEnabled(instance) {
+		return nil
+	}
+	groupNames := map[string]string{}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		name := utils.GenerateRayWorkerGroupName(instance.Name, worker.GroupName)
+		if groupName, ok := groupNames[name]; ok {
+			return fmt.Errorf("the RayWorkerGroups of worker groups %s and %s would both be named %s, shorten the group names",
+				groupName, worker.GroupName, name)
+		}
+		groupNames[name] = worker.GroupName
+	}
+	return nil
+}
+
+// BuildRayWorkerGroup builds the RayWorkerGroup exposing the replicas of the worker group through the scale subresource.
+func BuildRayWorkerGroup(instance rayv1alpha1.RayCluster, worker rayv1alpha1.WorkerGroupSpec) *rayv1alpha1.RayWorkerGroup {
+	replicas := GetWorkerGroupReplicas(worker)
+	return &rayv1alpha1.RayWorkerGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateRayWorkerGroupName(instance.Name, worker.GroupName),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:   instance.Name,
+				RayNodeGroupLabelKey: worker.GroupName,
+			},
+			Annotations: map[string]string{
+				RayWorkerGroupSyncedReplicasAnnotationKey: strconv.Itoa(int(replicas)),
+			},
+		},
+		Spec: rayv1alpha1.RayWorkerGroupSpec{
+			RayClusterName: instance.Name,
+			GroupName:      worker.GroupName,
+			Replicas:       &replicas,
+		},
+		Status: rayv1alpha1.RayWorkerGroupStatus{
+			Selector: GetWorkerGroupSelector(instance, worker.GroupName),
+		},
+	}
+}
+
+// GetWorkerGroupSelector returns the label selector of the worker Pods of the group, in its string form.
+func GetWorkerGroupSelector(instance rayv1alpha1.RayCluster, groupName string) string {
+	return labels.SelectorFromSet(labels.Set{
+		RayClusterLabelKey:   instance.Name,
+		RayNodeTypeLabelKey:  string(rayv1alpha1.WorkerNode),
+		RayNodeGroupLabelKey: groupName,
+	}).String()
+}
+
+// GetWorkerGroupReplicas returns the replicas of the worker group, or 0 if they are not set.
+func GetWorkerGroupReplicas(worker rayv1alpha1.WorkerGroupSpec) int32 {
+	if worker.Replicas == nil {
+		return 0
+	}
+	return *worker.Replicas
+}
+
+// ClampWorkerGroupReplicas returns the replicas bounded by the minReplicas and maxReplicas of the worker group.
+func ClampWorkerGroupReplicas(worker rayv1alpha1.WorkerGroupSpec, replicas int32) int32 {
+	if worker.MaxReplicas != nil && replicas > *worker.MaxReplicas {
+		replicas = *worker.MaxReplicas
+	}
+	if worker.MinReplicas != nil && replicas < *worker.MinReplicas {
+		replicas = *worker.MinReplicas
+	}
+	return replicas
+}
+
+// GetRayWorkerGroupSyncedReplicas returns the replicas last synced between the RayWorkerGroup and its worker group,
+// or false if the RayWorkerGroup was never synced.
+func GetRayWorkerGroupSyncedReplicas(workerGroup rayv1alpha1.RayWorkerGroup) (int32, bool) {
+	value, ok := workerGroup.Annotations[RayWorkerGroupSyncedReplicasAnnotationKey]
+	if !ok {
+		return 0, false
+	}
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(replicas), true
+}
+
+// SetRayWorkerGroupReplicas sets the replicas of the RayWorkerGroup and records them as synced with its worker group.
+func SetRayWorkerGroupReplicas(workerGroup *rayv1alpha1.RayWorkerGroup, replicas int32) {
+	workerGroup.Spec.Replicas = &replicas
+	if workerGroup.Annotations == nil {
+		workerGroup.Annotations = map[string]string{}
+	}
+	workerGroup.Annotations[RayWorkerGroupSyncedReplicasAnnotationKey] = strconv.Itoa(int(replicas))
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestBuildRayWorkerGroup(t *testing.T) {
+	cluster := instance.DeepCopy()
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Replicas = pointer.Int32(3)
+
+	workerGroup := BuildRayWorkerGroup(*cluster, worker)
+	assert.Equal(t, cluster.Name+"-"+worker.GroupName, workerGroup.Name)
+	assert.Equal(t, cluster.Namespace, workerGroup.Namespace)
+	assert.Equal(t, cluster.Name, workerGroup.Labels[RayClusterLabelKey])
+	assert.Equal(t, worker.GroupName, workerGroup.Labels[RayNodeGroupLabelKey])
+	assert.Equal(t, cluster.Name, workerGroup.Spec.RayClusterName)
+	assert.Equal(t, worker.GroupName, workerGroup.Spec.GroupName)
+	assert.Equal(t, int32(3), *workerGroup.Spec.Replicas)
+	assert.Equal(t, "ray.io/cluster="+cluster.Name+",ray.io/group="+worker.GroupName+",ray.io/node-type=worker", workerGroup.Status.Selector)
+
+	synced, ok := GetRayWorkerGroupSyncedReplicas(*workerGroup)
+	assert.True(t, ok)
+	assert.Equal(t, int32(3), synced)
+
+	SetRayWorkerGroupReplicas(workerGroup, 5)
+	assert.Equal(t, int32(5), *workerGroup.Spec.Replicas)
+	synced, ok = GetRayWorkerGroupSyncedReplicas(*workerGroup)
+	assert.True(t, ok)
+	assert.Equal(t, int32(5), synced)
+
+	delete(workerGroup.Annotations, RayWorkerGroupSyncedReplicasAnnotationKey)
+	_, ok = GetRayWorkerGroupSyncedReplicas(*workerGroup)
+	assert.False(t, ok)
+}
+
+func TestValidateRayWorkerGroups(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableRayWorkerGroups = pointer.Bool(true)
+	assert.Nil(t, ValidateRayWorkerGroups(*cluster))
+
+	// The RayWorkerGroup names keep the end of long group names, which collide.
+	suffix := strings.Repeat("x", 60)
+	otherWorker := cluster.Spec.WorkerGroupSpecs[0]
+	cluster.Spec.WorkerGroupSpecs[0].GroupName = "a-" + suffix
+	otherWorker.GroupName = "b-" + suffix
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, otherWorker)
+	assert.NotNil(t, ValidateRayWorkerGroups(*cluster))
+
+	cluster.Spec.EnableRayWorkerGroups = nil
+	assert.Nil(t, ValidateRayWorkerGroups(*cluster))
+}
+
+func TestClampWorkerGroupReplicas(t *testing.T) {
+	worker := rayv1alpha1.WorkerGroupSpec{MinReplicas: pointer.Int32(1), MaxReplicas: pointer.Int32(5)}
+	assert.Equal(t, int32(1), ClampWorkerGroupReplicas(worker, 0))
+	assert.Equal(t, int32(3), ClampWorkerGroupReplicas(worker, 3))
+	assert.Equal(t, int32(5), ClampWorkerGroupReplicas(worker, 10))
+
+	assert.Equal(t, int32(10), ClampWorkerGroupReplicas(rayv1alpha1.WorkerGroupSpec{}, 10))
+}
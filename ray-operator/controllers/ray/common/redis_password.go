@@ -0,0 +1,141 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RedisPasswordKey = "password"
+	// Annotation of the Redis password Secret recording the rotation ID the password was generated for
+	RedisPasswordRotationIDAnnotationKey = "ray.io/redis-password-rotation-id"
+	// Label of the Secrets holding a Redis password managed by KubeRay
+	RedisPasswordSecretLabelKey = "ray.io/redis-password"
+	// Annotation of the RayCluster confirming that the external Redis accepts the password of a rotation ID, so that the
+	// Pods can be recreated with it
+	RedisPasswordRolloutAnnotationKey = "ray.io/redis-password-rollout"
+	redisPasswordBytes                = 32
+)
+
+// IsManagedRedisPasswordEnabled returns whether KubeRay generates the Redis password of the RayCluster.
+func IsManagedRedisPasswordEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.ManagedRedisPassword != nil
+}
+
+// GenerateRedisPassword generates a random Redis password.
+func GenerateRedisPassword() (string, error) {
+	return generateRandomHexString(redisPasswordBytes)
+}
+
+// GetRedisPasswordSecretName returns the name of the Secret holding the Redis password of the current rotation ID.
+func GetRedisPasswordSecretName(instance rayv1alpha1.RayCluster) string {
+	return utils.GenerateRedisPasswordSecretName(instance.Name, instance.Spec.ManagedRedisPassword.RotationID)
+}
+
+// GetRolledOutRedisPasswordSecretName returns the name of the Secret holding the Redis password of the Pods, which is
+// the password of the current rotation ID once it is rolled out.
+func GetRolledOutRedisPasswordSecretName(instance rayv1alpha1.RayCluster) string {
+	if instance.Status.RedisPassword == nil {
+		return GetRedisPasswordSecretName(instance)
+	}
+	return utils.GenerateRedisPasswordSecretName(instance.Name, instance.Status.RedisPassword.RotationID)
+}
+
+// IsRedisPasswordRolloutAllowed returns whether the Pods can be recreated with the password of the current rotation ID.
+// An external Redis must be configured with the new password first, which the user confirms by setting the
+// ray.io/redis-password-rollout annotation to the rotation ID.
+func IsRedisPasswordRolloutAllowed(instance rayv1alpha1.RayCluster) bool {
+	if instance.Status.RedisPassword == nil || !IsGCSFaultToleranceEnabled(instance) {
+		return true
+	}
+	rotationID, ok := instance.Annotations[RedisPasswordRolloutAnnotationKey]
+	return ok && rotationID == instance.Spec.ManagedRedisPassword.RotationID
+}
+
+// GetPodRedisPasswordSecretName returns the name of the Secret of the REDIS_PASSWORD environment variable of the Ray
+// container of the Pod, if the Secret is managed by KubeRay.
+func GetPodRedisPasswordSecretName(instance rayv1alpha1.RayCluster, pod v1.Pod) string {
+	if len(pod.Spec.Containers) <= RayContainerIndex {
+		return ""
+	}
+	for _, env := range pod.Spec.Containers[RayContainerIndex].Env {
+		if env.Name != REDIS_PASSWORD || env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		if name := env.ValueFrom.SecretKeyRef.Name; strings.HasPrefix(name, utils.GenerateRedisPasswordSecretName(instance.Name, "")) {
+			return name
+		}
+	}
+	return ""
+}
+
+// BuildRedisPasswordSecret builds the Secret holding the Redis password managed by KubeRay.
+func BuildRedisPasswordSecret(instance rayv1alpha1.RayCluster, password string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetRedisPasswordSecretName(instance),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                instance.Name,
+				RedisPasswordSecretLabelKey:       "true",
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+			Annotations: map[string]string{
+				RedisPasswordRotationIDAnnotationKey: instance.Spec.ManagedRedisPassword.RotationID,
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			RedisPasswordKey: []byte(password),
+		},
+	}
+}
+
+// ValidateManagedRedisPassword makes sure the external Redis of spec.gcsFaultToleranceOptions is protected by a password,
+// either managed by KubeRay or taken from a Secret, and that no other Redis password is configured for the RayCluster
+// when KubeRay manages it.
+func ValidateManagedRedisPassword(instance rayv1alpha1.RayCluster) error {
+	if options := instance.Spec.GcsFaultToleranceOptions; options != nil && options.RedisPassword == nil && !IsManagedRedisPasswordEnabled(instance) {
+		return fmt.Errorf("spec.gcsFaultToleranceOptions requires either spec.gcsFaultToleranceOptions.redisPassword or spec.managedRedisPassword")
+	}
+	if !IsManagedRedisPasswordEnabled(instance) {
+		return nil
+	}
+	if options := instance.Spec.GcsFaultToleranceOptions; options != nil && options.RedisPassword != nil {
+		return fmt.Errorf("spec.managedRedisPassword cannot be used with spec.gcsFaultToleranceOptions.redisPassword")
+	}
+	if err := validateNoRedisPassword(HeadGroupName, instance.Spec.HeadGroupSpec.RayStartParams, instance.Spec.HeadGroupSpec.Template); err != nil {
+		return err
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if err := validateNoRedisPassword(worker.GroupName, worker.RayStartParams, worker.Template); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNoRedisPassword(groupName string, rayStartParams map[string]string, template v1.PodTemplateSpec) error {
+	if _, ok := rayStartParams["redis-password"]; ok {
+		return fmt.Errorf("the redis-password rayStartParam of the group %s cannot be used with spec.managedRedisPassword", groupName)
+	}
+	if len(template.Spec.Containers) > RayContainerIndex && envVarExists(REDIS_PASSWORD, template.Spec.Containers[RayContainerIndex].Env) {
+		return fmt.Errorf("the environment variable %s of the group %s cannot be used with spec.managedRedisPassword", REDIS_PASSWORD, groupName)
+	}
+	return nil
+}
+
+// addManagedRedisPassword passes the Redis password managed by KubeRay to `ray start` and to the Redis cleanup Job, which
+// is built from the head Pod.
+func addManagedRedisPassword(instance rayv1alpha1.RayCluster, podTemplate *v1.PodTemplateSpec, rayStartParams map[string]string) {
+	addRedisPasswordEnvVar(podTemplate, rayStartParams, &v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: GetRolledOutRedisPasswordSecretName(instance)},
+		Key:                  RedisPasswordKey,
+	})
+}
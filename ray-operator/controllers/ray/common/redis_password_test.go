@@ -0,0 +1,144 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func getRedisPasswordSecretKeyRef(t *testing.T, container v1.Container) *v1.SecretKeySelector {
+	for _, env := range container.Env {
+		if env.Name == REDIS_PASSWORD {
+			assert.Empty(t, env.Value)
+			return env.ValueFrom.SecretKeyRef
+		}
+	}
+	t.Fatalf("%s is not set", REDIS_PASSWORD)
+	return nil
+}
+
+func TestBuildPod_WithManagedRedisPassword(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.ManagedRedisPassword = &rayv1alpha1.ManagedRedisPasswordOptions{}
+	assert.Nil(t, ValidateManagedRedisPassword(*cluster))
+	secretName := utils.GenerateRedisPasswordSecretName(cluster.Name, "")
+
+	// Head Pod
+	podName := strings.ToLower(cluster.Name + DashSymbol + string(rayv1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
+	headSpec := *cluster.Spec.HeadGroupSpec.DeepCopy()
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, headSpec, podName, "6379")
+	pod := BuildPod(podTemplateSpec, rayv1alpha1.HeadNode, headSpec.RayStartParams, "6379", nil, "", "")
+	rayContainer := pod.Spec.Containers[RayContainerIndex]
+	secretKeyRef := getRedisPasswordSecretKeyRef(t, rayContainer)
+	assert.Equal(t, secretName, secretKeyRef.Name)
+	assert.Equal(t, RedisPasswordKey, secretKeyRef.Key)
+	assert.Contains(t, rayContainer.Args[0], "--redis-password=$REDIS_PASSWORD")
+
+	// Worker Pod
+	worker := *cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	podName = cluster.Name + DashSymbol + string(rayv1alpha1.WorkerNode) + DashSymbol + worker.GroupName + DashSymbol + utils.FormatInt32(0)
+	fqdnRayIP := utils.GenerateFQDNServiceName(*cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(*cluster, worker, podName, fqdnRayIP, "6379")
+	pod = BuildPod(podTemplateSpec, rayv1alpha1.WorkerNode, worker.RayStartParams, "6379", nil, "", fqdnRayIP)
+	rayContainer = pod.Spec.Containers[RayContainerIndex]
+	assert.Equal(t, secretName, getRedisPasswordSecretKeyRef(t, rayContainer).Name)
+	assert.Contains(t, rayContainer.Args[0], "--redis-password=$REDIS_PASSWORD")
+
+	// The pod templates of the RayCluster are not modified.
+	assert.False(t, envVarExists(REDIS_PASSWORD, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex].Env))
+	assert.False(t, envVarExists(REDIS_PASSWORD, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[RayContainerIndex].Env))
+}
+
+func TestValidateManagedRedisPassword(t *testing.T) {
+	tests := map[string]struct {
+		mutate      func(cluster *rayv1alpha1.RayCluster)
+		expectError bool
+	}{
+		"managed password only": {
+			mutate:      func(cluster *rayv1alpha1.RayCluster) {},
+			expectError: false,
+		},
+		"with GCS fault tolerance": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+			},
+			expectError: false,
+		},
+		"with a GCS fault tolerance password": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{
+					RedisAddress:  "redis:6379",
+					RedisPassword: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "redis"}, Key: "password"},
+				}
+			},
+			expectError: true,
+		},
+		"password in worker rayStartParams": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				cluster.Spec.WorkerGroupSpecs[0].RayStartParams["redis-password"] = "LetMeInRay"
+			},
+			expectError: true,
+		},
+		"password in the head container": {
+			mutate: func(cluster *rayv1alpha1.RayCluster) {
+				container := &cluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex]
+				container.Env = append(container.Env, v1.EnvVar{Name: REDIS_PASSWORD, Value: "LetMeInRay"})
+			},
+			expectError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := instance.DeepCopy()
+			cluster.Spec.ManagedRedisPassword = &rayv1alpha1.ManagedRedisPasswordOptions{}
+			tc.mutate(cluster)
+			err := ValidateManagedRedisPassword(*cluster)
+			assert.Equal(t, tc.expectError, err != nil, err)
+		})
+	}
+}
+
+func TestValidateManagedRedisPassword_ExternalRedis(t *testing.T) {
+	cluster := instance.DeepCopy()
+	assert.Nil(t, ValidateManagedRedisPassword(*cluster))
+
+	// An external Redis requires a password, either managed by KubeRay or taken from a Secret.
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	assert.NotNil(t, ValidateManagedRedisPassword(*cluster))
+	cluster.Spec.GcsFaultToleranceOptions.RedisPassword = &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "redis"}, Key: "password"}
+	assert.Nil(t, ValidateManagedRedisPassword(*cluster))
+}
+
+func TestGenerateRedisPasswordSecretName(t *testing.T) {
+	assert.Equal(t, "raycluster-sample-redis-password", utils.GenerateRedisPasswordSecretName("raycluster-sample", ""))
+	rotated := utils.GenerateRedisPasswordSecretName("raycluster-sample", "2023-10-01")
+	assert.Regexp(t, "^raycluster-sample-redis-password-[0-9a-f]{8}$", rotated)
+	assert.NotEqual(t, rotated, utils.GenerateRedisPasswordSecretName("raycluster-sample", "2023-11-01"))
+}
+
+func TestIsRedisPasswordRolloutAllowed(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.ManagedRedisPassword = &rayv1alpha1.ManagedRedisPasswordOptions{RotationID: "2023-10-01"}
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	// The first password is rolled out right away.
+	assert.True(t, IsRedisPasswordRolloutAllowed(*cluster))
+	assert.Equal(t, GetRedisPasswordSecretName(*cluster), GetRolledOutRedisPasswordSecretName(*cluster))
+
+	// The password of a new rotation ID waits for the external Redis.
+	cluster.Status.RedisPassword = &rayv1alpha1.RedisPasswordStatus{}
+	assert.False(t, IsRedisPasswordRolloutAllowed(*cluster))
+	assert.Equal(t, utils.GenerateRedisPasswordSecretName(cluster.Name, ""), GetRolledOutRedisPasswordSecretName(*cluster))
+	cluster.Annotations = map[string]string{RedisPasswordRolloutAnnotationKey: "2023-09-01"}
+	assert.False(t, IsRedisPasswordRolloutAllowed(*cluster))
+	cluster.Annotations[RedisPasswordRolloutAnnotationKey] = "2023-10-01"
+	assert.True(t, IsRedisPasswordRolloutAllowed(*cluster))
+
+	// Without an external Redis, the password is rolled out right away.
+	cluster.Annotations = nil
+	cluster.Spec.GcsFaultToleranceOptions = nil
+	assert.True(t, IsRedisPasswordRolloutAllowed(*cluster))
+}
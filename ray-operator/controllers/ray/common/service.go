@@ -22,6 +22,36 @@ func HeadServiceLabels(cluster rayv1alpha1.RayCluster) map[string]string {
 	}
 }
 
+// IsWorkerHeadlessServiceEnabled returns whether KubeRay creates a headless service for the worker Pods of the RayCluster.
+func IsWorkerHeadlessServiceEnabled(cluster rayv1alpha1.RayCluster) bool {
+	return cluster.Spec.EnableWorkerHeadlessService != nil && *cluster.Spec.EnableWorkerHeadlessService
+}
+
+// BuildHeadlessServiceForWorkerPods builds the headless service that gives a DNS name to each worker Pod. The Pods are
+// published before they are ready because Ray workers are usually looked up by their peers while they start.
+func BuildHeadlessServiceForWorkerPods(cluster rayv1alpha1.RayCluster) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateWorkerHeadlessServiceName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                cluster.Name,
+				RayNodeTypeLabelKey:               string(rayv1alpha1.WorkerNode),
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector: map[string]string{
+				RayClusterLabelKey:  cluster.Name,
+				RayNodeTypeLabelKey: string(rayv1alpha1.WorkerNode),
+			},
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
 // BuildServiceForHeadPod Builds the service for a pod. Currently, there is only one service that allows
 // the worker nodes to connect to the head node.
 func BuildServiceForHeadPod(cluster rayv1alpha1.RayCluster, labels map[string]string, annotations map[string]string) (*corev1.Service, error) {
@@ -66,6 +96,15 @@ func BuildServiceForHeadPod(cluster rayv1alpha1.RayCluster, labels map[string]st
 		svcPort := corev1.ServicePort{Name: name, Port: port, AppProtocol: &defaultAppProtocol}
 		ports = append(ports, svcPort)
 	}
+	// The dashboard and the dashboard agent only accept authenticated requests forwarded by the proxy container.
+	if IsDashboardAuthEnabled(cluster) {
+		targetPorts := getDashboardAuthTargetPorts()
+		for i := range ports {
+			if targetPort, ok := targetPorts[ports[i].Name]; ok {
+				ports[i].TargetPort = targetPort
+			}
+		}
+	}
 	if cluster.Spec.HeadGroupSpec.HeadService != nil {
 		// Use the provided "custom" HeadService.
 		// Deep copy the HeadService to avoid modifying the original object
@@ -114,6 +153,7 @@ func BuildServiceForHeadPod(cluster rayv1alpha1.RayCluster, labels map[string]st
 			Type:     default_type,
 		},
 	}
+	setPublishNotReadyAddressesForHeadService(headService, cluster)
 
 	// This change ensures that reconciliation in rayservice_controller will not update the Service spec due to change in ports order
 	// sorting the ServicePorts on their name
@@ -168,14 +208,7 @@ func BuildServeServiceForRayService(rayService rayv1alpha1.RayService, rayCluste
 
 	// `ports_int` is a map of port names to port numbers, while `ports` is a list of ServicePort objects
 	ports_int := getServicePorts(rayCluster)
-	ports := []corev1.ServicePort{}
-	for name, port := range ports_int {
-		if name == DefaultServingPortName {
-			svcPort := corev1.ServicePort{Name: name, Port: port}
-			ports = append(ports, svcPort)
-			break
-		}
-	}
+	ports := getServeServicePorts(ports_int)
 
 	if len(ports) == 0 && rayService.Spec.ServeService == nil {
 		return nil, fmt.Errorf("Please specify the port named 'serve' in the Ray head container; " +
@@ -194,21 +227,17 @@ func BuildServeServiceForRayService(rayService rayv1alpha1.RayService, rayCluste
 			serveService.ObjectMeta.Annotations = make(map[string]string)
 		}
 
-		// Add port with name "serve" if it is already not added and ignore any custom ports
-		// Keeping this consistentent with adding only serve port in serve service
+		// Add ports with name "serve" and "serve-grpc" if they are already not added and ignore any custom ports
+		// Keeping this consistentent with adding only serve ports in serve service
 		if len(ports) != 0 {
 			log.Info("port with name 'serve' already added. Ignoring user provided ports for serve service")
 			serveService.Spec.Ports = ports
 		} else {
-			ports := []corev1.ServicePort{}
+			userPorts := map[string]int32{}
 			for _, port := range serveService.Spec.Ports {
-				if port.Name == DefaultServingPortName {
-					svcPort := corev1.ServicePort{Name: port.Name, Port: port.Port}
-					ports = append(ports, svcPort)
-					break
-				}
+				userPorts[port.Name] = port.Port
 			}
-			serveService.Spec.Ports = ports
+			serveService.Spec.Ports = getServeServicePorts(userPorts)
 		}
 
 		setLabelsforUserProvidedService(serveService, labels)
@@ -235,6 +264,68 @@ func BuildServeServiceForRayService(rayService rayv1alpha1.RayService, rayCluste
 	return serveService, nil
 }
 
+// BuildServeServicesForApplications builds one service per Serve application defined in `serveConfigV2`. Each service
+// selects the same Pods as the serve service, i.e. the Pods with a healthy HTTP proxy, so that every application
+// gets its own DNS name and can be targeted individually by network policies.
+func BuildServeServicesForApplications(rayService rayv1alpha1.RayService, rayCluster rayv1alpha1.RayCluster) ([]*corev1.Service, error) {
+	apps, err := utils.ParseServeConfigV2Applications(rayService.Spec.ServeConfigV2)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := getServicePorts(rayCluster)
+	if _, ok := ports[DefaultServingPortName]; !ok {
+		return nil, fmt.Errorf("Please specify the port named 'serve' in the Ray head container; " +
+			"otherwise, the Kubernetes services for the Serve applications will not be created.")
+	}
+
+	serviceType := rayService.Spec.RayClusterSpec.HeadGroupSpec.ServiceType
+	if rayService.Spec.ServeApplicationServices != nil && rayService.Spec.ServeApplicationServices.ServiceType != "" {
+		serviceType = rayService.Spec.ServeApplicationServices.ServiceType
+	}
+
+	services := make([]*corev1.Service, 0, len(apps))
+	// Different application names can be sanitized or truncated to the same service name.
+	appsByServiceName := make(map[string]string, len(apps))
+	for _, app := range apps {
+		name := utils.GenerateServeApplicationServiceName(rayService.Name, app.Name)
+		if other, ok := appsByServiceName[name]; ok {
+			return nil, fmt.Errorf("the Serve applications %q and %q both map to the Kubernetes service %s; rename one of them", other, app.Name, name)
+		}
+		appsByServiceName[name] = app.Name
+		services = append(services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: rayService.Namespace,
+				Labels: map[string]string{
+					RayServiceLabelKey:               rayService.Name,
+					RayClusterServingServiceLabelKey: utils.GenerateServeServiceLabel(rayService.Name),
+					RayServeApplicationLabelKey:      utils.CheckLabel(utils.SanitizeDNSLabel(app.Name)),
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{
+					RayClusterLabelKey:               rayCluster.Name,
+					RayClusterServingServiceLabelKey: EnableRayClusterServingServiceTrue,
+				},
+				Ports: getServeServicePorts(ports),
+				Type:  serviceType,
+			},
+		})
+	}
+
+	return services, nil
+}
+
+// setPublishNotReadyAddressesForHeadService keeps the head Pod reachable while it waits for the Serve readiness gate.
+// Otherwise, the worker Pods could not connect to the GCS, and the RayService controller could not reach the dashboard
+// to deploy the Serve applications, which are required for the readiness gate to become true in the first place.
+func setPublishNotReadyAddressesForHeadService(service *corev1.Service, cluster rayv1alpha1.RayCluster) {
+	if utils.HasReadinessGate(cluster.Spec.HeadGroupSpec.Template.Spec, RayServeReadyConditionType) {
+		service.Spec.PublishNotReadyAddresses = true
+	}
+}
+
 func setServiceTypeForUserProvidedService(service *corev1.Service, default_type corev1.ServiceType) {
 	// If the user has not specified a service type, use the default service type
 	if service.Spec.Type == "" {
@@ -311,6 +402,20 @@ func getServicePorts(cluster rayv1alpha1.RayCluster) map[string]int32 {
 	return ports
 }
 
+// getServeServicePorts returns the HTTP and gRPC serve ports in `ports`, in that order. The gRPC port is marked as
+// HTTP/2 over cleartext so that ingress controllers and gateways that honor `appProtocol` proxy it correctly.
+func getServeServicePorts(ports map[string]int32) []corev1.ServicePort {
+	servicePorts := []corev1.ServicePort{}
+	if port, ok := ports[DefaultServingPortName]; ok {
+		servicePorts = append(servicePorts, corev1.ServicePort{Name: DefaultServingPortName, Port: port})
+	}
+	if port, ok := ports[DefaultServingGRPCPortName]; ok {
+		appProtocol := ServeGRPCAppProtocol
+		servicePorts = append(servicePorts, corev1.ServicePort{Name: DefaultServingGRPCPortName, Port: port, AppProtocol: &appProtocol})
+	}
+	return servicePorts
+}
+
 // getPortsFromCluster get the ports from head container and directly map them in service
 // It's user's responsibility to maintain rayStartParam ports and container ports mapping
 // TODO: Consider to infer ports from rayStartParams (source of truth) in the future.
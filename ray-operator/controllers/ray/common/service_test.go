@@ -129,6 +129,26 @@ func TestBuildServiceForHeadPod(t *testing.T) {
 	}
 }
 
+func TestBuildServiceForHeadPodWithDashboardAuth(t *testing.T) {
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Annotations = map[string]string{EnableAgentServiceKey: EnableAgentServiceTrue}
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeToken}
+	svc, err := BuildServiceForHeadPod(*cluster, nil, nil)
+	assert.Nil(t, err)
+
+	// The dashboard and dashboard agent ports are forwarded to the proxy, the other ports are unchanged.
+	for _, port := range svc.Spec.Ports {
+		switch port.Name {
+		case DefaultDashboardName:
+			assert.Equal(t, DashboardAuthProxyPortName, port.TargetPort.String())
+		case DefaultDashboardAgentListenPortName:
+			assert.Equal(t, DashboardAgentAuthProxyPortName, port.TargetPort.String())
+		default:
+			assert.Equal(t, "0", port.TargetPort.String())
+		}
+	}
+}
+
 func TestBuildServiceForHeadPodWithAppNameLabel(t *testing.T) {
 	labels := make(map[string]string)
 	labels[KubernetesApplicationNameLabelKey] = "testname"
@@ -555,3 +575,125 @@ func validateLabelsForUserSpecifiedService(svc *corev1.Service, userLabels map[s
 		}
 	}
 }
+
+func TestBuildServeServicesForApplications(t *testing.T) {
+	rayService := serviceInstance.DeepCopy()
+	rayService.Spec.ServeConfigV2 = `
+applications:
+  - name: text_ml_app
+    route_prefix: /summarize_translate
+    import_path: text_ml.app
+  - name: fruit_app
+    route_prefix: /fruit
+    import_path: fruit.deployment_graph
+`
+	rayService.Spec.ServeApplicationServices = &rayv1alpha1.ServeApplicationServicesOptions{
+		ServiceType: corev1.ServiceTypeNodePort,
+	}
+
+	svcs, err := BuildServeServicesForApplications(*rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(svcs))
+
+	assert.Equal(t, "rayservice-sample-text-ml-app-serve-svc", svcs[0].Name)
+	assert.Equal(t, "rayservice-sample-fruit-app-serve-svc", svcs[1].Name)
+	for _, svc := range svcs {
+		assert.Equal(t, rayService.Namespace, svc.Namespace)
+		assert.Equal(t, rayService.Name, svc.Labels[RayServiceLabelKey])
+		assert.Equal(t, instanceWithWrongSvc.Name, svc.Spec.Selector[RayClusterLabelKey])
+		assert.Equal(t, EnableRayClusterServingServiceTrue, svc.Spec.Selector[RayClusterServingServiceLabelKey])
+		assert.Equal(t, corev1.ServiceTypeNodePort, svc.Spec.Type)
+		assert.Equal(t, 1, len(svc.Spec.Ports))
+		assert.Equal(t, int32(8000), svc.Spec.Ports[0].Port)
+	}
+	assert.Equal(t, "text-ml-app", svcs[0].Labels[RayServeApplicationLabelKey])
+
+	// The service type falls back to the one of the head service.
+	rayService.Spec.ServeApplicationServices.ServiceType = ""
+	svcs, err = BuildServeServicesForApplications(*rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svcs[0].Spec.Type)
+
+	// An application without a name is invalid.
+	rayService.Spec.ServeConfigV2 = `
+applications:
+  - route_prefix: /
+    import_path: fruit.deployment_graph
+`
+	_, err = BuildServeServicesForApplications(*rayService, *instanceWithWrongSvc)
+	assert.NotNil(t, err)
+
+	// Application names that are sanitized to the same service name are rejected.
+	rayService.Spec.ServeConfigV2 = `
+applications:
+  - name: fruit_app
+    route_prefix: /fruit
+    import_path: fruit.deployment_graph
+  - name: Fruit.App
+    route_prefix: /other_fruit
+    import_path: fruit.deployment_graph
+`
+	_, err = BuildServeServicesForApplications(*rayService, *instanceWithWrongSvc)
+	assert.NotNil(t, err)
+}
+
+func TestBuildServiceForHeadPodWithServeReadinessGate(t *testing.T) {
+	svc, err := BuildServiceForHeadPod(*instanceWithWrongSvc, nil, nil)
+	assert.Nil(t, err)
+	assert.False(t, svc.Spec.PublishNotReadyAddresses)
+
+	// The head service should publish the head Pod before the Serve readiness gate becomes true.
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.ReadinessGates = []corev1.PodReadinessGate{
+		{ConditionType: RayServeReadyConditionType},
+	}
+	svc, err = BuildServiceForHeadPod(*cluster, nil, nil)
+	assert.Nil(t, err)
+	assert.True(t, svc.Spec.PublishNotReadyAddresses)
+}
+
+func TestBuildServeServiceForRayService_WithGRPCPort(t *testing.T) {
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = append(
+		cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports,
+		corev1.ContainerPort{Name: DefaultServingGRPCPortName, ContainerPort: 9000},
+	)
+
+	svc, err := BuildServeServiceForRayService(*serviceInstance, *cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(svc.Spec.Ports))
+	assert.Equal(t, DefaultServingPortName, svc.Spec.Ports[0].Name)
+	assert.Equal(t, DefaultServingGRPCPortName, svc.Spec.Ports[1].Name)
+	assert.Equal(t, int32(9000), svc.Spec.Ports[1].Port)
+	assert.Equal(t, ServeGRPCAppProtocol, *svc.Spec.Ports[1].AppProtocol)
+
+	// The gRPC port of a user-specified serve service is kept as well.
+	rayService := serviceInstance.DeepCopy()
+	rayService.Spec.ServeService = &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: DefaultServingGRPCPortName, Port: 9001},
+				{Name: "other", Port: 1234},
+			},
+		},
+	}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: "gcs", ContainerPort: 6379},
+	}
+	svc, err = BuildServeServiceForRayService(*rayService, *cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(svc.Spec.Ports))
+	assert.Equal(t, int32(9001), svc.Spec.Ports[0].Port)
+}
+
+func TestBuildHeadlessServiceForWorkerPods(t *testing.T) {
+	svc := BuildHeadlessServiceForWorkerPods(*instanceWithWrongSvc)
+	assert.Equal(t, instanceWithWrongSvc.Name+"-workers", svc.Name)
+	assert.Equal(t, instanceWithWrongSvc.Namespace, svc.Namespace)
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	assert.True(t, svc.Spec.PublishNotReadyAddresses)
+	assert.Equal(t, map[string]string{
+		RayClusterLabelKey:  instanceWithWrongSvc.Name,
+		RayNodeTypeLabelKey: string(rayv1alpha1.WorkerNode),
+	}, svc.Spec.Selector)
+}
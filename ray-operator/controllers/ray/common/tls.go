@@ -0,0 +1,413 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// Name of the volume added to Ray Pods when TLS is enabled. It is the Secret with the node certificate, the node key,
+	// and the trusted CA bundle that KubeRay issues for the Pod. The certificate covers the Pod IP, so the Secret is only
+	// created once the Pod is scheduled: the volume is optional and the init container waits for the certificate.
+	RayTLSVolumeName        = "ray-tls"
+	RayTLSVolumeMountPath   = "/etc/ray/tls"
+	RayTLSInitContainerName = "ray-tls-init"
+
+	// Keys of the CA Secret. The layout matches the Secrets created by cert-manager.
+	TLSCACertKey   = "tls.crt"
+	TLSCAKeyKey    = "tls.key"
+	TLSCABundleKey = "ca.crt"
+	// Keys of the node certificate Secret of a Pod, which holds the CA bundle but never the CA key.
+	TLSNodeCertKey = "tls.crt"
+	TLSNodeKeyKey  = "tls.key"
+
+	// Ray TLS environment variables. See https://docs.ray.io/en/latest/ray-core/configure.html#tls-authentication.
+	RAY_USE_TLS         = "RAY_USE_TLS"
+	RAY_TLS_SERVER_CERT = "RAY_TLS_SERVER_CERT"
+	RAY_TLS_SERVER_KEY  = "RAY_TLS_SERVER_KEY"
+	RAY_TLS_CA_CERT     = "RAY_TLS_CA_CERT"
+
+	DefaultTLSCADuration       = 365 * 24 * time.Hour
+	DefaultTLSRenewBefore      = 30 * 24 * time.Hour
+	DefaultTLSNodeCertDuration = 30 * 24 * time.Hour
+)
+
+// rayTLSInitScript waits until the kubelet projects the node certificate issued by KubeRay into the Pod.
+var rayTLSInitScript = fmt.Sprintf(`
+until [[ -s %[1]s/%[2]s && -s %[1]s/%[3]s && -s %[1]s/%[4]s ]]; do
+	echo "Waiting for KubeRay to issue the node certificate of the Pod"
+	sleep 2
+done
+`, RayTLSVolumeMountPath, TLSNodeCertKey, TLSNodeKeyKey, TLSCABundleKey)
+
+// IsTLSEnabled returns whether TLS between the Ray nodes is enabled for the RayCluster.
+func IsTLSEnabled(instance rayv1alpha1.RayCluster) bool {
+	return instance.Spec.TLS != nil
+}
+
+// GetTLSCASecretName returns the name of the Secret holding the CA that issues the node certificates of the RayCluster.
+func GetTLSCASecretName(instance rayv1alpha1.RayCluster) string {
+	if instance.Spec.TLS != nil && instance.Spec.TLS.CASecretName != "" {
+		return instance.Spec.TLS.CASecretName
+	}
+	return utils.CheckName(utils.GenerateTLSCASecretName(instance.Name))
+}
+
+// GetTLSCADuration returns the validity of the CA generated by KubeRay.
+func GetTLSCADuration(tlsOptions *rayv1alpha1.TLSOptions) time.Duration {
+	if tlsOptions != nil && tlsOptions.CADuration != nil {
+		return tlsOptions.CADuration.Duration
+	}
+	return DefaultTLSCADuration
+}
+
+// GetTLSRenewBefore returns how long before the CA expires it is rotated.
+func GetTLSRenewBefore(tlsOptions *rayv1alpha1.TLSOptions) time.Duration {
+	if tlsOptions != nil && tlsOptions.RenewBefore != nil {
+		return tlsOptions.RenewBefore.Duration
+	}
+	return DefaultTLSRenewBefore
+}
+
+// GetTLSNodeCertDuration returns the validity of the node certificates KubeRay issues to the Ray Pods.
+func GetTLSNodeCertDuration(tlsOptions *rayv1alpha1.TLSOptions) time.Duration {
+	if tlsOptions != nil && tlsOptions.NodeCertDuration != nil {
+		return tlsOptions.NodeCertDuration.Duration
+	}
+	return DefaultTLSNodeCertDuration
+}
+
+// GenerateTLSCA generates a self-signed CA certificate and its private key, both PEM encoded.
+func GenerateTLSCA(commonName string, notBefore time.Time, duration time.Duration) (certPEM []byte, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{ApplicationName}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(duration),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// IssueTLSNodeCert generates the private key of a Ray node and a certificate for it signed by the CA, both PEM encoded.
+// The certificate authenticates the node both as a server and as a client, and never outlives the CA.
+func IssueTLSNodeCert(caCertPEM []byte, caKeyPEM []byte, commonName string, dnsNames []string, ipAddresses []net.IP, notBefore time.Time, duration time.Duration) (certPEM []byte, keyPEM []byte, err error) {
+	caCerts, err := ParseTLSCertificates(caCertPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	caKey, err := parseTLSPrivateKey(caKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	notAfter := notBefore.Add(duration)
+	if caCerts[0].NotAfter.Before(notAfter) {
+		notAfter = caCerts[0].NotAfter
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{ApplicationName}},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     dnsNames,
+		IPAddresses:  ipAddresses,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCerts[0], &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// parseTLSPrivateKey parses a PEM encoded EC, RSA, or PKCS #8 private key, such as the keys generated by cert-manager.
+func parseTLSPrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q for a private key", block.Type)
+	}
+}
+
+// TLSNodeCertNeedsRenewal returns whether the node certificate must be renewed: either two thirds of the certificate
+// lifetime have elapsed, or it was not issued by the current CA, which Pods created later do not trust once the previous
+// CA expires.
+func TLSNodeCertNeedsRenewal(nodeCert *x509.Certificate, caCert *x509.Certificate, now time.Time) bool {
+	if nodeCert.CheckSignatureFrom(caCert) != nil {
+		return true
+	}
+	renewTime := nodeCert.NotBefore.Add(nodeCert.NotAfter.Sub(nodeCert.NotBefore) * 2 / 3)
+	return !now.Before(renewTime)
+}
+
+// IsTLSNodeCertRenewalDue returns whether the node certificate of the Pod may need a renewal according to its
+// RayTLSNodeCertIssuedAnnotationKey annotation, so that the Secret of the certificate is only read when it does: the
+// certificate is missing, two thirds of its lifetime have elapsed, or it may have been issued before the current CA.
+func IsTLSNodeCertRenewalDue(pod v1.Pod, caCert *x509.Certificate, duration time.Duration, now time.Time) bool {
+	issueTime, err := time.Parse(time.RFC3339, pod.Annotations[RayTLSNodeCertIssuedAnnotationKey])
+	if err != nil {
+		return true
+	}
+	// The issue time is truncated to the second, like the validity of the CA.
+	if !issueTime.After(caCert.NotBefore) {
+		return true
+	}
+	return !now.Before(issueTime.Add(duration * 2 / 3))
+}
+
+// GetTLSNodeDNSNames returns the DNS names covered by the node certificate of a Pod: localhost, the hostname of the Pod
+// and its DNS name in the headless service of the worker Pods, and the head service for the head Pod.
+func GetTLSNodeDNSNames(instance rayv1alpha1.RayCluster, pod v1.Pod) []string {
+	dnsNames := []string{"localhost"}
+	hostname := pod.Spec.Hostname
+	if hostname == "" {
+		hostname = pod.Name
+	}
+	dnsNames = append(dnsNames, hostname)
+	if pod.Spec.Hostname != "" && pod.Spec.Subdomain != "" {
+		dnsNames = append(dnsNames, fmt.Sprintf("%s.%s.%s.svc.%s", pod.Spec.Hostname, pod.Spec.Subdomain, pod.Namespace, utils.GetClusterDomainName()))
+	}
+	if pod.Labels[RayNodeTypeLabelKey] == string(rayv1alpha1.HeadNode) {
+		if headSvcName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, instance.Spec, instance.Name); err == nil {
+			dnsNames = append(dnsNames, headSvcName, utils.GenerateFQDNServiceName(instance, pod.Namespace))
+		}
+	}
+	return dnsNames
+}
+
+// ParseTLSCertificates parses all the PEM encoded certificates in data.
+func ParseTLSCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// BuildTLSCASecret builds the Secret holding the CA generated by KubeRay for a RayCluster.
+// The CA bundle contains the current CA certificate and, after a rotation, the previous one until it expires.
+func BuildTLSCASecret(instance rayv1alpha1.RayCluster, certPEM []byte, keyPEM []byte, bundlePEM []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetTLSCASecretName(instance),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                instance.Name,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			TLSCACertKey:   certPEM,
+			TLSCAKeyKey:    keyPEM,
+			TLSCABundleKey: bundlePEM,
+		},
+	}
+}
+
+// BuildTLSNodeSecret builds the Secret holding the node certificate of a Pod, named by its RayTLSNodeSecretAnnotationKey
+// annotation. The Secret only holds the CA bundle besides the node certificate and key, never the CA key.
+func BuildTLSNodeSecret(pod v1.Pod, certPEM []byte, keyPEM []byte, bundlePEM []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Annotations[RayTLSNodeSecretAnnotationKey],
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                pod.Labels[RayClusterLabelKey],
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			TLSNodeCertKey: certPEM,
+			TLSNodeKeyKey:  keyPEM,
+			TLSCABundleKey: bundlePEM,
+		},
+	}
+}
+
+// BuildTLSAutoscalerSecret builds the Secret holding the certificate of the Ray autoscaler Deployment of a RayCluster.
+// Like the node certificate Secrets, it never holds the CA key.
+func BuildTLSAutoscalerSecret(instance rayv1alpha1.RayCluster, certPEM []byte, keyPEM []byte, bundlePEM []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateAutoscalerTLSSecretName(instance.Name),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				RayClusterLabelKey:                instance.Name,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesCreatedByLabelKey:       ComponentName,
+			},
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			TLSNodeCertKey: certPEM,
+			TLSNodeKeyKey:  keyPEM,
+			TLSCABundleKey: bundlePEM,
+		},
+	}
+}
+
+// addTLSVolumes mounts the node certificate Secret of the Pod and prepends an init container waiting for KubeRay to issue
+// the certificate. Every container that talks to Ray gets the node certificate and the CA bundle.
+func addTLSVolumes(pod *v1.Pod) {
+	rayContainer := &pod.Spec.Containers[RayContainerIndex]
+	if !checkIfVolumeExists(pod, RayTLSVolumeName) {
+		// The annotations may be shared with the RayCluster spec, so they are copied before naming the Secret of this Pod.
+		annotations := make(map[string]string, len(pod.Annotations)+1)
+		for key, value := range pod.Annotations {
+			annotations[key] = value
+		}
+		secretName := utils.GenerateTLSNodeSecretName(pod.Labels[RayClusterLabelKey]) + DashSymbol + utilrand.String(5)
+		annotations[RayTLSNodeSecretAnnotationKey] = secretName
+		pod.Annotations = annotations
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: RayTLSVolumeName,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
+				SecretName: secretName,
+				Optional:   pointer.Bool(true),
+			}},
+		})
+	}
+
+	tlsVolumeMounts := []v1.VolumeMount{
+		{Name: RayTLSVolumeName, MountPath: RayTLSVolumeMountPath, ReadOnly: true},
+	}
+	for index := range pod.Spec.InitContainers {
+		addTLSVolumeMounts(&pod.Spec.InitContainers[index], tlsVolumeMounts)
+		setTLSEnvVars(&pod.Spec.InitContainers[index])
+	}
+	for index := range pod.Spec.Containers {
+		if index == RayContainerIndex || pod.Spec.Containers[index].Name == AutoscalerContainerName {
+			addTLSVolumeMounts(&pod.Spec.Containers[index], tlsVolumeMounts)
+		}
+		// The env vars of the Ray container are set in setContainerEnvVars.
+		if pod.Spec.Containers[index].Name == AutoscalerContainerName {
+			setTLSEnvVars(&pod.Spec.Containers[index])
+		}
+	}
+
+	tlsInitContainer := v1.Container{
+		Name:            RayTLSInitContainerName,
+		Image:           rayContainer.Image,
+		ImagePullPolicy: rayContainer.ImagePullPolicy,
+		Command:         []string{"/bin/bash", "-lc", "--"},
+		Args:            []string{rayTLSInitScript},
+		VolumeMounts:    tlsVolumeMounts,
+		SecurityContext: rayContainer.SecurityContext.DeepCopy(),
+		// If users specify ResourceQuota for the namespace, the init container need to specify resource explicitly.
+		Resources: *rayContainer.Resources.DeepCopy(),
+	}
+	// The node certificate must be available before any other init container talks to Ray.
+	pod.Spec.InitContainers = append([]v1.Container{tlsInitContainer}, pod.Spec.InitContainers...)
+}
+
+func addTLSVolumeMounts(container *v1.Container, volumeMounts []v1.VolumeMount) {
+	for _, volumeMount := range volumeMounts {
+		exists := false
+		for _, existing := range container.VolumeMounts {
+			if existing.Name == volumeMount.Name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		}
+	}
+}
+
+// setTLSEnvVars points Ray at the node certificate and the CA bundle. Env vars set by users are kept.
+func setTLSEnvVars(container *v1.Container) {
+	tlsEnvVars := []v1.EnvVar{
+		{Name: RAY_USE_TLS, Value: "1"},
+		{Name: RAY_TLS_SERVER_CERT, Value: RayTLSVolumeMountPath + "/" + TLSNodeCertKey},
+		{Name: RAY_TLS_SERVER_KEY, Value: RayTLSVolumeMountPath + "/" + TLSNodeKeyKey},
+		{Name: RAY_TLS_CA_CERT, Value: RayTLSVolumeMountPath + "/" + TLSCABundleKey},
+	}
+	for _, env := range tlsEnvVars {
+		if !envVarExists(env.Name, container.Env) {
+			container.Env = append(container.Env, env)
+		}
+	}
+}
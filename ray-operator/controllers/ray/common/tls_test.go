@@ -0,0 +1,101 @@
+package common
+
+import (
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateTLSCA(t *testing.T) {
+	notBefore := time.Now().Truncate(time.Second)
+	certPEM, keyPEM, err := GenerateTLSCA("raycluster-sample", notBefore, time.Hour)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, keyPEM)
+
+	certs, err := ParseTLSCertificates(certPEM)
+	assert.Nil(t, err)
+	assert.Len(t, certs, 1)
+	assert.True(t, certs[0].IsCA)
+	assert.Equal(t, "raycluster-sample", certs[0].Subject.CommonName)
+	assert.True(t, certs[0].NotAfter.Equal(notBefore.Add(time.Hour)))
+
+	// The CA is self-signed.
+	roots := x509.NewCertPool()
+	roots.AddCert(certs[0])
+	_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, CurrentTime: notBefore.Add(time.Minute)})
+	assert.Nil(t, err)
+
+	// A bundle holds several certificates.
+	otherCertPEM, _, err := GenerateTLSCA("raycluster-sample", notBefore, time.Hour)
+	assert.Nil(t, err)
+	certs, err = ParseTLSCertificates(append(otherCertPEM, certPEM...))
+	assert.Nil(t, err)
+	assert.Len(t, certs, 2)
+
+	_, err = ParseTLSCertificates(keyPEM)
+	assert.NotNil(t, err)
+}
+
+func TestIssueTLSNodeCert(t *testing.T) {
+	notBefore := time.Now().Truncate(time.Second)
+	caCertPEM, caKeyPEM, err := GenerateTLSCA("raycluster-sample", notBefore, 24*time.Hour)
+	assert.Nil(t, err)
+	caCerts, err := ParseTLSCertificates(caCertPEM)
+	assert.Nil(t, err)
+
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.ParseIP("10.0.0.1")}
+	certPEM, keyPEM, err := IssueTLSNodeCert(caCertPEM, caKeyPEM, "raycluster-sample-head-abcde", []string{"localhost"}, ips, notBefore, time.Hour)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, keyPEM)
+	certs, err := ParseTLSCertificates(certPEM)
+	assert.Nil(t, err)
+	assert.False(t, certs[0].IsCA)
+	assert.True(t, certs[0].NotAfter.Equal(notBefore.Add(time.Hour)))
+
+	// The node certificate is signed by the CA and valid for the Pod IP, both as a server and as a client.
+	roots := x509.NewCertPool()
+	roots.AddCert(caCerts[0])
+	for _, usage := range []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth} {
+		_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, DNSName: "10.0.0.1", CurrentTime: notBefore.Add(time.Minute), KeyUsages: []x509.ExtKeyUsage{usage}})
+		assert.Nil(t, err)
+	}
+
+	// The node certificate needs a renewal once two thirds of its lifetime have elapsed.
+	assert.False(t, TLSNodeCertNeedsRenewal(certs[0], caCerts[0], notBefore.Add(30*time.Minute)))
+	assert.True(t, TLSNodeCertNeedsRenewal(certs[0], caCerts[0], notBefore.Add(40*time.Minute)))
+
+	// The node certificate never outlives the CA.
+	certPEM, _, err = IssueTLSNodeCert(caCertPEM, caKeyPEM, "raycluster-sample-head-abcde", nil, ips, notBefore, 48*time.Hour)
+	assert.Nil(t, err)
+	certs, err = ParseTLSCertificates(certPEM)
+	assert.Nil(t, err)
+	assert.True(t, certs[0].NotAfter.Equal(caCerts[0].NotAfter))
+
+	// A node certificate issued by another CA needs a renewal.
+	otherCACertPEM, _, err := GenerateTLSCA("raycluster-sample", notBefore, 24*time.Hour)
+	assert.Nil(t, err)
+	otherCACerts, err := ParseTLSCertificates(otherCACertPEM)
+	assert.Nil(t, err)
+	assert.True(t, TLSNodeCertNeedsRenewal(certs[0], otherCACerts[0], notBefore.Add(time.Minute)))
+
+	// The Secret of the node certificate is only read once the certificate may need a renewal.
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+	assert.True(t, IsTLSNodeCertRenewalDue(pod, caCerts[0], time.Hour, notBefore.Add(time.Minute)))
+	pod.Annotations[RayTLSNodeCertIssuedAnnotationKey] = notBefore.Add(time.Minute).UTC().Format(time.RFC3339)
+	assert.False(t, IsTLSNodeCertRenewalDue(pod, caCerts[0], time.Hour, notBefore.Add(30*time.Minute)))
+	assert.True(t, IsTLSNodeCertRenewalDue(pod, caCerts[0], time.Hour, notBefore.Add(50*time.Minute)))
+	laterCACertPEM, _, err := GenerateTLSCA("raycluster-sample", notBefore.Add(2*time.Minute), 24*time.Hour)
+	assert.Nil(t, err)
+	laterCACerts, err := ParseTLSCertificates(laterCACertPEM)
+	assert.Nil(t, err)
+	assert.True(t, IsTLSNodeCertRenewalDue(pod, laterCACerts[0], time.Hour, notBefore.Add(3*time.Minute)))
+
+	// The CA key must be a PEM encoded private key.
+	_, _, err = IssueTLSNodeCert(caCertPEM, caCertPEM, "raycluster-sample-head-abcde", nil, ips, notBefore, time.Hour)
+	assert.NotNil(t, err)
+}
@@ -3,10 +3,13 @@ package ray
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,18 +22,24 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	_ "k8s.io/api/apps/v1beta1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -40,17 +49,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"k8s.io/utils/pointer"
 )
 
 var (
 	DefaultRequeueDuration = 2 * time.Second
 	ForcedClusterUpgrade   bool
 	EnableBatchScheduler   bool
+	// EnablePreemption makes RayClusters with spec.preemption preempt lower-priority RayClusters once their Pods have
+	// been unschedulable for PreemptionPendingThreshold.
+	EnablePreemption           bool
+	PreemptionPendingThreshold = common.DefaultPreemptionPendingThreshold
+	// PreemptionQuotaGroups maps a namespace to its quota group. The RayClusters of the namespaces of a quota group may
+	// preempt each other. Otherwise, a RayCluster only preempts the RayClusters of its namespace.
+	PreemptionQuotaGroups map[string]string
 
 	// Definition of a index field for pod name
 	podUIDIndexField = "metadata.uid"
 )
 
+// maxWorkerPodCreateAttempts is the number of names tried to create a worker Pod whose name is generated by KubeRay.
+const maxWorkerPodCreateAttempts = 3
+
 // getDiscoveryClient returns a discovery client for the current reconciler
 func getDiscoveryClient(config *rest.Config) (*discovery.DiscoveryClient, error) {
 	return discovery.NewDiscoveryClientForConfig(config)
@@ -92,6 +113,33 @@ func getClusterType(logger logr.Logger) bool {
 	}
 }
 
+// getPrometheusMonitorKinds returns the kinds of monitors of the Prometheus Operator served by the Kubernetes API server.
+func getPrometheusMonitorKinds(logger logr.Logger) map[string]bool {
+	kinds := map[string]bool{}
+	config, err := ctrl.GetConfig()
+	if err != nil || config == nil {
+		logger.Info("Cannot retrieve config, assuming the Prometheus Operator CRDs are not installed")
+		return kinds
+	}
+	dclient, err := getDiscoveryClient(config)
+	if err != nil || dclient == nil {
+		logger.Info("Cannot retrieve a DiscoveryClient, assuming the Prometheus Operator CRDs are not installed")
+		return kinds
+	}
+	resources, err := dclient.ServerResourcesForGroupVersion(common.PodMonitorGVK.GroupVersion().String())
+	if err != nil {
+		logger.Info("The Prometheus Operator CRDs are not installed. RayCluster monitoring is disabled.")
+		return kinds
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == common.PodMonitorGVK.Kind || resource.Kind == common.ServiceMonitorGVK.Kind {
+			kinds[resource.Kind] = true
+		}
+	}
+	logger.Info("Detected Prometheus Operator CRDs", "kinds", kinds)
+	return kinds
+}
+
 // NewReconciler returns a new reconcile.Reconciler
 func NewReconciler(mgr manager.Manager) *RayClusterReconciler {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podUIDIndexField, func(rawObj client.Object) []string {
@@ -103,14 +151,18 @@ func NewReconciler(mgr manager.Manager) *RayClusterReconciler {
 	log := ctrl.Log.WithName("controllers").WithName("RayCluster")
 	log.Info("Starting Reconciler")
 	isOpenShift := getClusterType(log)
+	monitorKinds := getPrometheusMonitorKinds(log)
 
 	return &RayClusterReconciler{
-		Client:            mgr.GetClient(),
+		Client:            utils.NewTracingClient(mgr.GetClient()),
+		APIReader:         mgr.GetAPIReader(),
 		Scheme:            mgr.GetScheme(),
 		Log:               log,
 		Recorder:          mgr.GetEventRecorderFor("raycluster-controller"),
 		BatchSchedulerMgr: batchscheduler.NewSchedulerManager(mgr.GetConfig()),
 		IsOpenShift:       isOpenShift,
+		KueueEnabled:      isKueueInstalled(log),
+		MonitorKinds:      monitorKinds,
 	}
 }
 
@@ -119,11 +171,23 @@ var _ reconcile.Reconciler = &RayClusterReconciler{}
 // RayClusterReconciler reconciles a RayCluster object
 type RayClusterReconciler struct {
 	client.Client
+	// APIReader reads the Secrets directly from the API server, so that the manager does not cache every Secret.
+	APIReader         client.Reader
 	Log               logr.Logger
 	Scheme            *runtime.Scheme
 	Recorder          record.EventRecorder
 	BatchSchedulerMgr *batchscheduler.SchedulerManager
 	IsOpenShift       bool
+	// KueueEnabled is whether the Kueue CRDs were found when the operator started.
+	KueueEnabled bool
+	// MonitorKinds is the set of Prometheus Operator monitor kinds found when the operator started.
+	MonitorKinds map[string]bool
+	// idleWorkers maps the NamespacedName of every RayCluster autoscaled by the operator to the time at which each of
+	// its idle worker Pods was first seen idle.
+	idleWorkers sync.Map
+	// preemptions maps the NamespacedName of every RayCluster that preempted other RayClusters to the time of its last
+	// preemption, so that the victims have time to release their resources before the next one.
+	preemptions sync.Map
 }
 
 // Reconcile reads that state of the cluster for a RayCluster object and makes changes based on it
@@ -132,17 +196,24 @@ type RayClusterReconciler struct {
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ray.io,resources=rayworkergroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayworkergroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors;servicemonitors,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;create;update;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
 
@@ -161,6 +232,8 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	// No match found
 	if errors.IsNotFound(err) {
 		r.Log.Info("Read request instance not found error!", "name", request.NamespacedName)
+		common.DeleteRayClusterMetrics(request.Namespace, request.Name)
+		r.idleWorkers.Delete(request.NamespacedName)
 	} else {
 		r.Log.Error(err, "Read request instance error!")
 	}
@@ -174,6 +247,7 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 
 	_ = r.Log.WithValues("raycluster", request.NamespacedName)
 	r.Log.Info("reconciling RayCluster", "cluster name", request.Name)
+	common.ObserveRayClusterState(instance)
 
 	// The `enableGCSFTRedisCleanup` is a feature flag introduced in KubeRay v1.0.0. It determines whether
 	// the Redis cleanup job should be activated. Users can disable the feature by setting the environment
@@ -181,7 +255,77 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 	// manually after the RayCluster CR deletion.
 	enableGCSFTRedisCleanup := strings.ToLower(os.Getenv(common.ENABLE_GCS_FT_REDIS_CLEANUP)) != "false"
 
-	if enableGCSFTRedisCleanup && common.IsGCSFaultToleranceEnabled(*instance) {
+	if instance.DeletionTimestamp.IsZero() {
+		if err := common.ValidateGcsFaultToleranceOptions(*instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidGcsFaultToleranceOptions", err.Error())
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if err := batchscheduler.ValidateBatchScheduling(instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidBatchScheduling", err.Error())
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if err := common.ValidateAutoscalerOptions(*instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidAutoscalerOptions", err.Error())
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if err := common.ValidateRayWorkerGroups(*instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidRayWorkerGroups", err.Error())
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if err := common.ValidatePodDisruptionBudgets(*instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidPodDisruptionBudgets", err.Error())
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if err := common.ValidateIdlePolicy(*instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidIdlePolicy", err.Error())
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if r.KueueEnabled && common.IsKueueManagedRayCluster(instance) {
+			if err := common.ValidateKueuePodSets(instance.Spec); err != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidKueuePodSets", err.Error())
+				if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+					r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+				}
+				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			}
+		}
+		if instance.Spec.GcsFaultToleranceOptions == nil && common.IsGCSFaultToleranceEnabled(*instance) {
+			r.Log.Info(fmt.Sprintf("The annotation %s is deprecated. Use spec.gcsFaultToleranceOptions instead.", common.RayFTEnabledAnnotationKey),
+				"cluster name", request.Name)
+		}
+	}
+
+	if enableGCSFTRedisCleanup && !common.IsGCSFaultToleranceRedisCleanupEnabled(*instance) &&
+		controllerutil.ContainsFinalizer(instance, common.GCSFaultToleranceRedisCleanupFinalizer) {
+		// The Redis cleanup policy was set to Retain, or GCS fault tolerance was disabled, after the finalizer was added.
+		r.Log.Info("Redis cleanup is disabled. Removing the finalizer.", "finalizer", common.GCSFaultToleranceRedisCleanupFinalizer)
+		controllerutil.RemoveFinalizer(instance, common.GCSFaultToleranceRedisCleanupFinalizer)
+		if err := r.Update(ctx, instance); err != nil {
+			r.Log.Error(err, fmt.Sprintf("Failed to remove the finalizer %s from the RayCluster.", common.GCSFaultToleranceRedisCleanupFinalizer))
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+	}
+
+	if enableGCSFTRedisCleanup && common.IsGCSFaultToleranceRedisCleanupEnabled(*instance) {
 		if instance.DeletionTimestamp.IsZero() {
 			if !controllerutil.ContainsFinalizer(instance, common.GCSFaultToleranceRedisCleanupFinalizer) {
 				r.Log.Info(
@@ -325,6 +469,19 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		}
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
+	// The autoscaler Deployment needs the TLS CA to issue its certificate.
+	if err := r.reconcileTLSCASecret(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileAutoscalerDeployment(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
 	if err := r.reconcileIngress(ctx, instance); err != nil {
 		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
 			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
@@ -337,7 +494,83 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		}
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
-	if err := r.reconcilePods(ctx, instance); err != nil {
+	if err := r.reconcileWorkerHeadlessService(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileNetworkPolicy(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcilePodDisruptionBudgets(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileMonitor(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileRedisPasswordSecret(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileDashboardAuthSecret(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileIdlePolicy(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileRayWorkerGroups(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileOperatorAutoscaling(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcilePreemption(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	// Kueue admits a queued RayCluster by letting KubeRay create its Pods.
+	podInstance := instance
+	if r.KueueEnabled && common.IsKueueManagedRayCluster(instance) {
+		admittedInstance, err := r.reconcileKueueWorkload(ctx, instance)
+		if err != nil {
+			if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+				r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if admittedInstance == nil {
+			r.Log.Info("Waiting for Kueue to admit the RayCluster", "cluster name", request.Name)
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+		}
+		podInstance = admittedInstance
+	}
+	if err := r.reconcilePods(ctx, podInstance); err != nil {
 		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
 			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
 		}
@@ -347,6 +580,18 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		r.Recorder.Event(instance, corev1.EventTypeWarning, string(rayv1alpha1.PodReconciliationError), err.Error())
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
+	if err := r.reconcileTLSNodeSecrets(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if err := r.reconcileRedisPasswordPods(ctx, instance); err != nil {
+		if updateErr := r.updateClusterState(ctx, instance, rayv1alpha1.Failed); updateErr != nil {
+			r.Log.Error(updateErr, "RayCluster update state error", "cluster name", request.Name)
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
 
 	// Calculate the new status for the RayCluster. Note that the function will deep copy `instance` instead of mutating it.
 	newInstance, err := r.calculateStatus(ctx, instance)
@@ -362,6 +607,7 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 			r.Log.Info("Got error when updating status", "cluster name", request.Name, "error", err, "RayCluster", newInstance)
 			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 		}
+		common.ObserveRayClusterState(newInstance)
 	}
 
 	// Unconditionally requeue after the number of seconds specified in the
@@ -372,8 +618,19 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		r.Log.Info(fmt.Sprintf("Environment variable %s is not set, using default value of %d seconds", common.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, common.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS), "cluster name", request.Name)
 		requeueAfterSeconds = common.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS
 	}
-	r.Log.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfterSeconds)
-	return ctrl.Result{RequeueAfter: time.Duration(requeueAfterSeconds) * time.Second}, nil
+	requeueAfter := time.Duration(requeueAfterSeconds) * time.Second
+	if common.IsOperatorAutoscalingEnabled(*instance) && requeueAfter > common.OperatorAutoscalingPeriod {
+		// The operator autoscaling loop runs once per reconciliation.
+		requeueAfter = common.OperatorAutoscalingPeriod
+	}
+	if common.IsIdlePolicyEnabled(*instance) && requeueAfter > common.IdlePolicyCheckPeriod {
+		requeueAfter = common.IdlePolicyCheckPeriod
+	}
+	if ((EnablePreemption && instance.Spec.Preemption != nil) || common.IsRayClusterPreempted(*instance)) && requeueAfter > common.PreemptionCheckPeriod {
+		requeueAfter = common.PreemptionCheckPeriod
+	}
+	r.Log.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfter.Seconds())
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // Checks whether the old and new RayClusterStatus are inconsistent by comparing different fields. If the only
@@ -404,6 +661,29 @@ func (r *RayClusterReconciler) inconsistentRayClusterStatus(oldStatus rayv1alpha
 			oldStatus.Endpoints, newStatus.Endpoints, oldStatus.Head, newStatus.Head))
 		return true
 	}
+	if (oldStatus.TLS == nil) != (newStatus.TLS == nil) || (oldStatus.TLS != nil && (oldStatus.TLS.CASecretName != newStatus.TLS.CASecretName ||
+		!oldStatus.TLS.CAExpirationTime.Equal(newStatus.TLS.CAExpirationTime))) {
+		r.Log.Info("inconsistentRayClusterStatus", "detect inconsistency", fmt.Sprintf(
+			"old TLS: %v, new TLS: %v", oldStatus.TLS, newStatus.TLS))
+		return true
+	}
+	if (oldStatus.Hibernation == nil) != (newStatus.Hibernation == nil) || (oldStatus.Hibernation != nil &&
+		(!oldStatus.Hibernation.LastActivityTime.Equal(newStatus.Hibernation.LastActivityTime) ||
+			!oldStatus.Hibernation.HibernationTime.Equal(newStatus.Hibernation.HibernationTime))) {
+		r.Log.Info("inconsistentRayClusterStatus", "detect inconsistency", fmt.Sprintf(
+			"old Hibernation: %v, new Hibernation: %v", oldStatus.Hibernation, newStatus.Hibernation))
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.Conditions, newStatus.Conditions) {
+		r.Log.Info("inconsistentRayClusterStatus", "detect inconsistency", fmt.Sprintf(
+			"old Conditions: %v, new Conditions: %v", oldStatus.Conditions, newStatus.Conditions))
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.RedisPassword, newStatus.RedisPassword) {
+		r.Log.Info("inconsistentRayClusterStatus", "detect inconsistency", fmt.Sprintf(
+			"old RedisPassword: %v, new RedisPassword: %v", oldStatus.RedisPassword, newStatus.RedisPassword))
+		return true
+	}
 	return false
 }
 
@@ -538,6 +818,35 @@ func (r *RayClusterReconciler) reconcileHeadService(ctx context.Context, instanc
 	return nil
 }
 
+// reconcileWorkerHeadlessService creates the headless service of the worker Pods if it is enabled, and deletes it otherwise.
+func (r *RayClusterReconciler) reconcileWorkerHeadlessService(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	service := &corev1.Service{}
+	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: utils.GenerateWorkerHeadlessServiceName(instance.Name)}
+	err := r.Get(ctx, namespacedName, service)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if !common.IsWorkerHeadlessServiceEnabled(*instance) {
+		if exists && metav1.IsControlledBy(service, instance) {
+			if err := r.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			r.Log.Info("Worker headless service deleted", "service name", service.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted service %s", service.Name)
+		}
+		return nil
+	}
+	if exists {
+		if !metav1.IsControlledBy(service, instance) {
+			return fmt.Errorf("the service %s/%s already exists and is not controlled by the RayCluster", service.Namespace, service.Name)
+		}
+		return nil
+	}
+	return r.createService(ctx, common.BuildHeadlessServiceForWorkerPods(*instance), instance)
+}
+
 func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
 	// check if all the pods exist
 	headPods := corev1.PodList{}
@@ -556,7 +865,20 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 	}
 
 	// Reconcile head Pod
-	if len(headPods.Items) == 1 {
+	if common.IsHeadStopped(*instance) {
+		// The RayCluster is hibernated and its idle policy stops the head Pod.
+		for i := range headPods.Items {
+			headPod := headPods.Items[i]
+			if headPod.DeletionTimestamp != nil {
+				continue
+			}
+			if err := r.Delete(ctx, &headPod); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted head Pod %s of the hibernated RayCluster", headPod.Name)
+		}
+		headPods.Items = nil
+	} else if len(headPods.Items) == 1 {
 		headPod := headPods.Items[0]
 		r.Log.Info("reconcilePods", "Found 1 head Pod", headPod.Name, "Pod status", headPod.Status.Phase,
 			"Pod restart policy", headPod.Spec.RestartPolicy,
@@ -955,54 +1277,38 @@ func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1
 }
 
 func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1alpha1.RayCluster, worker rayv1alpha1.WorkerGroupSpec) error {
-	// build the pod then create it
-	pod := r.buildWorkerPod(instance, worker)
-	podIdentifier := types.NamespacedName{
-		Name:      pod.Name,
-		Namespace: pod.Namespace,
-	}
-	if EnableBatchScheduler {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
-			scheduler.AddMetadataToPod(&instance, &pod)
-		} else {
-			return err
+	// The name of a worker Pod with a hostname is generated by KubeRay and may already be taken, even by a Pod that is not
+	// terminating. The Pod is built again with a new name, so that the replica is not lost.
+	for attempt := 1; ; attempt++ {
+		// build the pod then create it
+		pod := r.buildWorkerPod(instance, worker)
+		if EnableBatchScheduler {
+			if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
+				scheduler.AddMetadataToPod(&instance, &pod)
+			} else {
+				return err
+			}
 		}
-	}
 
-	replica := pod
-	if err := r.Create(ctx, &replica); err != nil {
-		if errors.IsAlreadyExists(err) {
-			fetchedPod := corev1.Pod{}
-			// the pod might be in terminating state, we need to check
-			if errPod := r.Get(ctx, podIdentifier, &fetchedPod); errPod == nil {
-				if fetchedPod.DeletionTimestamp != nil {
-					r.Log.Error(errPod, "create pod error!", "pod is in a terminating state, we will wait until it is cleaned up", podIdentifier)
-					return err
-				}
+		replica := pod
+		if err := r.Create(ctx, &replica); err != nil {
+			if errors.IsAlreadyExists(err) && attempt < maxWorkerPodCreateAttempts {
+				r.Log.Info("Creating pod", "Pod already exists, retry with another name", pod.Name)
+				continue
 			}
-			r.Log.Info("Creating pod", "Pod already exists", pod.Name)
-		} else {
 			r.Log.Error(fmt.Errorf("createWorkerPod error"), "error creating pod", "pod", pod, "err = ", err)
 			return err
 		}
+		r.Log.Info("Created pod", "Pod ", replica.Name)
+		r.Recorder.Eventf(&instance, corev1.EventTypeNormal, "Created", "Created worker pod %s", replica.Name)
+		return nil
 	}
-	r.Log.Info("Created pod", "Pod ", pod.GenerateName)
-	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, "Created", "Created worker pod %s", pod.Name)
-	return nil
 }
 
 // Build head instance pod(s).
 func (r *RayClusterReconciler) buildHeadPod(instance rayv1alpha1.RayCluster) corev1.Pod {
-	podName := strings.ToLower(instance.Name + common.DashSymbol + string(rayv1alpha1.HeadNode) + common.DashSymbol)
-	podName = utils.CheckName(podName)                                       // making sure the name is valid
-	fqdnRayIP := utils.GenerateFQDNServiceName(instance, instance.Namespace) // Fully Qualified Domain Name
-	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
-	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
-	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
-	podConf := common.DefaultHeadPodTemplate(instance, instance.Spec.HeadGroupSpec, podName, headPort)
-	r.Log.Info("head pod labels", "labels", podConf.Labels)
-	creatorName := getCreator(instance)
-	pod := common.BuildPod(podConf, rayv1alpha1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, headPort, autoscalingEnabled, creatorName, fqdnRayIP)
+	pod := common.BuildHeadPod(instance)
+	r.Log.Info("head pod labels", "labels", pod.Labels)
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		r.Log.Error(err, "Failed to set controller reference for raycluster pod")
@@ -1011,31 +1317,9 @@ func (r *RayClusterReconciler) buildHeadPod(instance rayv1alpha1.RayCluster) cor
 	return pod
 }
 
-func getCreator(instance rayv1alpha1.RayCluster) string {
-	if instance.Labels == nil {
-		return ""
-	}
-	creatorName, exist := instance.Labels[common.KubernetesCreatedByLabelKey]
-
-	if !exist {
-		return ""
-	}
-
-	return creatorName
-}
-
 // Build worker instance pods.
 func (r *RayClusterReconciler) buildWorkerPod(instance rayv1alpha1.RayCluster, worker rayv1alpha1.WorkerGroupSpec) corev1.Pod {
-	podName := strings.ToLower(instance.Name + common.DashSymbol + string(rayv1alpha1.WorkerNode) + common.DashSymbol + worker.GroupName + common.DashSymbol)
-	podName = utils.CheckName(podName)                                       // making sure the name is valid
-	fqdnRayIP := utils.GenerateFQDNServiceName(instance, instance.Namespace) // Fully Qualified Domain Name
-
-	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
-	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
-	autoscalingEnabled := instance.Spec.EnableInTreeAutoscaling
-	podTemplateSpec := common.DefaultWorkerPodTemplate(instance, worker, podName, fqdnRayIP, headPort)
-	creatorName := getCreator(instance)
-	pod := common.BuildPod(podTemplateSpec, rayv1alpha1.WorkerNode, worker.RayStartParams, headPort, autoscalingEnabled, creatorName, fqdnRayIP)
+	pod := common.BuildWorkerPod(instance, worker)
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		r.Log.Error(err, "Failed to set controller reference for raycluster pod")
@@ -1055,8 +1339,10 @@ func (r *RayClusterReconciler) buildRedisCleanupJob(instance rayv1alpha1.RayClus
 			"\"from ray._private.gcs_utils import cleanup_redis_storage; " +
 			"import os; " +
 			"import sys; " +
-			"host, port = os.getenv('RAY_REDIS_ADDRESS').rsplit(':'); " +
-			"sys.exit(1) if not cleanup_redis_storage(host=host, port=int(port), password=os.getenv('REDIS_PASSWORD'), use_ssl=False, storage_namespace=os.getenv('RAY_external_storage_namespace')) else None\"",
+			"address = os.getenv('RAY_REDIS_ADDRESS'); " +
+			"use_ssl = address.startswith('rediss://'); " +
+			"host, port = address.split('://')[-1].rsplit(':', 1); " +
+			"sys.exit(1) if not cleanup_redis_storage(host=host, port=int(port), password=os.getenv('REDIS_PASSWORD'), use_ssl=use_ssl, storage_namespace=os.getenv('RAY_external_storage_namespace')) else None\"",
 	}
 	// Disable liveness and readiness probes because the Job will not launch processes like Raylet and GCS.
 	pod.Spec.Containers[common.RayContainerIndex].LivenessProbe = nil
@@ -1096,7 +1382,23 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 			predicate.AnnotationChangedPredicate{},
 		))).
 		Owns(&corev1.Pod{}).
-		Owns(&corev1.Service{})
+		Owns(&corev1.Service{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&rayv1alpha1.RayWorkerGroup{}).
+		Owns(&appsv1.Deployment{})
+	if r.KueueEnabled {
+		workload := &unstructured.Unstructured{}
+		workload.SetGroupVersionKind(common.WorkloadGVK)
+		b = b.Owns(workload)
+	}
+	for _, gvk := range []schema.GroupVersionKind{common.PodMonitorGVK, common.ServiceMonitorGVK} {
+		if r.MonitorKinds[gvk.Kind] {
+			monitor := &unstructured.Unstructured{}
+			monitor.SetGroupVersionKind(gvk)
+			b = b.Owns(monitor)
+		}
+	}
 
 	if EnableBatchScheduler {
 		b = batchscheduler.ConfigureReconciler(b)
@@ -1104,7 +1406,7 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 
 	return b.
 		WithOptions(controller.Options{MaxConcurrentReconciles: reconcileConcurrency}).
-		Complete(r)
+		Complete(utils.NewTracingReconciler("RayCluster", r))
 }
 
 func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *rayv1alpha1.RayCluster) (*rayv1alpha1.RayCluster, error) {
@@ -1133,9 +1435,14 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	// only in invalid status that we update the status to unhealthy.
 	if !isValid {
 		newInstance.Status.State = rayv1alpha1.Unhealthy
+	} else if common.IsRayClusterHibernated(*newInstance) {
+		newInstance.Status.State = rayv1alpha1.Hibernated
 	} else {
 		if utils.CheckAllPodsRunning(runtimePods) {
 			newInstance.Status.State = rayv1alpha1.Ready
+		} else if newInstance.Status.State == rayv1alpha1.Hibernated {
+			// The RayCluster is waking up.
+			newInstance.Status.State = ""
 		}
 	}
 
@@ -1168,6 +1475,17 @@ func (r *RayClusterReconciler) getHeadPodIP(ctx context.Context, instance *rayv1
 	return runtimePods.Items[0].Status.PodIP, nil
 }
 
+// isHeadPodReady returns true if the head Pod of the RayCluster is running and its containers are ready, so that its
+// dashboard can be reached. The readiness gates of the head Pod, such as the Serve readiness gate, are ignored.
+func (r *RayClusterReconciler) isHeadPodReady(ctx context.Context, instance *rayv1alpha1.RayCluster) (bool, error) {
+	headPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayv1alpha1.HeadNode)}
+	if err := r.List(ctx, &headPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return false, err
+	}
+	return len(headPods.Items) == 1 && utils.IsRunningAndContainersReady(&headPods.Items[0]), nil
+}
+
 func (r *RayClusterReconciler) getHeadServiceIP(ctx context.Context, instance *rayv1alpha1.RayCluster) (string, error) {
 	runtimeServices := corev1.ServiceList{}
 	filterLabels := client.MatchingLabels(common.HeadServiceLabels(*instance))
@@ -1242,7 +1560,7 @@ func (r *RayClusterReconciler) updateHeadInfo(ctx context.Context, instance *ray
 }
 
 func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
-	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling || common.IsOperatorAutoscalingEnabled(*instance) {
 		return nil
 	}
 
@@ -1297,7 +1615,7 @@ func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Con
 }
 
 func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
-	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling || common.IsOperatorAutoscalingEnabled(*instance) {
 		return nil
 	}
 
@@ -1338,7 +1656,7 @@ func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, inst
 }
 
 func (r *RayClusterReconciler) reconcileAutoscalerRoleBinding(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
-	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling {
+	if instance.Spec.EnableInTreeAutoscaling == nil || !*instance.Spec.EnableInTreeAutoscaling || common.IsOperatorAutoscalingEnabled(*instance) {
 		return nil
 	}
 
@@ -1378,13 +1696,1406 @@ func (r *RayClusterReconciler) reconcileAutoscalerRoleBinding(ctx context.Contex
 	return nil
 }
 
-func (r *RayClusterReconciler) updateClusterState(ctx context.Context, instance *rayv1alpha1.RayCluster, clusterState rayv1alpha1.ClusterState) error {
-	if instance.Status.State == clusterState {
+// reconcileAutoscalerDeployment creates, updates, or deletes the Deployment running the Ray autoscaler when
+// spec.autoscalerOptions.type is "Deployment".
+func (r *RayClusterReconciler) reconcileAutoscalerDeployment(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	deployment := &appsv1.Deployment{}
+	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: utils.GenerateAutoscalerDeploymentName(instance.Name)}
+	err := r.Get(ctx, namespacedName, deployment)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if !common.IsDeploymentAutoscalerEnabled(*instance) {
+		if exists && metav1.IsControlledBy(deployment, instance) {
+			if err := r.Delete(ctx, deployment); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			r.Log.Info("Autoscaler Deployment deleted because the autoscaler does not run as a Deployment", "Deployment", namespacedName.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted autoscaler Deployment %s", namespacedName.Name)
+		}
 		return nil
 	}
-	instance.Status.State = clusterState
-	r.Log.Info("updateClusterState", "Update CR Status.State", clusterState)
-	return r.Status().Update(ctx, instance)
+
+	tlsCertIssueTime := ""
+	if common.IsTLSEnabled(*instance) {
+		if tlsCertIssueTime, err = r.reconcileAutoscalerTLSSecret(ctx, instance); err != nil {
+			return err
+		}
+	}
+	desired, err := common.BuildAutoscalerDeployment(*instance, tlsCertIssueTime)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			r.Log.Error(err, "Autoscaler Deployment create error!", "Deployment", desired.Name)
+			return err
+		}
+		r.Log.Info("Autoscaler Deployment created successfully", "Deployment", desired.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created autoscaler Deployment %s", desired.Name)
+		return nil
+	}
+
+	if !metav1.IsControlledBy(deployment, instance) {
+		return fmt.Errorf("the Deployment %s/%s already exists and is not controlled by the RayCluster", deployment.Namespace, deployment.Name)
+	}
+	// The Pod template is compared through its hash, as the Kubernetes API server sets the default values of its fields.
+	templateHash := desired.Annotations[common.RayAutoscalerTemplateHashAnnotationKey]
+	if deployment.Annotations[common.RayAutoscalerTemplateHashAnnotationKey] == templateHash &&
+		reflect.DeepEqual(deployment.Spec.Replicas, desired.Spec.Replicas) {
+		return nil
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[common.RayAutoscalerTemplateHashAnnotationKey] = templateHash
+	deployment.Spec.Replicas = desired.Spec.Replicas
+	deployment.Spec.Strategy = desired.Spec.Strategy
+	deployment.Spec.Template = desired.Spec.Template
+	if err := r.Update(ctx, deployment); err != nil {
+		r.Log.Error(err, "Autoscaler Deployment update error!", "Deployment", deployment.Name)
+		return err
+	}
+	r.Log.Info("Autoscaler Deployment updated successfully", "Deployment", deployment.Name, "replicas", *deployment.Spec.Replicas)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Updated", "Updated autoscaler Deployment %s", deployment.Name)
+	return nil
+}
+
+// reconcileAutoscalerTLSSecret issues the certificate of the autoscaler Deployment, and issues a new one when it needs a
+// renewal like the node certificates. It returns when the current certificate was issued, which rolls out the Deployment.
+func (r *RayClusterReconciler) reconcileAutoscalerTLSSecret(ctx context.Context, instance *rayv1alpha1.RayCluster) (string, error) {
+	caSecret := &corev1.Secret{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: common.GetTLSCASecretName(*instance)}, caSecret); err != nil {
+		return "", err
+	}
+	caCerts, err := common.ParseTLSCertificates(caSecret.Data[common.TLSCACertKey])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s of the TLS CA Secret %s/%s: %w", common.TLSCACertKey, caSecret.Namespace, caSecret.Name, err)
+	}
+
+	now := time.Now()
+	secret := &corev1.Secret{}
+	secretName := utils.GenerateAutoscalerTLSSecretName(instance.Name)
+	err = r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: secretName}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	exists := err == nil
+	if exists {
+		if !metav1.IsControlledBy(secret, instance) {
+			return "", fmt.Errorf("the Secret %s/%s already exists and is not controlled by the RayCluster", secret.Namespace, secret.Name)
+		}
+		certs, err := common.ParseTLSCertificates(secret.Data[common.TLSNodeCertKey])
+		if err == nil && !common.TLSNodeCertNeedsRenewal(certs[0], caCerts[0], now) {
+			return certs[0].NotBefore.UTC().Format(time.RFC3339), nil
+		}
+	}
+
+	certPEM, keyPEM, err := common.IssueTLSNodeCert(caSecret.Data[common.TLSCACertKey], caSecret.Data[common.TLSCAKeyKey],
+		utils.GenerateAutoscalerDeploymentName(instance.Name), []string{"localhost"}, []net.IP{net.IPv4(127, 0, 0, 1)}, now,
+		common.GetTLSNodeCertDuration(instance.Spec.TLS))
+	if err != nil {
+		return "", fmt.Errorf("failed to issue the TLS certificate of the autoscaler: %w", err)
+	}
+	desired := common.BuildTLSAutoscalerSecret(*instance, certPEM, keyPEM, caSecret.Data[common.TLSCABundleKey])
+	if !exists {
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return "", err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			r.Log.Error(err, "Autoscaler TLS Secret create error!", "Secret", secretName)
+			return "", err
+		}
+		r.Log.Info("Autoscaler TLS Secret created successfully", "Secret", secretName)
+	} else {
+		secret.Data = desired.Data
+		if err := r.Update(ctx, secret); err != nil {
+			r.Log.Error(err, "Autoscaler TLS Secret update error!", "Secret", secretName)
+			return "", err
+		}
+		r.Log.Info("Autoscaler TLS Secret renewed", "Secret", secretName)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RenewedTLSCert", "Renewed the TLS certificate of the autoscaler in Secret %s", secretName)
+	}
+	return now.UTC().Format(time.RFC3339), nil
+}
+
+// reconcileRayWorkerGroups keeps a RayWorkerGroup for each worker group when spec.enableRayWorkerGroups is set, and
+// syncs the replicas in both directions. A RayWorkerGroup whose replicas differ from the last synced ones was scaled,
+// e.g. by the HorizontalPodAutoscaler, and its replicas are written to the worker group. Otherwise, the replicas of the
+// worker group, which may have been changed by the user or the Ray autoscaler, are written to the RayWorkerGroup.
+func (r *RayClusterReconciler) reconcileRayWorkerGroups(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	workerGroupList := rayv1alpha1.RayWorkerGroupList{}
+	if err := r.List(ctx, &workerGroupList, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	workerGroups := map[string]*rayv1alpha1.RayWorkerGroup{}
+	for i := range workerGroupList.Items {
+		if metav1.IsControlledBy(&workerGroupList.Items[i], instance) {
+			workerGroups[workerGroupList.Items[i].Spec.GroupName] = &workerGroupList.Items[i]
+		}
+	}
+
+	if !common.IsRayWorkerGroupsEnabled(*instance) {
+		for _, workerGroup := range workerGroups {
+			if err := r.deleteRayWorkerGroup(ctx, instance, workerGroup); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Write the replicas of the scaled RayWorkerGroups to the RayCluster first, so that the RayWorkerGroups are only
+	// marked as synced once the RayCluster is updated.
+	clusterUpdated := false
+	for i := range instance.Spec.WorkerGroupSpecs {
+		worker := &instance.Spec.WorkerGroupSpecs[i]
+		workerGroup, ok := workerGroups[worker.GroupName]
+		if !ok || workerGroup.Spec.Replicas == nil {
+			continue
+		}
+		// The worker groups of a hibernated or preempted RayCluster stay at zero replicas. The RayWorkerGroup is reset below.
+		if common.IsWorkerScalingSuspended(*instance) {
+			continue
+		}
+		if synced, ok := common.GetRayWorkerGroupSyncedReplicas(*workerGroup); ok && synced == *workerGroup.Spec.Replicas {
+			continue
+		}
+		replicas := common.ClampWorkerGroupReplicas(*worker, *workerGroup.Spec.Replicas)
+		if replicas == common.GetWorkerGroupReplicas(*worker) {
+			continue
+		}
+		r.Log.Info("reconcileRayWorkerGroups", "RayWorkerGroup", workerGroup.Name, "replicas", replicas)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Scaled", "Scaled worker group %s from %d to %d replicas through RayWorkerGroup %s",
+			worker.GroupName, common.GetWorkerGroupReplicas(*worker), replicas, workerGroup.Name)
+		worker.Replicas = pointer.Int32(replicas)
+		clusterUpdated = true
+	}
+	if clusterUpdated {
+		if err := r.Update(ctx, instance); err != nil {
+			return err
+		}
+	}
+
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		workerGroup, ok := workerGroups[worker.GroupName]
+		delete(workerGroups, worker.GroupName)
+		if !ok {
+			workerGroup = common.BuildRayWorkerGroup(*instance, worker)
+			if err := controllerutil.SetControllerReference(instance, workerGroup, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, workerGroup); err != nil {
+				if !errors.IsAlreadyExists(err) {
+					return err
+				}
+				// The RayWorkerGroup may be missing from the cache, or its name may be taken by the RayWorkerGroup of
+				// another RayCluster whose name shares the same prefix once truncated.
+				existing := &rayv1alpha1.RayWorkerGroup{}
+				if err := r.Get(ctx, client.ObjectKeyFromObject(workerGroup), existing); err != nil {
+					return err
+				}
+				if metav1.IsControlledBy(existing, instance) && existing.Spec.GroupName == worker.GroupName {
+					r.Log.Info("RayWorkerGroup already exists, no need to create", "RayWorkerGroup", workerGroup.Name)
+					continue
+				}
+				err = fmt.Errorf("RayWorkerGroup %s of worker group %s already exists and belongs to RayCluster %s",
+					workerGroup.Name, worker.GroupName, existing.Spec.RayClusterName)
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, "RayWorkerGroupNameConflict", err.Error())
+				return err
+			}
+			r.Log.Info("RayWorkerGroup created successfully", "RayWorkerGroup", workerGroup.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created RayWorkerGroup %s", workerGroup.Name)
+		} else {
+			replicas := common.GetWorkerGroupReplicas(worker)
+			synced, ok := common.GetRayWorkerGroupSyncedReplicas(*workerGroup)
+			if workerGroup.Spec.Replicas == nil || *workerGroup.Spec.Replicas != replicas || !ok || synced != replicas {
+				common.SetRayWorkerGroupReplicas(workerGroup, replicas)
+				if err := r.Update(ctx, workerGroup); err != nil {
+					return err
+				}
+			}
+		}
+		if err := r.updateRayWorkerGroupStatus(ctx, instance, workerGroup); err != nil {
+			return err
+		}
+	}
+
+	// Delete the RayWorkerGroups of the worker groups that were removed from the RayCluster.
+	for _, workerGroup := range workerGroups {
+		if err := r.deleteRayWorkerGroup(ctx, instance, workerGroup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RayClusterReconciler) updateRayWorkerGroupStatus(ctx context.Context, instance *rayv1alpha1.RayCluster, workerGroup *rayv1alpha1.RayWorkerGroup) error {
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{
+		common.RayClusterLabelKey:   instance.Name,
+		common.RayNodeTypeLabelKey:  string(rayv1alpha1.WorkerNode),
+		common.RayNodeGroupLabelKey: workerGroup.Spec.GroupName,
+	}
+	if err := r.List(ctx, &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+	status := rayv1alpha1.RayWorkerGroupStatus{Selector: common.GetWorkerGroupSelector(*instance, workerGroup.Spec.GroupName)}
+	for i := range workerPods.Items {
+		if !workerPods.Items[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		status.Replicas++
+		if utils.IsRunningAndReady(&workerPods.Items[i]) {
+			status.ReadyReplicas++
+		}
+	}
+	if reflect.DeepEqual(workerGroup.Status, status) {
+		return nil
+	}
+	workerGroup.Status = status
+	return r.Status().Update(ctx, workerGroup)
+}
+
+func (r *RayClusterReconciler) deleteRayWorkerGroup(ctx context.Context, instance *rayv1alpha1.RayCluster, workerGroup *rayv1alpha1.RayWorkerGroup) error {
+	if err := r.Delete(ctx, workerGroup); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.Log.Info("RayWorkerGroup deleted successfully", "RayWorkerGroup", workerGroup.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted RayWorkerGroup %s", workerGroup.Name)
+	return nil
+}
+
+// reconcileOperatorAutoscaling runs one round of the operator autoscaling loop when spec.autoscalerOptions.type is
+// "Operator". It reads the resource demand and the resource usage published by the Ray monitor from the dashboard of
+// the head Pod, and writes the resulting Replicas and WorkersToDelete of every worker group to the RayCluster, which
+// reconcilePods then applies.
+func (r *RayClusterReconciler) reconcileOperatorAutoscaling(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	clusterKey := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if !common.IsOperatorAutoscalingEnabled(*instance) {
+		r.idleWorkers.Delete(clusterKey)
+		return nil
+	}
+	// A hibernated or preempted RayCluster keeps no worker.
+	if common.IsWorkerScalingSuspended(*instance) {
+		return nil
+	}
+	// The dashboard is not reachable before the head Pod is ready. The RayCluster itself is not ready while workers are
+	// pending, which is when they may have to be scaled down.
+	if isHeadPodReady, err := r.isHeadPodReady(ctx, instance); err != nil || !isHeadPodReady {
+		return err
+	}
+
+	clusterStatus, err := r.getRayClusterStatus(ctx, instance)
+	if err != nil {
+		// Keep the current replicas, the next reconciliation will try again.
+		r.Log.Info("Failed to get the Ray cluster status from the dashboard. Skip autoscaling.", "cluster name", instance.Name, "error", err)
+		return nil
+	}
+	if clusterStatus == nil {
+		r.Log.Info("The Ray monitor has not published the cluster status yet. Skip autoscaling.", "cluster name", instance.Name)
+		return nil
+	}
+
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayv1alpha1.WorkerNode)}
+	if err := r.List(ctx, &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var idleSince map[string]time.Time
+	if value, ok := r.idleWorkers.Load(clusterKey); ok {
+		idleSince = value.(map[string]time.Time)
+	}
+	idleSince = common.UpdateIdleWorkers(clusterStatus, workerPods.Items, idleSince, now)
+	r.idleWorkers.Store(clusterKey, idleSince)
+
+	scaling := common.ComputeWorkerGroupScaling(*instance, clusterStatus, workerPods.Items, idleSince, now)
+	updated := false
+	for i := range instance.Spec.WorkerGroupSpecs {
+		worker := &instance.Spec.WorkerGroupSpecs[i]
+		target, ok := scaling[worker.GroupName]
+		if !ok {
+			continue
+		}
+		if worker.Replicas != nil && *worker.Replicas == target.Replicas &&
+			len(worker.ScaleStrategy.WorkersToDelete) == 0 && len(target.WorkersToDelete) == 0 {
+			continue
+		}
+		if worker.Replicas == nil || *worker.Replicas != target.Replicas {
+			r.Log.Info("reconcileOperatorAutoscaling", "worker group", worker.GroupName, "replicas", target.Replicas, "workersToDelete", target.WorkersToDelete)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Autoscaled",
+				"Scaled worker group %s from %d to %d replicas", worker.GroupName, pointer.Int32Deref(worker.Replicas, 0), target.Replicas)
+		}
+		worker.Replicas = pointer.Int32(target.Replicas)
+		worker.ScaleStrategy.WorkersToDelete = target.WorkersToDelete
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	return r.Update(ctx, instance)
+}
+
+// getRayClusterStatus returns the cluster status published by the Ray monitor of the RayCluster.
+func (r *RayClusterReconciler) getRayClusterStatus(ctx context.Context, instance *rayv1alpha1.RayCluster) (*utils.ClusterStatus, error) {
+	rayDashboardClient, err := r.getRayDashboardClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	return rayDashboardClient.GetClusterStatus(ctx)
+}
+
+// getRayDashboardClient returns a client of the dashboard of the RayCluster.
+func (r *RayClusterReconciler) getRayDashboardClient(ctx context.Context, instance *rayv1alpha1.RayCluster) (utils.RayDashboardClientInterface, error) {
+	dashboardURL, err := utils.FetchHeadServiceURL(ctx, &r.Log, r.Client, instance, common.DefaultDashboardName)
+	if err != nil {
+		return nil, err
+	}
+	authToken, err := utils.FetchDashboardAuthToken(ctx, r.APIReader, instance)
+	if err != nil {
+		return nil, err
+	}
+	rayDashboardClient := utils.GetRayDashboardClientFunc()
+	rayDashboardClient.InitClient(dashboardURL, authToken)
+	return rayDashboardClient, nil
+}
+
+// reconcilePreemption preempts lower-priority RayClusters once Pods of the RayCluster have been unschedulable for
+// PreemptionPendingThreshold. The RayJobs of the victims are suspended, and the workers of the other victims are scaled
+// to zero, until the resources they release cover the requests of the unschedulable Pods.
+func (r *RayClusterReconciler) reconcilePreemption(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	clusterKey := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if common.IsRayClusterPreempted(*instance) {
+		r.preemptions.Delete(clusterKey)
+		return r.reconcilePreemptedRayCluster(ctx, instance)
+	}
+	if !EnablePreemption || instance.Spec.Preemption == nil {
+		r.preemptions.Delete(clusterKey)
+		return nil
+	}
+	now := time.Now()
+	if value, ok := r.preemptions.Load(clusterKey); ok && now.Sub(value.(time.Time)) < PreemptionPendingThreshold {
+		return nil
+	}
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	since, requests := common.GetUnschedulablePods(pods.Items)
+	if since.IsZero() || now.Sub(since) < PreemptionPendingThreshold {
+		return nil
+	}
+
+	candidates, err := r.listPreemptionCandidates(ctx, instance)
+	if err != nil {
+		return err
+	}
+	victims := common.SelectPreemptionVictims(PreemptionQuotaGroups, *instance, candidates, requests)
+	if len(victims) == 0 {
+		r.Log.Info("reconcilePreemption", "no lower-priority RayCluster can release the resources requested by", instance.Name, "requests", requests)
+		return nil
+	}
+
+	status := rayv1alpha1.PreemptionStatus{
+		PreemptedBy:       clusterKey.String(),
+		PreemptorPriority: common.GetPreemptionPriority(*instance),
+		PreemptionTime:    &metav1.Time{Time: now},
+	}
+	for _, victim := range victims {
+		if victim.RayJob != "" {
+			err = r.preemptRayJob(ctx, victim, status)
+		} else {
+			err = r.preemptRayCluster(ctx, victim, status)
+		}
+		if err != nil {
+			return err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Preempting", "Preempted RayCluster %s/%s with priority %d",
+			victim.Cluster.Namespace, victim.Cluster.Name, common.GetPreemptionPriority(victim.Cluster))
+	}
+	r.preemptions.Store(clusterKey, now)
+	return nil
+}
+
+// listPreemptionCandidates returns the RayClusters that compete with the RayCluster for resources: those of its
+// namespace, and those of the other namespaces of its quota group. RayClusters whose RayJob cannot be suspended are left out.
+func (r *RayClusterReconciler) listPreemptionCandidates(ctx context.Context, instance *rayv1alpha1.RayCluster) ([]common.PreemptionCandidate, error) {
+	var listOptions []client.ListOption
+	if _, ok := PreemptionQuotaGroups[instance.Namespace]; !ok {
+		listOptions = append(listOptions, client.InNamespace(instance.Namespace))
+	}
+	rayClusters := rayv1alpha1.RayClusterList{}
+	if err := r.List(ctx, &rayClusters, listOptions...); err != nil {
+		return nil, err
+	}
+
+	var candidates []common.PreemptionCandidate
+	for _, cluster := range rayClusters.Items {
+		if cluster.UID == instance.UID || cluster.DeletionTimestamp != nil || !common.IsPreemptible(cluster) ||
+			common.IsWorkerScalingSuspended(cluster) || common.GetPreemptionPriority(cluster) >= common.GetPreemptionPriority(*instance) ||
+			!common.InSamePreemptionScope(PreemptionQuotaGroups, *instance, cluster) {
+			continue
+		}
+		pods := corev1.PodList{}
+		filterLabels := client.MatchingLabels{common.RayClusterLabelKey: cluster.Name}
+		rayJobName, ownedByRayJob := common.GetOwnerRayJobName(cluster)
+		if !ownedByRayJob {
+			// Only the workers of a RayCluster are scaled down.
+			filterLabels[common.RayNodeTypeLabelKey] = string(rayv1alpha1.WorkerNode)
+		} else {
+			rayJob := rayv1alpha1.RayJob{}
+			if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: rayJobName}, &rayJob); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			// The RayJob controller only suspends running RayJobs, and Kueue owns the suspension of the RayJobs it manages.
+			if rayJob.Spec.Suspend || rayJob.Status.JobDeploymentStatus != rayv1alpha1.JobDeploymentStatusRunning || common.IsKueueManaged(&rayJob) {
+				continue
+			}
+		}
+		if err := r.List(ctx, &pods, client.InNamespace(cluster.Namespace), filterLabels); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, common.PreemptionCandidate{
+			Cluster:   cluster,
+			RayJob:    rayJobName,
+			Resources: common.GetScheduledPodsResources(pods.Items),
+		})
+	}
+	return candidates, nil
+}
+
+// preemptRayJob suspends the RayJob of the victim. The RayJob controller then stops the Ray job and deletes its
+// RayCluster.
+func (r *RayClusterReconciler) preemptRayJob(ctx context.Context, victim common.PreemptionCandidate, status rayv1alpha1.PreemptionStatus) error {
+	rayJob := rayv1alpha1.RayJob{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: victim.Cluster.Namespace, Name: victim.RayJob}, &rayJob); err != nil {
+		return err
+	}
+	rayJob.Spec.Suspend = true
+	r.Log.Info("reconcilePreemption", "suspending RayJob", rayJob.Name, "preempted by", status.PreemptedBy)
+	if err := r.Update(ctx, &rayJob); err != nil {
+		return err
+	}
+	rayJob.Status.Preemption = &status
+	if err := r.Status().Update(ctx, &rayJob); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(&rayJob, corev1.EventTypeNormal, "Preempted", "Suspended by RayCluster %s with priority %d", status.PreemptedBy, status.PreemptorPriority)
+	return nil
+}
+
+// preemptRayCluster scales all the worker groups of the victim to zero. reconcilePreemptedRayCluster restores them once
+// the preemptor no longer needs the resources.
+func (r *RayClusterReconciler) preemptRayCluster(ctx context.Context, victim common.PreemptionCandidate, status rayv1alpha1.PreemptionStatus) error {
+	cluster := victim.Cluster.DeepCopy()
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: cluster.Name, common.RayNodeTypeLabelKey: string(rayv1alpha1.WorkerNode)}
+	if err := r.List(ctx, &workerPods, client.InNamespace(cluster.Namespace), filterLabels); err != nil {
+		return err
+	}
+	if err := common.PreemptRayCluster(cluster, workerPods.Items, status.PreemptedBy); err != nil {
+		return err
+	}
+	r.Log.Info("reconcilePreemption", "scaling down RayCluster", cluster.Name, "preempted by", status.PreemptedBy)
+	if err := r.Update(ctx, cluster); err != nil {
+		return err
+	}
+	cluster.Status.Preemption = &status
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "Preempted", "Scaled all worker groups to zero for RayCluster %s with priority %d",
+		status.PreemptedBy, status.PreemptorPriority)
+	return nil
+}
+
+// reconcilePreemptedRayCluster keeps the worker groups of a preempted RayCluster at zero replicas, whichever autoscaler
+// scaled them up, and restores their replicas once the preemptor no longer needs the resources: it is deleted, it is
+// hibernated or preempted itself, it no longer has spec.preemption, or preemption is disabled.
+func (r *RayClusterReconciler) reconcilePreemptedRayCluster(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	preemptedBy := instance.Annotations[common.RayClusterPreemptedByAnnotationKey]
+	preemptorNeedsResources := false
+	if namespace, name, err := cache.SplitMetaNamespaceKey(preemptedBy); err == nil && EnablePreemption && name != "" {
+		preemptor := rayv1alpha1.RayCluster{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &preemptor); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		} else {
+			preemptorNeedsResources = preemptor.DeletionTimestamp == nil && preemptor.Spec.Preemption != nil &&
+				!common.IsWorkerScalingSuspended(preemptor)
+		}
+	}
+
+	if !preemptorNeedsResources {
+		replicas, err := common.GetPreemptedReplicas(*instance)
+		if err != nil {
+			r.Log.Error(err, "Invalid preempted replicas, the worker groups are restored to their minReplicas", "cluster name", instance.Name)
+		}
+		common.RestorePreemptedRayCluster(instance, replicas)
+		r.Log.Info("reconcilePreemption", "restoring RayCluster", instance.Name, "preempted by", preemptedBy, "replicas", replicas)
+		if err := r.Update(ctx, instance); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Restored",
+			"Restored the replicas of the worker groups after the preemption by RayCluster %s ended", preemptedBy)
+		return nil
+	}
+
+	if !common.HasWorkerReplicas(*instance) {
+		return nil
+	}
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayv1alpha1.WorkerNode)}
+	if err := r.List(ctx, &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+	common.ScaleWorkerGroupsToZero(instance, workerPods.Items)
+	r.Log.Info("reconcilePreemption", "scaling the preempted RayCluster back to zero", instance.Name, "preempted by", preemptedBy)
+	return r.Update(ctx, instance)
+}
+
+// reconcileIdlePolicy hibernates the RayCluster once it has been idle for spec.idlePolicy.idleTimeoutSeconds: all the
+// worker groups are scaled to zero, and the head Pod is stopped if spec.idlePolicy.stopHead is set. The RayCluster is
+// woken up when the ray.io/wake-up annotation is set or the idle policy is removed.
+func (r *RayClusterReconciler) reconcileIdlePolicy(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	if common.IsRayClusterHibernated(*instance) && (common.IsWakeUpRequested(*instance) || !common.IsIdlePolicyEnabled(*instance)) {
+		return r.wakeUpRayCluster(ctx, instance)
+	}
+	if common.IsWakeUpRequested(*instance) {
+		// The RayCluster is awake, so the annotation only resets the idle timer.
+		delete(instance.Annotations, common.RayClusterWakeUpAnnotationKey)
+		if err := r.Update(ctx, instance); err != nil {
+			return err
+		}
+		if common.IsIdlePolicyEnabled(*instance) {
+			instance.Status.Hibernation = &rayv1alpha1.HibernationStatus{LastActivityTime: &metav1.Time{Time: time.Now()}}
+			return nil
+		}
+	}
+	if !common.IsIdlePolicyEnabled(*instance) {
+		instance.Status.Hibernation = nil
+		return nil
+	}
+	// The replicas of a preempted RayCluster are restored when the preemption ends, so it is not hibernated meanwhile.
+	if common.IsWorkerScalingSuspended(*instance) {
+		return nil
+	}
+
+	now := time.Now()
+	hibernation := instance.Status.Hibernation
+	if hibernation == nil || hibernation.LastActivityTime == nil {
+		instance.Status.Hibernation = &rayv1alpha1.HibernationStatus{LastActivityTime: &metav1.Time{Time: now}}
+		return nil
+	}
+	// Check the activity at most once per period while the RayCluster is active. A RayCluster whose head Pod is not
+	// ready, for example because it is starting, is considered active. Pending workers do not keep it active.
+	if now.Sub(hibernation.LastActivityTime.Time) < common.IdlePolicyCheckPeriod {
+		return nil
+	}
+	isHeadPodReady, err := r.isHeadPodReady(ctx, instance)
+	if err != nil {
+		return err
+	}
+	// The dashboard request count is persisted in the status, so that it survives restarts of the operator.
+	instance.Status.Hibernation = hibernation.DeepCopy()
+	if !isHeadPodReady || r.isRayClusterActive(ctx, instance) {
+		instance.Status.Hibernation.LastActivityTime = &metav1.Time{Time: now}
+		return nil
+	}
+	if now.Sub(hibernation.LastActivityTime.Time) < common.GetIdleTimeout(*instance) {
+		return nil
+	}
+
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayv1alpha1.WorkerNode)}
+	if err := r.List(ctx, &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+	if err := common.HibernateRayCluster(instance, workerPods.Items); err != nil {
+		return err
+	}
+	// The status is overwritten by the response of the update.
+	lastActivityTime := hibernation.LastActivityTime.DeepCopy()
+	r.Log.Info("reconcileIdlePolicy", "hibernating RayCluster", instance.Name, "last activity", lastActivityTime)
+	if err := r.Update(ctx, instance); err != nil {
+		return err
+	}
+	message := "Scaled all worker groups to zero"
+	if common.IsHeadStopped(*instance) {
+		message += " and stopped the head Pod"
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Hibernated", "%s after %v of inactivity", message, common.GetIdleTimeout(*instance))
+	instance.Status.Hibernation = &rayv1alpha1.HibernationStatus{
+		LastActivityTime: lastActivityTime,
+		HibernationTime:  &metav1.Time{Time: now},
+	}
+	return nil
+}
+
+// wakeUpRayCluster gives the worker groups of the hibernated RayCluster their replicas back. reconcilePods then
+// recreates the head Pod if it was stopped.
+func (r *RayClusterReconciler) wakeUpRayCluster(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	replicas, err := common.GetHibernatedReplicas(*instance)
+	if err != nil {
+		r.Log.Error(err, "Invalid hibernated replicas, the worker groups are restored to their minReplicas", "cluster name", instance.Name)
+	}
+	common.WakeUpRayCluster(instance, replicas)
+	r.Log.Info("reconcileIdlePolicy", "waking up RayCluster", instance.Name, "replicas", replicas)
+	if err := r.Update(ctx, instance); err != nil {
+		return err
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "WokeUp", "Restored the replicas of the worker groups of the hibernated RayCluster")
+	if common.IsIdlePolicyEnabled(*instance) {
+		instance.Status.Hibernation = &rayv1alpha1.HibernationStatus{LastActivityTime: &metav1.Time{Time: time.Now()}}
+	} else {
+		instance.Status.Hibernation = nil
+	}
+	return nil
+}
+
+// isRayClusterActive returns true if the dashboard of the RayCluster reports pending or running jobs or drivers, Ray
+// resources in use or in demand, or user requests since the previous check. The RayCluster is considered active if
+// the jobs, the cluster status, or the dashboard requests cannot be read.
+func (r *RayClusterReconciler) isRayClusterActive(ctx context.Context, instance *rayv1alpha1.RayCluster) bool {
+	rayDashboardClient, err := r.getRayDashboardClient(ctx, instance)
+	if err != nil {
+		r.Log.Info("Failed to create the dashboard client. Consider the RayCluster active.", "cluster name", instance.Name, "error", err)
+		return true
+	}
+	jobInfos, err := rayDashboardClient.ListJobs(ctx)
+	if err != nil {
+		r.Log.Info("Failed to list the jobs from the dashboard. Consider the RayCluster active.", "cluster name", instance.Name, "error", err)
+		return true
+	}
+	if common.HasActiveJobs(jobInfos) {
+		return true
+	}
+	clusterStatus, err := rayDashboardClient.GetClusterStatus(ctx)
+	if err != nil {
+		r.Log.Info("Failed to get the Ray cluster status from the dashboard. Consider the RayCluster active.", "cluster name", instance.Name, "error", err)
+		return true
+	}
+	if common.IsRayClusterBusy(clusterStatus) {
+		return true
+	}
+	return r.hasDashboardActivity(ctx, instance)
+}
+
+// hasDashboardActivity returns true if the dashboard of the RayCluster served user requests since the previous check.
+// The requests are counted from the metrics exported by the dashboard on the head Pod, and the count is stored in
+// instance.Status.Hibernation. The dashboard is considered active if the requests cannot be counted, or if there is
+// no previous count to compare with.
+func (r *RayClusterReconciler) hasDashboardActivity(ctx context.Context, instance *rayv1alpha1.RayCluster) bool {
+	if instance.Status.Head.PodIP == "" {
+		r.Log.Info("The head Pod has no IP to count the dashboard requests. Consider the RayCluster active.", "cluster name", instance.Name)
+		return true
+	}
+	metricsURL := fmt.Sprintf("%s:%d", instance.Status.Head.PodIP, utils.DefaultDashboardMetricsPort)
+	count, err := utils.FetchDashboardRequestCountFunc(ctx, metricsURL, utils.KubeRayDashboardPaths)
+	if err != nil {
+		r.Log.Info("Failed to count the dashboard requests. Consider the RayCluster active.", "cluster name", instance.Name, "error", err)
+		return true
+	}
+	hibernation := instance.Status.Hibernation
+	previous := hibernation.DashboardRequestCount
+	hibernation.DashboardRequestCount = &count
+	return previous == nil || *previous != count
+}
+
+// reconcileTLSCASecret makes sure the CA issuing the node certificates exists when TLS is enabled, and reports its expiration
+// in the status. The CA generated by KubeRay is rotated `RenewBefore` ahead of its expiration. The previous CA stays in the
+// trusted CA bundle until it expires, so the Pods created after the rotation still accept the node certificates of older Pods
+// until reconcileTLSNodeSecrets renews them. A user-provided CA that expires soon is reported by the TLSCAExpiring condition.
+func (r *RayClusterReconciler) reconcileTLSCASecret(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	if !common.IsTLSEnabled(*instance) {
+		instance.Status.TLS = nil
+		meta.RemoveStatusCondition(&instance.Status.Conditions, rayv1alpha1.TLSCAExpiring)
+		return nil
+	}
+
+	caSecretName := common.GetTLSCASecretName(*instance)
+	isManaged := instance.Spec.TLS.CASecretName == ""
+	caDuration := common.GetTLSCADuration(instance.Spec.TLS)
+	renewBefore := common.GetTLSRenewBefore(instance.Spec.TLS)
+	now := time.Now()
+
+	secret := &corev1.Secret{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: caSecretName}, secret); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if !isManaged {
+			r.Log.Error(err, "The CA Secret specified in spec.tls.caSecretName is not found", "Secret", caSecretName)
+			return err
+		}
+		certPEM, keyPEM, err := common.GenerateTLSCA(instance.Name, now, caDuration)
+		if err != nil {
+			return err
+		}
+		secret = common.BuildTLSCASecret(*instance, certPEM, keyPEM, certPEM)
+		if err := controllerutil.SetControllerReference(instance, secret, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			r.Log.Error(err, "TLS CA Secret create error!", "Secret", caSecretName)
+			return err
+		}
+		r.Log.Info("TLS CA Secret created successfully", "Secret", caSecretName)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created TLS CA Secret %s", caSecretName)
+	}
+
+	for _, key := range []string{common.TLSCACertKey, common.TLSCAKeyKey, common.TLSCABundleKey} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("the TLS CA Secret %s/%s does not have the key %s", secret.Namespace, secret.Name, key)
+		}
+	}
+	caCerts, err := common.ParseTLSCertificates(secret.Data[common.TLSCACertKey])
+	if err != nil {
+		return fmt.Errorf("failed to parse %s of the TLS CA Secret %s/%s: %w", common.TLSCACertKey, secret.Namespace, secret.Name, err)
+	}
+	caNotAfter := caCerts[0].NotAfter
+
+	if now.Add(renewBefore).After(caNotAfter) {
+		if !isManaged {
+			message := fmt.Sprintf("The TLS CA in Secret %s expires at %s. KubeRay does not rotate user-provided CAs.", caSecretName, caNotAfter.Format(time.RFC3339))
+			// The event is only emitted when the condition becomes true, not on every reconciliation.
+			if !meta.IsStatusConditionTrue(instance.Status.Conditions, rayv1alpha1.TLSCAExpiring) {
+				r.Recorder.Event(instance, corev1.EventTypeWarning, rayv1alpha1.TLSCAExpiring, message)
+			}
+			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+				Type: rayv1alpha1.TLSCAExpiring, Status: metav1.ConditionTrue, Reason: "CANotRotated", Message: message,
+			})
+		} else {
+			certPEM, keyPEM, err := common.GenerateTLSCA(instance.Name, now, caDuration)
+			if err != nil {
+				return err
+			}
+			// Keep trusting the previous CA until it expires so that the node certificates it issued stay valid.
+			bundlePEM := append(append([]byte{}, certPEM...), secret.Data[common.TLSCACertKey]...)
+			rotatedSecret := common.BuildTLSCASecret(*instance, certPEM, keyPEM, bundlePEM)
+			secret.Data = rotatedSecret.Data
+			if err := r.Update(ctx, secret); err != nil {
+				r.Log.Error(err, "TLS CA Secret rotation error!", "Secret", caSecretName)
+				return err
+			}
+			r.Log.Info("TLS CA Secret rotated", "Secret", caSecretName, "previous CA expiration", caNotAfter)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RotatedTLSCA",
+				"Rotated the TLS CA in Secret %s. The Pods created before the rotation are recreated before %s.", caSecretName, caNotAfter.Format(time.RFC3339))
+			caNotAfter = now.Add(caDuration)
+		}
+	}
+
+	if !now.Add(renewBefore).After(caNotAfter) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, rayv1alpha1.TLSCAExpiring)
+	}
+
+	caExpirationTime := metav1.NewTime(caNotAfter.Truncate(time.Second))
+	instance.Status.TLS = &rayv1alpha1.TLSStatus{
+		CASecretName:     caSecretName,
+		CAExpirationTime: &caExpirationTime,
+	}
+	return nil
+}
+
+// reconcileTLSNodeSecrets issues the node certificate of each Ray Pod once the Pod has an IP, into the Secret named by the
+// RayTLSNodeSecretAnnotationKey annotation of the Pod and owned by it. The certificates that need a renewal are reissued
+// in their Secret, which the kubelet refreshes in the Pod. With spec.tls.recreatePodsOnRenewal, the Pods are recreated
+// one at a time instead, the workers before the head. The Secret of a Pod is only read when the
+// RayTLSNodeCertIssuedAnnotationKey annotation of the Pod says that its certificate may need a renewal.
+func (r *RayClusterReconciler) reconcileTLSNodeSecrets(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	if !common.IsTLSEnabled(*instance) {
+		return nil
+	}
+
+	caSecret := &corev1.Secret{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: common.GetTLSCASecretName(*instance)}, caSecret); err != nil {
+		return err
+	}
+	caCerts, err := common.ParseTLSCertificates(caSecret.Data[common.TLSCACertKey])
+	if err != nil {
+		return fmt.Errorf("failed to parse %s of the TLS CA Secret %s/%s: %w", common.TLSCACertKey, caSecret.Namespace, caSecret.Name, err)
+	}
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	now := time.Now()
+	certDuration := common.GetTLSNodeCertDuration(instance.Spec.TLS)
+	podsTerminating := false
+	var podsToRecreate []corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		secretName, ok := pod.Annotations[common.RayTLSNodeSecretAnnotationKey]
+		if !ok {
+			continue
+		}
+		if pod.DeletionTimestamp != nil {
+			podsTerminating = true
+			continue
+		}
+		if pod.Status.PodIP == "" {
+			// The certificate covers the Pod IP. The Pod is reconciled again once it is scheduled.
+			continue
+		}
+		if !common.IsTLSNodeCertRenewalDue(*pod, caCerts[0], certDuration, now) {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: secretName}, secret); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			if err := r.createTLSNodeSecret(ctx, instance, pod, caSecret, now); err != nil {
+				return err
+			}
+			continue
+		}
+		nodeCerts, err := common.ParseTLSCertificates(secret.Data[common.TLSNodeCertKey])
+		if err == nil && !common.TLSNodeCertNeedsRenewal(nodeCerts[0], caCerts[0], now) {
+			continue
+		}
+		if instance.Spec.TLS.RecreatePodsOnRenewal {
+			podsToRecreate = append(podsToRecreate, *pod)
+			continue
+		}
+		if err := r.renewTLSNodeSecret(ctx, instance, pod, secret, caSecret, now); err != nil {
+			return err
+		}
+	}
+
+	if len(podsToRecreate) == 0 {
+		return nil
+	}
+	if podsTerminating {
+		r.Log.Info("Waiting for the terminating Pods to be deleted before recreating the next Pod to renew its TLS node certificate",
+			"cluster name", instance.Name, "Pods to recreate", len(podsToRecreate))
+		return nil
+	}
+	sort.SliceStable(podsToRecreate, func(i, j int) bool {
+		return podsToRecreate[i].Labels[common.RayNodeTypeLabelKey] != string(rayv1alpha1.HeadNode) &&
+			podsToRecreate[j].Labels[common.RayNodeTypeLabelKey] == string(rayv1alpha1.HeadNode)
+	})
+	pod := podsToRecreate[0]
+	if err := r.Delete(ctx, &pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.Log.Info("Deleted Pod to renew its TLS node certificate", "cluster name", instance.Name, "Pod", pod.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RenewingTLSNodeCert", "Deleted Pod %s to renew its TLS node certificate", pod.Name)
+	return nil
+}
+
+// createTLSNodeSecret issues the node certificate of a Pod and creates the Secret holding it.
+func (r *RayClusterReconciler) createTLSNodeSecret(ctx context.Context, instance *rayv1alpha1.RayCluster, pod *corev1.Pod, caSecret *corev1.Secret, now time.Time) error {
+	certPEM, keyPEM, err := issueTLSNodeCert(*instance, *pod, caSecret, now)
+	if err != nil {
+		return err
+	}
+	secret := common.BuildTLSNodeSecret(*pod, certPEM, keyPEM, caSecret.Data[common.TLSCABundleKey])
+	// The Secret is garbage collected together with the Pod.
+	if err := controllerutil.SetControllerReference(pod, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Log.Error(err, "TLS node Secret create error!", "Secret", secret.Name)
+		return err
+	}
+	r.Log.Info("TLS node Secret created successfully", "Pod", pod.Name, "Secret", secret.Name)
+	return r.markTLSNodeCertIssued(ctx, pod, now)
+}
+
+// renewTLSNodeSecret reissues the node certificate of a Pod in its existing Secret, together with the current CA bundle.
+func (r *RayClusterReconciler) renewTLSNodeSecret(ctx context.Context, instance *rayv1alpha1.RayCluster, pod *corev1.Pod, secret *corev1.Secret, caSecret *corev1.Secret, now time.Time) error {
+	certPEM, keyPEM, err := issueTLSNodeCert(*instance, *pod, caSecret, now)
+	if err != nil {
+		return err
+	}
+	secret.Data = common.BuildTLSNodeSecret(*pod, certPEM, keyPEM, caSecret.Data[common.TLSCABundleKey]).Data
+	if err := r.Update(ctx, secret); err != nil {
+		r.Log.Error(err, "TLS node Secret renewal error!", "Secret", secret.Name)
+		return err
+	}
+	r.Log.Info("TLS node certificate renewed", "Pod", pod.Name, "Secret", secret.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RenewedTLSNodeCert", "Renewed the TLS node certificate of Pod %s", pod.Name)
+	return r.markTLSNodeCertIssued(ctx, pod, now)
+}
+
+// markTLSNodeCertIssued records the issue time of the node certificate of a Pod. Updating the Pod also makes the kubelet
+// refresh the Secret volume instead of waiting for its periodic sync.
+func (r *RayClusterReconciler) markTLSNodeCertIssued(ctx context.Context, pod *corev1.Pod, now time.Time) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	pod.Annotations[common.RayTLSNodeCertIssuedAnnotationKey] = now.UTC().Format(time.RFC3339)
+	return client.IgnoreNotFound(r.Patch(ctx, pod, patch))
+}
+
+// issueTLSNodeCert issues the node certificate of a Pod, covering its IPs and DNS names.
+func issueTLSNodeCert(instance rayv1alpha1.RayCluster, pod corev1.Pod, caSecret *corev1.Secret, now time.Time) ([]byte, []byte, error) {
+	ipAddresses := []net.IP{net.IPv4(127, 0, 0, 1)}
+	for _, podIP := range pod.Status.PodIPs {
+		if ip := net.ParseIP(podIP.IP); ip != nil {
+			ipAddresses = append(ipAddresses, ip)
+		}
+	}
+	if ip := net.ParseIP(pod.Status.PodIP); ip != nil && len(pod.Status.PodIPs) == 0 {
+		ipAddresses = append(ipAddresses, ip)
+	}
+	certPEM, keyPEM, err := common.IssueTLSNodeCert(caSecret.Data[common.TLSCACertKey], caSecret.Data[common.TLSCAKeyKey], pod.Name,
+		common.GetTLSNodeDNSNames(instance, pod), ipAddresses, now, common.GetTLSNodeCertDuration(instance.Spec.TLS))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to issue the TLS node certificate of Pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return certPEM, keyPEM, nil
+}
+
+// reconcileNetworkPolicy creates, updates, or deletes the NetworkPolicy isolating the Pods of the RayCluster according to
+// spec.networkIsolation. With dashboard auth, the NetworkPolicy also keeps the dashboard agent behind the proxy.
+func (r *RayClusterReconciler) reconcileNetworkPolicy(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: utils.GenerateNetworkPolicyName(instance.Name)}
+	err := r.Get(ctx, namespacedName, networkPolicy)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if !common.IsNetworkPolicyEnabled(*instance) {
+		if exists && metav1.IsControlledBy(networkPolicy, instance) {
+			if err := r.Delete(ctx, networkPolicy); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			r.Log.Info("NetworkPolicy deleted because network isolation and dashboard auth are disabled", "NetworkPolicy", namespacedName.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted NetworkPolicy %s", namespacedName.Name)
+		}
+		return nil
+	}
+
+	operatorNamespace := utils.GetOperatorNamespace()
+	if operatorNamespace == "" {
+		r.Log.Info("Cannot determine the namespace of the operator. The operator is not allowed through the NetworkPolicy.")
+	}
+	desired := common.BuildNetworkPolicy(*instance, operatorNamespace)
+	if !exists {
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			r.Log.Error(err, "NetworkPolicy create error!", "NetworkPolicy", desired.Name)
+			return err
+		}
+		r.Log.Info("NetworkPolicy created successfully", "NetworkPolicy", desired.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created NetworkPolicy %s", desired.Name)
+		return nil
+	}
+
+	if !metav1.IsControlledBy(networkPolicy, instance) {
+		return fmt.Errorf("the NetworkPolicy %s/%s already exists and is not controlled by the RayCluster", networkPolicy.Namespace, networkPolicy.Name)
+	}
+	if reflect.DeepEqual(networkPolicy.Spec, desired.Spec) {
+		return nil
+	}
+	networkPolicy.Spec = desired.Spec
+	if err := r.Update(ctx, networkPolicy); err != nil {
+		r.Log.Error(err, "NetworkPolicy update error!", "NetworkPolicy", networkPolicy.Name)
+		return err
+	}
+	r.Log.Info("NetworkPolicy updated successfully", "NetworkPolicy", networkPolicy.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Updated", "Updated NetworkPolicy %s", networkPolicy.Name)
+	return nil
+}
+
+// reconcilePodDisruptionBudgets creates or updates the PodDisruptionBudgets of the head and of each worker group when
+// spec.podDisruptionBudget is set, and deletes the ones of removed worker groups, or all of them when it is unset. The
+// Pods are listed to exclude the ones that are only unready because of the Serve readiness gate from the budgets.
+func (r *RayClusterReconciler) reconcilePodDisruptionBudgets(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	desired := map[string]*policyv1.PodDisruptionBudget{}
+	if common.IsPodDisruptionBudgetEnabled(*instance) {
+		headPDB := common.BuildHeadPodDisruptionBudget(*instance)
+		desired[headPDB.Name] = headPDB
+		pods := corev1.PodList{}
+		if err := r.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+			return err
+		}
+		serveUnreadyPods := map[string]int{}
+		for i := range pods.Items {
+			if common.IsServeUnreadyPod(&pods.Items[i]) {
+				serveUnreadyPods[pods.Items[i].Labels[common.RayNodeGroupLabelKey]]++
+			}
+		}
+		for _, worker := range instance.Spec.WorkerGroupSpecs {
+			workerPDB, err := common.BuildWorkerPodDisruptionBudget(*instance, worker, serveUnreadyPods[worker.GroupName])
+			if err != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidMaxUnavailable", "Invalid maxUnavailable for group %s: %v", worker.GroupName, err)
+				return err
+			}
+			desired[workerPDB.Name] = workerPDB
+		}
+	}
+
+	pdbs := policyv1.PodDisruptionBudgetList{}
+	if err := r.List(ctx, &pdbs, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	existing := map[string]*policyv1.PodDisruptionBudget{}
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		if !metav1.IsControlledBy(pdb, instance) {
+			continue
+		}
+		if _, ok := desired[pdb.Name]; ok {
+			existing[pdb.Name] = pdb
+			continue
+		}
+		if err := r.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		r.Log.Info("PodDisruptionBudget deleted", "PodDisruptionBudget", pdb.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted PodDisruptionBudget %s", pdb.Name)
+	}
+
+	for name, pdb := range desired {
+		current, ok := existing[name]
+		if !ok {
+			if err := controllerutil.SetControllerReference(instance, pdb, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, pdb); err != nil {
+				r.Log.Error(err, "PodDisruptionBudget create error!", "PodDisruptionBudget", pdb.Name)
+				return err
+			}
+			r.Log.Info("PodDisruptionBudget created successfully", "PodDisruptionBudget", pdb.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created PodDisruptionBudget %s", pdb.Name)
+			continue
+		}
+		if reflect.DeepEqual(current.Spec, pdb.Spec) {
+			continue
+		}
+		current.Spec = pdb.Spec
+		if err := r.Update(ctx, current); err != nil {
+			r.Log.Error(err, "PodDisruptionBudget update error!", "PodDisruptionBudget", current.Name)
+			return err
+		}
+		r.Log.Info("PodDisruptionBudget updated successfully", "PodDisruptionBudget", current.Name, "minAvailable", current.Spec.MinAvailable.String())
+	}
+	return nil
+}
+
+// reconcileMonitor creates or updates the Prometheus Operator monitor of the RayCluster according to spec.monitoring, and deletes
+// the monitor of the other kind, or both when monitoring is disabled. Kinds whose CRD was not found at startup are skipped.
+func (r *RayClusterReconciler) reconcileMonitor(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	enabled := common.IsMonitoringEnabled(*instance)
+	desiredGVK := common.GetMonitorGVK(*instance)
+	if enabled && !r.MonitorKinds[desiredGVK.Kind] {
+		r.Log.Info("The CRD of the monitor was not found when the operator started. Skipping RayCluster monitoring.",
+			"RayCluster", instance.Name, "kind", desiredGVK.Kind)
+	}
+
+	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: utils.GenerateMonitorName(instance.Name)}
+	for _, gvk := range []schema.GroupVersionKind{common.PodMonitorGVK, common.ServiceMonitorGVK} {
+		if !r.MonitorKinds[gvk.Kind] {
+			continue
+		}
+		monitor := &unstructured.Unstructured{}
+		monitor.SetGroupVersionKind(gvk)
+		err := r.Get(ctx, namespacedName, monitor)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		exists := err == nil
+
+		if !enabled || gvk != desiredGVK {
+			if exists && metav1.IsControlledBy(monitor, instance) {
+				if err := r.Delete(ctx, monitor); err != nil && !errors.IsNotFound(err) {
+					return err
+				}
+				r.Log.Info("Monitor deleted", "kind", gvk.Kind, "name", namespacedName.Name)
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted %s %s", gvk.Kind, namespacedName.Name)
+			}
+			continue
+		}
+
+		desired := common.BuildMonitor(*instance)
+		if !exists {
+			if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, desired); err != nil {
+				r.Log.Error(err, "Monitor create error!", "kind", gvk.Kind, "name", desired.GetName())
+				return err
+			}
+			r.Log.Info("Monitor created successfully", "kind", gvk.Kind, "name", desired.GetName())
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created %s %s", gvk.Kind, desired.GetName())
+			continue
+		}
+
+		if !metav1.IsControlledBy(monitor, instance) {
+			return fmt.Errorf("the %s %s/%s already exists and is not controlled by the RayCluster", gvk.Kind, monitor.GetNamespace(), monitor.GetName())
+		}
+		if reflect.DeepEqual(monitor.Object["spec"], desired.Object["spec"]) && reflect.DeepEqual(monitor.GetLabels(), desired.GetLabels()) {
+			continue
+		}
+		monitor.Object["spec"] = desired.Object["spec"]
+		monitor.SetLabels(desired.GetLabels())
+		if err := r.Update(ctx, monitor); err != nil {
+			r.Log.Error(err, "Monitor update error!", "kind", gvk.Kind, "name", monitor.GetName())
+			return err
+		}
+		r.Log.Info("Monitor updated successfully", "kind", gvk.Kind, "name", monitor.GetName())
+	}
+	return nil
+}
+
+// reconcileDashboardAuthSecret makes sure the Secret with the token accepted by the dashboard proxy exists when dashboard
+// auth is enabled. The token is generated once and never rotated by KubeRay; deleting the Secret generates a new one, which
+// the proxy picks up once the head Pod is recreated.
+func (r *RayClusterReconciler) reconcileDashboardAuthSecret(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	if !common.IsDashboardAuthEnabled(*instance) {
+		return nil
+	}
+	if auth := instance.Spec.DashboardAuth; auth.Mode == rayv1alpha1.DashboardAuthModeOIDC &&
+		(auth.OIDC == nil || auth.OIDC.IssuerURL == "" || auth.OIDC.Audience == "") {
+		err := fmt.Errorf("spec.dashboardAuth.oidc.issuerURL and spec.dashboardAuth.oidc.audience are required in OIDC mode")
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidDashboardAuth", err.Error())
+		return err
+	}
+
+	secretName := utils.GenerateDashboardAuthSecretName(instance.Name)
+	secret := &corev1.Secret{}
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: secretName}, secret); err == nil {
+		if len(secret.Data[common.DashboardAuthTokenKey]) == 0 {
+			return fmt.Errorf("the dashboard auth Secret %s/%s does not have the key %s", secret.Namespace, secret.Name, common.DashboardAuthTokenKey)
+		}
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	token, err := common.GenerateDashboardAuthToken()
+	if err != nil {
+		return err
+	}
+	secret = common.BuildDashboardAuthSecret(*instance, token)
+	if err := controllerutil.SetControllerReference(instance, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		r.Log.Error(err, "Dashboard auth Secret create error!", "Secret", secretName)
+		return err
+	}
+	r.Log.Info("Dashboard auth Secret created successfully", "Secret", secretName)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created dashboard auth Secret %s", secretName)
+	return nil
+}
+
+// reconcileRedisPasswordSecret makes sure the Secret with the Redis password exists when KubeRay manages it. Each value
+// of spec.managedRedisPassword.rotationID gets its own Secret with a new password, so that the password of the running
+// Pods is never overwritten. The new password is only rolled out to the Pods once the external Redis accepts it, see
+// common.IsRedisPasswordRolloutAllowed. The Secrets are kept if the option is removed, because Pods created before may
+// still reference them.
+func (r *RayClusterReconciler) reconcileRedisPasswordSecret(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	if err := common.ValidateManagedRedisPassword(*instance); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "InvalidManagedRedisPassword", err.Error())
+		return err
+	}
+	if !common.IsManagedRedisPasswordEnabled(*instance) {
+		instance.Status.RedisPassword = nil
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	secretName := common.GetRedisPasswordSecretName(*instance)
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: secretName}, secret); err == nil {
+		if !metav1.IsControlledBy(secret, instance) {
+			return fmt.Errorf("the Secret %s/%s already exists and is not controlled by the RayCluster", secret.Namespace, secret.Name)
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	} else {
+		password, err := common.GenerateRedisPassword()
+		if err != nil {
+			return err
+		}
+		desired := common.BuildRedisPasswordSecret(*instance, password)
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			r.Log.Error(err, "Redis password Secret create error!", "Secret", desired.Name)
+			return err
+		}
+		r.Log.Info("Redis password Secret created successfully", "Secret", desired.Name,
+			"rotationID", instance.Spec.ManagedRedisPassword.RotationID)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created Redis password Secret %s", desired.Name)
+		if !common.IsRedisPasswordRolloutAllowed(*instance) {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RedisPasswordRolloutPending",
+				"Configure the external Redis with the password of Secret %s, then set the annotation %s=%s to recreate the Pods with it",
+				desired.Name, common.RedisPasswordRolloutAnnotationKey, instance.Spec.ManagedRedisPassword.RotationID)
+		}
+	}
+
+	if common.IsRedisPasswordRolloutAllowed(*instance) {
+		instance.Status.RedisPassword = &rayv1alpha1.RedisPasswordStatus{RotationID: instance.Spec.ManagedRedisPassword.RotationID}
+	}
+	return nil
+}
+
+// reconcileRedisPasswordPods rolls the Pods onto the rolled-out Redis password after a rotation. Environment variables
+// are only read when a container starts, so the Pods referencing the Secret of a previous rotation are recreated one at
+// a time, the head before the workers. The Secrets of the previous rotations are deleted once no Pod references them.
+func (r *RayClusterReconciler) reconcileRedisPasswordPods(ctx context.Context, instance *rayv1alpha1.RayCluster) error {
+	if !common.IsManagedRedisPasswordEnabled(*instance) {
+		return nil
+	}
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	secretName := common.GetRolledOutRedisPasswordSecretName(*instance)
+	referencedSecrets := map[string]bool{common.GetRedisPasswordSecretName(*instance): true}
+	podsTerminating := false
+	var podsToRecreate []corev1.Pod
+	for _, pod := range pods.Items {
+		podSecretName := common.GetPodRedisPasswordSecretName(*instance, pod)
+		referencedSecrets[podSecretName] = true
+		if pod.DeletionTimestamp != nil {
+			podsTerminating = true
+			continue
+		}
+		if podSecretName != "" && podSecretName != secretName {
+			podsToRecreate = append(podsToRecreate, pod)
+		}
+	}
+
+	if len(podsToRecreate) > 0 {
+		if podsTerminating {
+			r.Log.Info("Waiting for the terminating Pods to be deleted before recreating the next Pod to rotate the Redis password",
+				"cluster name", instance.Name, "Pods to recreate", len(podsToRecreate))
+			return nil
+		}
+		sort.SliceStable(podsToRecreate, func(i, j int) bool {
+			return podsToRecreate[i].Labels[common.RayNodeTypeLabelKey] == string(rayv1alpha1.HeadNode) &&
+				podsToRecreate[j].Labels[common.RayNodeTypeLabelKey] != string(rayv1alpha1.HeadNode)
+		})
+		pod := podsToRecreate[0]
+		if err := r.Delete(ctx, &pod); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		r.Log.Info("Deleted Pod to rotate the Redis password", "cluster name", instance.Name, "Pod", pod.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "RotatingRedisPassword", "Deleted Pod %s to rotate the Redis password", pod.Name)
+		return nil
+	}
+
+	secrets := corev1.SecretList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RedisPasswordSecretLabelKey: "true"}
+	if err := r.APIReader.List(ctx, &secrets, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Name == secretName || referencedSecrets[secret.Name] || !metav1.IsControlledBy(secret, instance) {
+			continue
+		}
+		if err := r.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		r.Log.Info("Deleted the Redis password Secret of a previous rotation", "cluster name", instance.Name, "Secret", secret.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted Redis password Secret %s", secret.Name)
+	}
+	return nil
+}
+
+// reconcileKueueWorkload creates the Kueue Workload of a queued RayCluster, and recreates it when the pod sets of the
+// RayCluster change. Once Kueue admits the Workload, it returns a copy of the RayCluster whose Pod templates are
+// scheduled on the nodes of the assigned ResourceFlavors. Until then, it returns nil and deletes the Pods of the
+// RayCluster, which were created before Kueue evicted the Workload.
+func (r *RayClusterReconciler) reconcileKueueWorkload(ctx context.Context, instance *rayv1alpha1.RayCluster) (*rayv1alpha1.RayCluster, error) {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(common.WorkloadGVK)
+	workloadName := utils.GenerateRayClusterWorkloadName(instance.Name)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: workloadName}, workload); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if workload, err = common.BuildWorkloadForRayCluster(instance); err != nil {
+			return nil, err
+		}
+		if err := ctrl.SetControllerReference(instance, workload, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, workload); err != nil {
+			return nil, err
+		}
+		r.Log.Info("Created Kueue Workload for RayCluster", "cluster name", instance.Name, "Workload", workloadName)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Created", "Created Workload %s", workloadName)
+	}
+
+	admitted := common.IsWorkloadAdmitted(workload)
+	if workload.GetDeletionTimestamp() != nil {
+		admitted = false
+	} else if outdated, err := common.IsWorkloadOutdated(workload, instance.Spec); err != nil {
+		return nil, err
+	} else if outdated {
+		if err := r.Delete(ctx, workload); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		r.Log.Info("Deleted the outdated Kueue Workload of the RayCluster", "cluster name", instance.Name, "Workload", workloadName)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted Workload %s because the RayCluster spec changed", workloadName)
+		admitted = false
+	}
+
+	if !admitted {
+		pods := corev1.PodList{}
+		if err := r.List(ctx, &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+			return nil, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.DeletionTimestamp != nil || !metav1.IsControlledBy(pod, instance) {
+				continue
+			}
+			if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			r.Log.Info("Deleted Pod of the RayCluster waiting for Kueue", "cluster name", instance.Name, "Pod", pod.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Deleted", "Deleted Pod %s because Workload %s is not admitted", pod.Name, workloadName)
+		}
+		return nil, nil
+	}
+
+	podSetInfos, err := getKueuePodSetInfos(ctx, r.Client, workload)
+	if err != nil {
+		return nil, err
+	}
+	admittedInstance := instance.DeepCopy()
+	common.InjectKueuePodSetInfo(&admittedInstance.Spec, podSetInfos)
+	return admittedInstance, nil
+}
+
+func (r *RayClusterReconciler) updateClusterState(ctx context.Context, instance *rayv1alpha1.RayCluster, clusterState rayv1alpha1.ClusterState) error {
+	if instance.Status.State == clusterState {
+		return nil
+	}
+	instance.Status.State = clusterState
+	r.Log.Info("updateClusterState", "Update CR Status.State", clusterState)
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return err
+	}
+	common.ObserveRayClusterState(instance)
+	return nil
 }
 
 func (r *RayClusterReconciler) updateClusterReason(ctx context.Context, instance *rayv1alpha1.RayCluster, clusterReason string) error {
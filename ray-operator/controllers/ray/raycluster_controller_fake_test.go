@@ -17,6 +17,7 @@ package ray
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -29,11 +30,14 @@ import (
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 	"github.com/stretchr/testify/assert"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -41,12 +45,17 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/utils/pointer"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	// +kubebuilder:scaffold:imports
 )
@@ -487,10 +496,11 @@ func TestReconcile_RemoveWorkersToDelete_RandomDelete(t *testing.T) {
 			assert.Equal(t, expectedNumWorkersToDelete, len(testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete))
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    scheme.Scheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
@@ -581,10 +591,11 @@ func TestReconcile_RemoveWorkersToDelete_NoRandomDelete(t *testing.T) {
 			assert.Equal(t, expectedNumWorkersToDelete, len(testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)-tc.numNonExistPods)
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    scheme.Scheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
@@ -630,10 +641,11 @@ func TestReconcile_RandomDelete_OK(t *testing.T) {
 	assert.Equal(t, len(testPods), len(podList.Items), "Init pod list len is wrong")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
@@ -694,10 +706,11 @@ func TestReconcile_PodDeleted_Diff0_OK(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Since the desired state of the workerGroup is 3 replicas,
@@ -752,10 +765,11 @@ func TestReconcile_PodDeleted_DiffLess0_OK(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Since the desired state of the workerGroup is 3 replicas, the controller
@@ -808,10 +822,11 @@ func TestReconcile_Diff0_WorkersToDelete_OK(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Pod3 and Pod4 should be deleted because of the workersToDelete.
@@ -881,10 +896,11 @@ func TestReconcile_PodCrash_DiffLess0_OK(t *testing.T) {
 
 			// Initialize a new RayClusterReconciler.
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    scheme.Scheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			if tc.ENABLE_RANDOM_POD_DELETE {
@@ -941,10 +957,11 @@ func TestReconcile_PodEvicted_DiffLess0_OK(t *testing.T) {
 	assert.Nil(t, err, "Fail to update head Pod status")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
@@ -998,10 +1015,11 @@ func TestReconcileHeadService(t *testing.T) {
 
 	// Initialize RayCluster reconciler.
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Case 1: Head service does not exist.
@@ -1083,10 +1101,11 @@ func TestReconcile_AutoscalerServiceAccount(t *testing.T) {
 	assert.True(t, k8serrors.IsNotFound(err), "Head group service account should not exist yet")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	err = testRayClusterReconciler.reconcileAutoscalerServiceAccount(ctx, testRayCluster)
@@ -1097,6 +1116,1436 @@ func TestReconcile_AutoscalerServiceAccount(t *testing.T) {
 	assert.Nil(t, err, "Fail to get head group ServiceAccount after reconciliation")
 }
 
+func TestReconcile_TLSCASecret(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+
+	// TLS is disabled. No Secret is created.
+	cluster := testRayCluster.DeepCopy()
+	err := testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, cluster.Status.TLS)
+
+	// TLS is enabled. KubeRay generates a CA.
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{}
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	secretNamespacedName := types.NamespacedName{Name: common.GetTLSCASecretName(*cluster), Namespace: namespaceStr}
+	secret := corev1.Secret{}
+	err = fakeClient.Get(ctx, secretNamespacedName, &secret)
+	assert.Nil(t, err, "Fail to get TLS CA Secret after reconciliation")
+	caCerts, err := common.ParseTLSCertificates(secret.Data[common.TLSCACertKey])
+	assert.Nil(t, err)
+	assert.True(t, caCerts[0].IsCA)
+	assert.Equal(t, secret.Data[common.TLSCACertKey], secret.Data[common.TLSCABundleKey])
+	assert.Equal(t, secretNamespacedName.Name, cluster.Status.TLS.CASecretName)
+	assert.True(t, cluster.Status.TLS.CAExpirationTime.Time.Equal(caCerts[0].NotAfter))
+
+	// The CA is not rotated before `RenewBefore`.
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	rotatedSecret := corev1.Secret{}
+	err = fakeClient.Get(ctx, secretNamespacedName, &rotatedSecret)
+	assert.Nil(t, err)
+	assert.Equal(t, secret.Data, rotatedSecret.Data)
+
+	// The CA expires within `RenewBefore`. A new CA is generated and the previous one stays in the CA bundle.
+	cluster.Spec.TLS.RenewBefore = &metav1.Duration{Duration: common.DefaultTLSCADuration + time.Hour}
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, secretNamespacedName, &rotatedSecret)
+	assert.Nil(t, err)
+	assert.NotEqual(t, secret.Data[common.TLSCACertKey], rotatedSecret.Data[common.TLSCACertKey])
+	bundle, err := common.ParseTLSCertificates(rotatedSecret.Data[common.TLSCABundleKey])
+	assert.Nil(t, err)
+	assert.Len(t, bundle, 2)
+	assert.Equal(t, caCerts[0].Raw, bundle[1].Raw)
+
+	// A user-provided CA Secret that does not exist is an error.
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{CASecretName: "user-ca"}
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// A user-provided CA that expires soon is not rotated. The TLSCAExpiring event is only emitted when the condition is set.
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-ca", Namespace: namespaceStr},
+		Data:       rotatedSecret.Data,
+	}
+	err = fakeClient.Create(ctx, userSecret)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(10)
+	testRayClusterReconciler.Recorder = recorder
+	cluster.Spec.TLS.RenewBefore = &metav1.Duration{Duration: common.DefaultTLSCADuration + time.Hour}
+	for i := 0; i < 2; i++ {
+		err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+		assert.Nil(t, err)
+	}
+	assert.True(t, meta.IsStatusConditionTrue(cluster.Status.Conditions, rayv1alpha1.TLSCAExpiring))
+	assert.Len(t, recorder.Events, 1)
+
+	cluster.Spec.TLS.RenewBefore = nil
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, meta.FindStatusCondition(cluster.Status.Conditions, rayv1alpha1.TLSCAExpiring))
+}
+
+func TestReconcile_AutoscalerTLSSecret(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{}
+	err := testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+
+	// The certificate is issued once, and the issue time does not change until it is renewed.
+	issueTime, err := testRayClusterReconciler.reconcileAutoscalerTLSSecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, issueTime)
+	secret := corev1.Secret{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: utils.GenerateAutoscalerTLSSecretName(cluster.Name)}, &secret)
+	assert.Nil(t, err)
+	caSecret := corev1.Secret{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: common.GetTLSCASecretName(*cluster)}, &caSecret)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, secret.Data[common.TLSNodeKeyKey])
+	assert.NotEqual(t, caSecret.Data[common.TLSCAKeyKey], secret.Data[common.TLSNodeKeyKey])
+	assert.NotEmpty(t, secret.Data[common.TLSCABundleKey])
+	certs, err := common.ParseTLSCertificates(secret.Data[common.TLSNodeCertKey])
+	assert.Nil(t, err)
+	assert.Equal(t, utils.GenerateAutoscalerDeploymentName(cluster.Name), certs[0].Subject.CommonName)
+
+	sameIssueTime, err := testRayClusterReconciler.reconcileAutoscalerTLSSecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, issueTime, sameIssueTime)
+}
+
+func TestReconcile_TLSNodeSecrets(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.TLS = &rayv1alpha1.TLSOptions{}
+	newPod := func(name string, nodeType rayv1alpha1.RayNodeType, podIP string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespaceStr,
+				Labels: map[string]string{
+					common.RayClusterLabelKey:  cluster.Name,
+					common.RayNodeTypeLabelKey: string(nodeType),
+				},
+				Annotations: map[string]string{common.RayTLSNodeSecretAnnotationKey: name + "-tls"},
+			},
+			Status: corev1.PodStatus{PodIP: podIP},
+		}
+	}
+	headPod := newPod("headNode", rayv1alpha1.HeadNode, "10.0.0.1")
+	workerPod := newPod("pod1", rayv1alpha1.WorkerNode, "10.0.0.2")
+	pendingPod := newPod("pod2", rayv1alpha1.WorkerNode, "")
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(headPod, workerPod, pendingPod).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	err := testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	caSecret := corev1.Secret{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: common.GetTLSCASecretName(*cluster), Namespace: namespaceStr}, &caSecret)
+	assert.Nil(t, err)
+	caCerts, err := common.ParseTLSCertificates(caSecret.Data[common.TLSCACertKey])
+	assert.Nil(t, err)
+
+	// The node certificates are issued for the Pods with an IP, into Secrets without the CA key.
+	err = testRayClusterReconciler.reconcileTLSNodeSecrets(ctx, cluster)
+	assert.Nil(t, err)
+	headSecret := corev1.Secret{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "headNode-tls", Namespace: namespaceStr}, &headSecret)
+	assert.Nil(t, err)
+	assert.Equal(t, caSecret.Data[common.TLSCABundleKey], headSecret.Data[common.TLSCABundleKey])
+	assert.NotEqual(t, caSecret.Data[common.TLSCAKeyKey], headSecret.Data[common.TLSNodeKeyKey])
+	assert.True(t, metav1.IsControlledBy(&headSecret, headPod))
+	headCerts, err := common.ParseTLSCertificates(headSecret.Data[common.TLSNodeCertKey])
+	assert.Nil(t, err)
+	assert.Nil(t, headCerts[0].CheckSignatureFrom(caCerts[0]))
+	assert.Nil(t, headCerts[0].VerifyHostname("10.0.0.1"))
+	assert.Nil(t, headCerts[0].VerifyHostname(utils.GenerateFQDNServiceName(*cluster, namespaceStr)))
+	assert.True(t, headCerts[0].NotAfter.Sub(headCerts[0].NotBefore) == common.DefaultTLSNodeCertDuration)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "pod1-tls", Namespace: namespaceStr}, &corev1.Secret{})
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "pod2-tls", Namespace: namespaceStr}, &corev1.Secret{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	pod := corev1.Pod{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "headNode", Namespace: namespaceStr}, &pod)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pod.Annotations[common.RayTLSNodeCertIssuedAnnotationKey])
+
+	// No Pod is recreated while the node certificates are valid.
+	err = testRayClusterReconciler.reconcileTLSNodeSecrets(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "pod1", Namespace: namespaceStr}, &corev1.Pod{})
+	assert.Nil(t, err)
+
+	// The CA is rotated. The node certificates are renewed in their Secret without recreating the Pods.
+	cluster.Spec.TLS.RenewBefore = &metav1.Duration{Duration: common.DefaultTLSCADuration + time.Hour}
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: common.GetTLSCASecretName(*cluster), Namespace: namespaceStr}, &caSecret)
+	assert.Nil(t, err)
+	caCerts, err = common.ParseTLSCertificates(caSecret.Data[common.TLSCACertKey])
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileTLSNodeSecrets(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "headNode", Namespace: namespaceStr}, &pod)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "headNode-tls", Namespace: namespaceStr}, &headSecret)
+	assert.Nil(t, err)
+	assert.Equal(t, caSecret.Data[common.TLSCABundleKey], headSecret.Data[common.TLSCABundleKey])
+	headCerts, err = common.ParseTLSCertificates(headSecret.Data[common.TLSNodeCertKey])
+	assert.Nil(t, err)
+	assert.Nil(t, headCerts[0].CheckSignatureFrom(caCerts[0]))
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "pod1", Namespace: namespaceStr}, &corev1.Pod{})
+	assert.Nil(t, err)
+
+	// With recreatePodsOnRenewal, the Pods are recreated one at a time after the next rotation, the workers before the head.
+	cluster.Spec.TLS.RecreatePodsOnRenewal = true
+	err = testRayClusterReconciler.reconcileTLSCASecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileTLSNodeSecrets(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "pod1", Namespace: namespaceStr}, &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "headNode", Namespace: namespaceStr}, &corev1.Pod{})
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileTLSNodeSecrets(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "headNode", Namespace: namespaceStr}, &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcile_NetworkPolicy(t *testing.T) {
+	setupTest(t)
+	t.Setenv(utils.OperatorNamespaceEnvKey, "ray-system")
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+
+	// Network isolation is disabled. No NetworkPolicy is created.
+	cluster := testRayCluster.DeepCopy()
+	err := testRayClusterReconciler.reconcileNetworkPolicy(ctx, cluster)
+	assert.Nil(t, err)
+	namespacedName := types.NamespacedName{Name: utils.GenerateNetworkPolicyName(cluster.Name), Namespace: namespaceStr}
+	networkPolicy := networkingv1.NetworkPolicy{}
+	err = fakeClient.Get(ctx, namespacedName, &networkPolicy)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// Network isolation is enabled. The operator namespace is allowed to reach the dashboard.
+	cluster.Spec.NetworkIsolation = &rayv1alpha1.NetworkIsolationOptions{}
+	err = testRayClusterReconciler.reconcileNetworkPolicy(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &networkPolicy)
+	assert.Nil(t, err, "Fail to get NetworkPolicy after reconciliation")
+	assert.True(t, metav1.IsControlledBy(&networkPolicy, cluster))
+	assert.Equal(t, "ray-system", networkPolicy.Spec.Ingress[1].From[0].NamespaceSelector.MatchLabels[common.KubernetesNamespaceNameLabelKey])
+	ruleCount := len(networkPolicy.Spec.Ingress)
+
+	// The NetworkPolicy is updated when the peers change.
+	cluster.Spec.NetworkIsolation.ClientPeers = []networkingv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "notebook"}}},
+	}
+	err = testRayClusterReconciler.reconcileNetworkPolicy(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &networkPolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, ruleCount+1, len(networkPolicy.Spec.Ingress))
+
+	// With dashboard auth, the NetworkPolicy is kept to close the dashboard agent port.
+	cluster.Spec.NetworkIsolation = nil
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeToken}
+	err = testRayClusterReconciler.reconcileNetworkPolicy(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &networkPolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(networkPolicy.Spec.Ingress))
+
+	// The NetworkPolicy is deleted when network isolation and dashboard auth are disabled.
+	cluster.Spec.DashboardAuth = nil
+	err = testRayClusterReconciler.reconcileNetworkPolicy(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &networkPolicy)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcile_AutoscalerDeployment(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+
+	// The autoscaler runs as a sidecar. No Deployment is created.
+	cluster := testRayCluster.DeepCopy()
+	err := testRayClusterReconciler.reconcileAutoscalerDeployment(ctx, cluster)
+	assert.Nil(t, err)
+	namespacedName := types.NamespacedName{Name: utils.GenerateAutoscalerDeploymentName(cluster.Name), Namespace: namespaceStr}
+	deployment := appsv1.Deployment{}
+	err = fakeClient.Get(ctx, namespacedName, &deployment)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// The autoscaler runs as a Deployment owned by the RayCluster.
+	deploymentAutoscaler := rayv1alpha1.DeploymentAutoscaler
+	cluster.Spec.EnableInTreeAutoscaling = pointer.Bool(true)
+	cluster.Spec.AutoscalerOptions = &rayv1alpha1.AutoscalerOptions{Type: &deploymentAutoscaler}
+	err = testRayClusterReconciler.reconcileAutoscalerDeployment(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &deployment)
+	assert.Nil(t, err, "Fail to get the autoscaler Deployment after reconciliation")
+	assert.True(t, metav1.IsControlledBy(&deployment, cluster))
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	templateHash := deployment.Annotations[common.RayAutoscalerTemplateHashAnnotationKey]
+
+	// The Deployment is updated when the autoscaler image changes, without touching the head Pod.
+	cluster.Spec.AutoscalerOptions.Image = pointer.String("rayproject/ray:nightly")
+	err = testRayClusterReconciler.reconcileAutoscalerDeployment(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, "rayproject/ray:nightly", deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.NotEqual(t, templateHash, deployment.Annotations[common.RayAutoscalerTemplateHashAnnotationKey])
+
+	// The Deployment is scaled to zero while the RayCluster is hibernated.
+	cluster.Annotations = map[string]string{common.RayClusterHibernatedReplicasAnnotationKey: "{}"}
+	err = testRayClusterReconciler.reconcileAutoscalerDeployment(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	// The Deployment is deleted when the autoscaler runs as a sidecar again.
+	cluster.Spec.AutoscalerOptions.Type = nil
+	err = testRayClusterReconciler.reconcileAutoscalerDeployment(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, namespacedName, &deployment)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcile_PodDisruptionBudgets(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	listPDBs := func() map[string]int {
+		pdbs := policyv1.PodDisruptionBudgetList{}
+		err := fakeClient.List(ctx, &pdbs, client.InNamespace(namespaceStr))
+		assert.Nil(t, err)
+		minAvailable := map[string]int{}
+		for _, pdb := range pdbs.Items {
+			minAvailable[pdb.Name] = pdb.Spec.MinAvailable.IntValue()
+		}
+		return minAvailable
+	}
+
+	// PodDisruptionBudgets are disabled. None is created.
+	cluster := testRayCluster.DeepCopy()
+	err := testRayClusterReconciler.reconcilePodDisruptionBudgets(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Empty(t, listPDBs())
+
+	// PodDisruptionBudgets are enabled. The worker group has 3 replicas and the default maxUnavailable of 1.
+	cluster.Spec.PodDisruptionBudget = &rayv1alpha1.PodDisruptionBudgetOptions{}
+	err = testRayClusterReconciler.reconcilePodDisruptionBudgets(ctx, cluster)
+	assert.Nil(t, err)
+	headPDBName := utils.GeneratePodDisruptionBudgetName(cluster.Name, common.HeadGroupName)
+	workerPDBName := utils.GeneratePodDisruptionBudgetName(cluster.Name, cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	assert.Equal(t, map[string]int{headPDBName: 1, workerPDBName: 2}, listPDBs())
+
+	// A worker Pod whose Serve application is down is not counted against the budget, so it does not block node drains.
+	serveUnreadyPod := &corev1.Pod{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Name: "pod1", Namespace: namespaceStr}, serveUnreadyPod)
+	assert.Nil(t, err)
+	serveUnreadyPod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: common.RayServeReadyConditionType}}
+	serveUnreadyPod.Status.Phase = corev1.PodRunning
+	serveUnreadyPod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+		{Type: common.RayServeReadyConditionType, Status: corev1.ConditionFalse},
+	}
+	err = fakeClient.Update(ctx, serveUnreadyPod)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcilePodDisruptionBudgets(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{headPDBName: 1, workerPDBName: 1}, listPDBs())
+
+	// minAvailable follows the replicas of the worker group.
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32Ptr(1)
+	err = testRayClusterReconciler.reconcilePodDisruptionBudgets(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{headPDBName: 1, workerPDBName: 0}, listPDBs())
+
+	// The PodDisruptionBudget of a deleted worker group is deleted.
+	cluster.Spec.WorkerGroupSpecs = nil
+	err = testRayClusterReconciler.reconcilePodDisruptionBudgets(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{headPDBName: 1}, listPDBs())
+
+	// All PodDisruptionBudgets are deleted when they are disabled.
+	cluster.Spec.PodDisruptionBudget = nil
+	err = testRayClusterReconciler.reconcilePodDisruptionBudgets(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Empty(t, listPDBs())
+}
+
+func TestReconcile_WorkerHeadlessService(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	cluster := testRayCluster.DeepCopy()
+	serviceNamespacedName := types.NamespacedName{Name: utils.GenerateWorkerHeadlessServiceName(cluster.Name), Namespace: namespaceStr}
+
+	// The headless service is disabled. No service is created.
+	err := testRayClusterReconciler.reconcileWorkerHeadlessService(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, serviceNamespacedName, &corev1.Service{})
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// The headless service is created and owned by the RayCluster.
+	cluster.Spec.EnableWorkerHeadlessService = pointer.BoolPtr(true)
+	err = testRayClusterReconciler.reconcileWorkerHeadlessService(ctx, cluster)
+	assert.Nil(t, err)
+	service := corev1.Service{}
+	err = fakeClient.Get(ctx, serviceNamespacedName, &service)
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+	assert.True(t, metav1.IsControlledBy(&service, cluster))
+
+	// Worker Pods use the headless service as their subdomain.
+	pod := testRayClusterReconciler.buildWorkerPod(*cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Equal(t, service.Name, pod.Spec.Subdomain)
+	assert.Equal(t, pod.Name, pod.Spec.Hostname)
+
+	// A worker Pod whose generated name is taken is created with another name, instead of being counted as created.
+	utilrand.Seed(1)
+	takenPod := testRayClusterReconciler.buildWorkerPod(*cluster, cluster.Spec.WorkerGroupSpecs[0])
+	err = fakeClient.Create(ctx, &takenPod)
+	assert.Nil(t, err)
+	utilrand.Seed(1)
+	err = testRayClusterReconciler.createWorkerPod(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Nil(t, err)
+	workerPods := corev1.PodList{}
+	err = fakeClient.List(ctx, &workerPods, client.InNamespace(namespaceStr), client.MatchingLabels{common.RayNodeGroupLabelKey: cluster.Spec.WorkerGroupSpecs[0].GroupName})
+	assert.Nil(t, err)
+	var names []string
+	for _, workerPod := range workerPods.Items {
+		if workerPod.Spec.Subdomain == service.Name {
+			names = append(names, workerPod.Name)
+		}
+	}
+	assert.Equal(t, 2, len(names))
+	assert.Contains(t, names, takenPod.Name)
+
+	// The headless service is deleted when it is disabled.
+	cluster.Spec.EnableWorkerHeadlessService = nil
+	err = testRayClusterReconciler.reconcileWorkerHeadlessService(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, serviceNamespacedName, &corev1.Service{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcile_RayWorkerGroups(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableRayWorkerGroups = pointer.Bool(true)
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32(1)
+	cluster.Spec.WorkerGroupSpecs[0].MaxReplicas = pointer.Int32(4)
+	workerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-1",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				common.RayClusterLabelKey:   instanceName,
+				common.RayNodeTypeLabelKey:  string(rayv1alpha1.WorkerNode),
+				common.RayNodeGroupLabelKey: groupNameStr,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, workerPod).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	clusterKey := types.NamespacedName{Name: instanceName, Namespace: namespaceStr}
+	workerGroupKey := types.NamespacedName{Name: utils.GenerateRayWorkerGroupName(instanceName, groupNameStr), Namespace: namespaceStr}
+	getCluster := func() *rayv1alpha1.RayCluster {
+		instance := &rayv1alpha1.RayCluster{}
+		err := fakeClient.Get(ctx, clusterKey, instance)
+		assert.Nil(t, err)
+		return instance
+	}
+	getWorkerGroup := func() *rayv1alpha1.RayWorkerGroup {
+		workerGroup := &rayv1alpha1.RayWorkerGroup{}
+		err := fakeClient.Get(ctx, workerGroupKey, workerGroup)
+		assert.Nil(t, err)
+		return workerGroup
+	}
+
+	// A RayWorkerGroup owned by the RayCluster is created with the replicas of the worker group.
+	err := testRayClusterReconciler.reconcileRayWorkerGroups(ctx, getCluster())
+	assert.Nil(t, err)
+	workerGroup := getWorkerGroup()
+	assert.True(t, metav1.IsControlledBy(workerGroup, cluster))
+	assert.Equal(t, int32(1), *workerGroup.Spec.Replicas)
+	assert.Equal(t, int32(1), workerGroup.Status.Replicas)
+	assert.Equal(t, int32(1), workerGroup.Status.ReadyReplicas)
+	assert.Equal(t, common.GetWorkerGroupSelector(*cluster, groupNameStr), workerGroup.Status.Selector)
+
+	// The RayWorkerGroup is scaled through the scale subresource. The replicas are clamped to maxReplicas and written to the RayCluster.
+	workerGroup.Spec.Replicas = pointer.Int32(10)
+	err = fakeClient.Update(ctx, workerGroup)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileRayWorkerGroups(ctx, getCluster())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), *getCluster().Spec.WorkerGroupSpecs[0].Replicas)
+	workerGroup = getWorkerGroup()
+	assert.Equal(t, int32(4), *workerGroup.Spec.Replicas)
+	assert.Equal(t, "4", workerGroup.Annotations[common.RayWorkerGroupSyncedReplicasAnnotationKey])
+
+	// The worker group is scaled in the RayCluster. The replicas are written to the RayWorkerGroup.
+	instance := getCluster()
+	instance.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32(2)
+	err = fakeClient.Update(ctx, instance)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileRayWorkerGroups(ctx, getCluster())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), *getCluster().Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Equal(t, int32(2), *getWorkerGroup().Spec.Replicas)
+
+	// The RayWorkerGroups are deleted when they are disabled.
+	instance = getCluster()
+	instance.Spec.EnableRayWorkerGroups = nil
+	err = testRayClusterReconciler.reconcileRayWorkerGroups(ctx, instance)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, workerGroupKey, &rayv1alpha1.RayWorkerGroup{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcile_RayWorkerGroupNameConflict(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableRayWorkerGroups = pointer.Bool(true)
+	// The RayWorkerGroup name of the worker group is taken by another RayCluster.
+	otherWorkerGroup := &rayv1alpha1.RayWorkerGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateRayWorkerGroupName(instanceName, groupNameStr),
+			Namespace: namespaceStr,
+		},
+		Spec: rayv1alpha1.RayWorkerGroupSpec{RayClusterName: "other-cluster", GroupName: "other-group"},
+	}
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, otherWorkerGroup).Build()
+	recorder := record.NewFakeRecorder(10)
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  recorder,
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+
+	err := testRayClusterReconciler.reconcileRayWorkerGroups(context.Background(), cluster)
+	assert.NotNil(t, err)
+	assert.Contains(t, <-recorder.Events, "RayWorkerGroupNameConflict")
+}
+
+func TestReconcile_OperatorAutoscaling(t *testing.T) {
+	setupTest(t)
+
+	operatorAutoscaler := rayv1alpha1.OperatorAutoscaler
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.AutoscalerOptions = &rayv1alpha1.AutoscalerOptions{Type: &operatorAutoscaler}
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32(1)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				common.RayClusterLabelKey:  instanceName,
+				common.RayNodeTypeLabelKey: string(rayv1alpha1.HeadNode),
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	workerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-1",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				common.RayClusterLabelKey:   instanceName,
+				common.RayNodeTypeLabelKey:  string(rayv1alpha1.WorkerNode),
+				common.RayNodeGroupLabelKey: groupNameStr,
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, testServices[0], headPod, workerPod).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	utils.GetRayDashboardClientFunc = func() utils.RayDashboardClientInterface {
+		return fakeDashboardClient
+	}
+	defer func() { utils.GetRayDashboardClientFunc = utils.GetRayDashboardClient }()
+	clusterKey := types.NamespacedName{Name: instanceName, Namespace: namespaceStr}
+	getCluster := func() *rayv1alpha1.RayCluster {
+		instance := &rayv1alpha1.RayCluster{}
+		err := fakeClient.Get(ctx, clusterKey, instance)
+		assert.Nil(t, err)
+		return instance
+	}
+
+	// Three pending tasks need one CPU each, and every node is busy. Each worker has one CPU. The dashboard cannot be
+	// reached before the head Pod is ready, so the replicas are left as is.
+	busyClusterStatus := &utils.ClusterStatus{LoadMetricsReport: utils.LoadMetricsReport{
+		ResourceDemand: []utils.ResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 3}},
+		UsageByNode: map[string]map[string][2]float64{
+			headNodeIP: {"CPU": {1, 1}},
+			"10.0.0.1": {"CPU": {1, 1}},
+		},
+	}}
+	fakeDashboardClient.SetClusterStatus(busyClusterStatus)
+	err := testRayClusterReconciler.reconcileOperatorAutoscaling(ctx, getCluster())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *getCluster().Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The head Pod is ready, but the Ray monitor has not published the cluster status yet.
+	headPod.Status = corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		PodIP:      headNodeIP,
+		Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}},
+	}
+	err = fakeClient.Status().Update(ctx, headPod)
+	assert.Nil(t, err)
+	fakeDashboardClient.SetClusterStatus(nil)
+	err = testRayClusterReconciler.reconcileOperatorAutoscaling(ctx, getCluster())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *getCluster().Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The workers are scaled up, even though the RayCluster is not ready while they are pending.
+	fakeDashboardClient.SetClusterStatus(busyClusterStatus)
+	assert.NotEqual(t, rayv1alpha1.Ready, getCluster().Status.State)
+	err = testRayClusterReconciler.reconcileOperatorAutoscaling(ctx, getCluster())
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), *getCluster().Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The worker becomes idle. It is only removed after the idle timeout.
+	fakeDashboardClient.SetClusterStatus(&utils.ClusterStatus{LoadMetricsReport: utils.LoadMetricsReport{
+		UsageByNode: map[string]map[string][2]float64{
+			headNodeIP: {"CPU": {0, 1}},
+			"10.0.0.1": {"CPU": {0, 1}},
+		},
+	}})
+	err = testRayClusterReconciler.reconcileOperatorAutoscaling(ctx, getCluster())
+	assert.Nil(t, err)
+	instance := getCluster()
+	assert.Equal(t, int32(4), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Empty(t, instance.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+
+	testRayClusterReconciler.idleWorkers.Store(clusterKey, map[string]time.Time{"worker-1": time.Now().Add(-2 * time.Minute)})
+	err = testRayClusterReconciler.reconcileOperatorAutoscaling(ctx, getCluster())
+	assert.Nil(t, err)
+	instance = getCluster()
+	assert.Equal(t, int32(3), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Equal(t, []string{"worker-1"}, instance.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+
+	// The idle workers are forgotten when the operator autoscaling is disabled.
+	instance.Spec.AutoscalerOptions.Type = nil
+	err = testRayClusterReconciler.reconcileOperatorAutoscaling(ctx, instance)
+	assert.Nil(t, err)
+	_, ok := testRayClusterReconciler.idleWorkers.Load(clusterKey)
+	assert.False(t, ok)
+}
+
+func TestReconcile_IdlePolicy(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.IdlePolicy = &rayv1alpha1.IdlePolicy{IdleTimeoutSeconds: pointer.Int32(300), StopHead: true}
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32(1)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	// The RayCluster is not ready while a worker is pending, which does not keep it awake.
+	cluster.Status.State = ""
+	cluster.Status.Head.PodIP = headNodeIP
+	delete(cluster.Spec.HeadGroupSpec.RayStartParams, common.ObjectStoreMemoryKey)
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				common.RayClusterLabelKey:  instanceName,
+				common.RayNodeTypeLabelKey: string(rayv1alpha1.HeadNode),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.3.0"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      headNodeIP,
+			Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	workerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-1",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				common.RayClusterLabelKey:   instanceName,
+				common.RayNodeTypeLabelKey:  string(rayv1alpha1.WorkerNode),
+				common.RayNodeGroupLabelKey: groupNameStr,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:2.3.0"}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+
+	headService, err := common.BuildServiceForHeadPod(*cluster, nil, nil)
+	assert.Nil(t, err)
+	headService.Spec.ClusterIP = headNodeIP
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, headService, headPod, workerPod).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	utils.GetRayDashboardClientFunc = func() utils.RayDashboardClientInterface {
+		return fakeDashboardClient
+	}
+	defer func() { utils.GetRayDashboardClientFunc = utils.GetRayDashboardClient }()
+	dashboardRequests := int64(0)
+	var dashboardMetricsErr error
+	utils.FetchDashboardRequestCountFunc = func(_ context.Context, metricsURL string, _ []string) (int64, error) {
+		assert.Equal(t, fmt.Sprintf("%s:%d", headNodeIP, utils.DefaultDashboardMetricsPort), metricsURL)
+		return dashboardRequests, dashboardMetricsErr
+	}
+	defer func() { utils.FetchDashboardRequestCountFunc = utils.FetchDashboardRequestCount }()
+	clusterKey := types.NamespacedName{Name: instanceName, Namespace: namespaceStr}
+	getCluster := func() *rayv1alpha1.RayCluster {
+		instance := &rayv1alpha1.RayCluster{}
+		err := fakeClient.Get(ctx, clusterKey, instance)
+		assert.Nil(t, err)
+		return instance
+	}
+	listPods := func(nodeType rayv1alpha1.RayNodeType) []corev1.Pod {
+		pods := corev1.PodList{}
+		err := fakeClient.List(ctx, &pods, client.InNamespace(namespaceStr),
+			client.MatchingLabels{common.RayClusterLabelKey: instanceName, common.RayNodeTypeLabelKey: string(nodeType)})
+		assert.Nil(t, err)
+		return pods.Items
+	}
+	idleSince := &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+
+	// The idle timer starts on the first reconciliation.
+	instance := getCluster()
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.NotNil(t, instance.Status.Hibernation.LastActivityTime)
+	assert.Nil(t, instance.Status.Hibernation.HibernationTime)
+
+	// A running job, such as the driver of a connected Ray client, keeps the RayCluster awake.
+	fakeDashboardClient.SetJobInfos([]utils.RayJobInfo{{JobStatus: rayv1alpha1.JobStatusRunning}})
+	instance.Status.Hibernation.LastActivityTime = idleSince
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.True(t, instance.Status.Hibernation.LastActivityTime.After(idleSince.Time))
+	assert.False(t, common.IsRayClusterHibernated(*getCluster()))
+
+	// So do Ray resources in use.
+	fakeDashboardClient.SetJobInfos([]utils.RayJobInfo{{JobStatus: rayv1alpha1.JobStatusSucceeded}})
+	fakeDashboardClient.SetClusterStatus(&utils.ClusterStatus{LoadMetricsReport: utils.LoadMetricsReport{
+		UsageByNode: map[string]map[string][2]float64{"10.0.0.1": {"CPU": {1, 1}}},
+	}})
+	instance.Status.Hibernation.LastActivityTime = idleSince
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.True(t, instance.Status.Hibernation.LastActivityTime.After(idleSince.Time))
+
+	// The dashboard requests cannot be counted, so the RayCluster is considered active.
+	fakeDashboardClient.SetClusterStatus(&utils.ClusterStatus{LoadMetricsReport: utils.LoadMetricsReport{
+		UsageByNode: map[string]map[string][2]float64{"10.0.0.1": {"CPU": {0, 1}}},
+	}})
+	dashboardMetricsErr = fmt.Errorf("connection refused")
+	instance.Status.Hibernation.LastActivityTime = idleSince
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.True(t, instance.Status.Hibernation.LastActivityTime.After(idleSince.Time))
+	assert.Nil(t, instance.Status.Hibernation.DashboardRequestCount)
+
+	// Without a previous count, the dashboard requests are reported as activity. The count is kept in the status.
+	dashboardMetricsErr = nil
+	dashboardRequests = 10
+	instance.Status.Hibernation.LastActivityTime = idleSince
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.True(t, instance.Status.Hibernation.LastActivityTime.After(idleSince.Time))
+	assert.Equal(t, int64(10), *instance.Status.Hibernation.DashboardRequestCount)
+
+	// New user requests are activity.
+	dashboardRequests = 12
+	instance.Status.Hibernation.LastActivityTime = idleSince
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.True(t, instance.Status.Hibernation.LastActivityTime.After(idleSince.Time))
+	assert.Equal(t, int64(12), *instance.Status.Hibernation.DashboardRequestCount)
+
+	// The RayCluster is idle for longer than the idle timeout. It is hibernated.
+	instance.Status.Hibernation.LastActivityTime = idleSince
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.True(t, idleSince.Equal(instance.Status.Hibernation.LastActivityTime))
+	assert.NotNil(t, instance.Status.Hibernation.HibernationTime)
+	instance = getCluster()
+	assert.True(t, common.IsRayClusterHibernated(*instance))
+	assert.Equal(t, int32(0), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Equal(t, []string{"worker-1"}, instance.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+
+	// The worker and head Pods are deleted, and the RayCluster is reported as hibernated.
+	err = testRayClusterReconciler.reconcilePods(ctx, instance)
+	assert.Nil(t, err)
+	assert.Empty(t, listPods(rayv1alpha1.HeadNode))
+	assert.Empty(t, listPods(rayv1alpha1.WorkerNode))
+	newInstance, err := testRayClusterReconciler.calculateStatus(ctx, instance)
+	assert.Nil(t, err)
+	assert.Equal(t, rayv1alpha1.Hibernated, newInstance.Status.State)
+
+	// The ray.io/wake-up annotation restores the replicas, and the head Pod is recreated.
+	common.RequestWakeUp(instance, time.Now())
+	err = fakeClient.Update(ctx, instance)
+	assert.Nil(t, err)
+	instance = getCluster()
+	err = testRayClusterReconciler.reconcileIdlePolicy(ctx, instance)
+	assert.Nil(t, err)
+	assert.Nil(t, instance.Status.Hibernation.HibernationTime)
+	instance = getCluster()
+	assert.False(t, common.IsRayClusterHibernated(*instance))
+	assert.False(t, common.IsWakeUpRequested(*instance))
+	assert.Equal(t, int32(1), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	err = testRayClusterReconciler.reconcilePods(ctx, instance)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(listPods(rayv1alpha1.HeadNode)))
+	assert.Equal(t, 1, len(listPods(rayv1alpha1.WorkerNode)))
+}
+
+func TestReconcile_Preemption(t *testing.T) {
+	setupTest(t)
+
+	EnablePreemption = true
+	defer func() { EnablePreemption = false }()
+	now := time.Now()
+	newCluster := func(name string, options *rayv1alpha1.PreemptionOptions) *rayv1alpha1.RayCluster {
+		cluster := testRayCluster.DeepCopy()
+		cluster.Name = name
+		cluster.UID = types.UID(name)
+		cluster.CreationTimestamp = metav1.Time{Time: now}
+		cluster.Spec.Preemption = options
+		cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32(1)
+		cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+		return cluster
+	}
+	newPod := func(cluster string, name string, nodeType rayv1alpha1.RayNodeType, cpu string, scheduled bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespaceStr,
+				Labels: map[string]string{
+					common.RayClusterLabelKey:   cluster,
+					common.RayNodeTypeLabelKey:  string(nodeType),
+					common.RayNodeGroupLabelKey: groupNameStr,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:      "ray-worker",
+					Image:     "rayproject/ray:2.3.0",
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if scheduled {
+			pod.Spec.NodeName = "node-1"
+		} else {
+			pod.Status.Phase = corev1.PodPending
+			pod.Status.Conditions = []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionFalse,
+				Reason:             corev1.PodReasonUnschedulable,
+				LastTransitionTime: metav1.Time{Time: now.Add(-10 * time.Minute)},
+			}}
+		}
+		return pod
+	}
+
+	preemptor := newCluster("preemptor", &rayv1alpha1.PreemptionOptions{Priority: 10})
+	victimCluster := newCluster("victim-cluster", &rayv1alpha1.PreemptionOptions{Priority: 1, Preemptible: true})
+	victimJobCluster := newCluster("victim-job-cluster", &rayv1alpha1.PreemptionOptions{Priority: 0, Preemptible: true})
+	victimJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "victim-job", Namespace: namespaceStr, UID: "victim-job"},
+		Spec:       rayv1alpha1.RayJobSpec{RayClusterSpec: &victimJobCluster.Spec},
+		Status:     rayv1alpha1.RayJobStatus{JobDeploymentStatus: rayv1alpha1.JobDeploymentStatusRunning},
+	}
+	victimJob.APIVersion = rayv1alpha1.GroupVersion.String()
+	victimJob.Kind = string(utils.RayJobCRD)
+	victimJobCluster.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(victimJob, victimJob.GroupVersionKind())}
+	notPreemptible := newCluster("not-preemptible", &rayv1alpha1.PreemptionOptions{Priority: 0})
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		preemptor, victimCluster, victimJobCluster, victimJob, notPreemptible,
+		newPod("preemptor", "preemptor-worker", rayv1alpha1.WorkerNode, "4", false),
+		newPod("victim-cluster", "victim-cluster-head", rayv1alpha1.HeadNode, "8", true),
+		newPod("victim-cluster", "victim-cluster-worker", rayv1alpha1.WorkerNode, "2", true),
+		newPod("victim-job-cluster", "victim-job-cluster-head", rayv1alpha1.HeadNode, "1", true),
+		newPod("victim-job-cluster", "victim-job-cluster-worker", rayv1alpha1.WorkerNode, "1", true),
+		newPod("not-preemptible", "not-preemptible-worker", rayv1alpha1.WorkerNode, "16", true),
+	).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	getCluster := func(name string) *rayv1alpha1.RayCluster {
+		instance := &rayv1alpha1.RayCluster{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: name}, instance)
+		assert.Nil(t, err)
+		return instance
+	}
+
+	// The Pods of the preemptor have not been unschedulable for long enough.
+	PreemptionPendingThreshold = time.Hour
+	err := testRayClusterReconciler.reconcilePreemption(ctx, getCluster("preemptor"))
+	assert.Nil(t, err)
+	assert.Nil(t, getCluster("victim-cluster").Status.Preemption)
+
+	// The RayJob with the lowest priority is suspended first, which releases the 2 CPUs of its whole RayCluster. The
+	// workers of the RayCluster are then scaled down to release the 2 missing CPUs, while its head Pod is kept.
+	PreemptionPendingThreshold = common.DefaultPreemptionPendingThreshold
+	defer func() { PreemptionPendingThreshold = common.DefaultPreemptionPendingThreshold }()
+	err = testRayClusterReconciler.reconcilePreemption(ctx, getCluster("preemptor"))
+	assert.Nil(t, err)
+
+	rayJob := &rayv1alpha1.RayJob{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: "victim-job"}, rayJob)
+	assert.Nil(t, err)
+	assert.True(t, rayJob.Spec.Suspend)
+	assert.Equal(t, namespaceStr+"/preemptor", rayJob.Status.Preemption.PreemptedBy)
+	assert.Equal(t, int32(10), rayJob.Status.Preemption.PreemptorPriority)
+
+	instance := getCluster("victim-cluster")
+	assert.Equal(t, int32(0), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Equal(t, []string{"victim-cluster-worker"}, instance.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+	assert.Equal(t, namespaceStr+"/preemptor", instance.Status.Preemption.PreemptedBy)
+	assert.NotNil(t, instance.Status.Preemption.PreemptionTime)
+	assert.True(t, common.IsRayClusterPreempted(*instance))
+	assert.Equal(t, namespaceStr+"/preemptor", instance.Annotations[common.RayClusterPreemptedByAnnotationKey])
+
+	instance = getCluster("not-preemptible")
+	assert.Equal(t, int32(1), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Nil(t, instance.Status.Preemption)
+
+	// The victims are given time to release their resources before the next preemption.
+	instance = getCluster("victim-cluster")
+	instance.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32(1)
+	instance.Status.Preemption = nil
+	err = fakeClient.Update(ctx, instance)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcilePreemption(ctx, getCluster("preemptor"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), *getCluster("victim-cluster").Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The preempted RayCluster is scaled back to zero, e.g. after an autoscaler scaled it up, while the preemptor
+	// still needs the resources.
+	err = testRayClusterReconciler.reconcilePreemption(ctx, getCluster("victim-cluster"))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), *getCluster("victim-cluster").Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The replicas are restored once the preemptor is deleted.
+	err = fakeClient.Delete(ctx, getCluster("preemptor"))
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcilePreemption(ctx, getCluster("victim-cluster"))
+	assert.Nil(t, err)
+	instance = getCluster("victim-cluster")
+	assert.Equal(t, int32(1), *instance.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Empty(t, instance.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete)
+	assert.False(t, common.IsRayClusterPreempted(*instance))
+	assert.Empty(t, instance.Annotations[common.RayClusterPreemptedByAnnotationKey])
+}
+
+func TestReconcile_Monitor(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:       fakeClient,
+		APIReader:    fakeClient,
+		Recorder:     &record.FakeRecorder{},
+		Scheme:       scheme.Scheme,
+		Log:          ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		MonitorKinds: map[string]bool{common.PodMonitorGVK.Kind: true, common.ServiceMonitorGVK.Kind: true},
+	}
+	cluster := testRayCluster.DeepCopy()
+	monitorNamespacedName := types.NamespacedName{Name: utils.GenerateMonitorName(cluster.Name), Namespace: namespaceStr}
+	getMonitor := func(gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+		monitor := &unstructured.Unstructured{}
+		monitor.SetGroupVersionKind(gvk)
+		err := fakeClient.Get(ctx, monitorNamespacedName, monitor)
+		return monitor, err
+	}
+
+	// Monitoring is disabled. No monitor is created.
+	err := testRayClusterReconciler.reconcileMonitor(ctx, cluster)
+	assert.Nil(t, err)
+	_, err = getMonitor(common.PodMonitorGVK)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// A PodMonitor owned by the RayCluster is created by default.
+	cluster.Spec.Monitoring = &rayv1alpha1.MonitoringOptions{}
+	err = testRayClusterReconciler.reconcileMonitor(ctx, cluster)
+	assert.Nil(t, err)
+	monitor, err := getMonitor(common.PodMonitorGVK)
+	assert.Nil(t, err)
+	assert.True(t, metav1.IsControlledBy(monitor, cluster))
+
+	// Changes of the labels and scrape interval are applied.
+	cluster.Spec.Monitoring.Labels = map[string]string{"release": "prometheus"}
+	cluster.Spec.Monitoring.Interval = "1m"
+	err = testRayClusterReconciler.reconcileMonitor(ctx, cluster)
+	assert.Nil(t, err)
+	monitor, err = getMonitor(common.PodMonitorGVK)
+	assert.Nil(t, err)
+	assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	assert.Equal(t, "1m", endpoints[0].(map[string]interface{})["interval"])
+
+	// Switching to a ServiceMonitor deletes the PodMonitor.
+	cluster.Spec.Monitoring.Type = rayv1alpha1.MonitorTypeServiceMonitor
+	err = testRayClusterReconciler.reconcileMonitor(ctx, cluster)
+	assert.Nil(t, err)
+	_, err = getMonitor(common.PodMonitorGVK)
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = getMonitor(common.ServiceMonitorGVK)
+	assert.Nil(t, err)
+
+	// The monitor is deleted when monitoring is disabled.
+	cluster.Spec.Monitoring = nil
+	err = testRayClusterReconciler.reconcileMonitor(ctx, cluster)
+	assert.Nil(t, err)
+	_, err = getMonitor(common.ServiceMonitorGVK)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// Nothing is created when the CRD is not installed.
+	testRayClusterReconciler.MonitorKinds = map[string]bool{}
+	cluster.Spec.Monitoring = &rayv1alpha1.MonitoringOptions{}
+	err = testRayClusterReconciler.reconcileMonitor(ctx, cluster)
+	assert.Nil(t, err)
+	_, err = getMonitor(common.PodMonitorGVK)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcile_RedisPasswordSecret(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	cluster := testRayCluster.DeepCopy()
+	secretNamespacedName := types.NamespacedName{Name: utils.GenerateRedisPasswordSecretName(cluster.Name, ""), Namespace: namespaceStr}
+	getPassword := func(namespacedName types.NamespacedName) string {
+		secret := corev1.Secret{}
+		err := fakeClient.Get(ctx, namespacedName, &secret)
+		assert.Nil(t, err, "Fail to get Redis password Secret after reconciliation")
+		return string(secret.Data[common.RedisPasswordKey])
+	}
+
+	// The Redis password is not managed. No Secret is created.
+	err := testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, secretNamespacedName, &corev1.Secret{})
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// KubeRay generates a password, which does not change across reconciliations.
+	cluster.Spec.ManagedRedisPassword = &rayv1alpha1.ManagedRedisPasswordOptions{}
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	password := getPassword(secretNamespacedName)
+	assert.Len(t, password, 64)
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, password, getPassword(secretNamespacedName))
+
+	// Changing the rotation ID generates a new password in a new Secret. The Secret of the running Pods is kept.
+	cluster.Spec.ManagedRedisPassword.RotationID = "2023-10-01"
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	rotatedNamespacedName := types.NamespacedName{Name: common.GetRedisPasswordSecretName(*cluster), Namespace: namespaceStr}
+	assert.NotEqual(t, secretNamespacedName, rotatedNamespacedName)
+	assert.NotEqual(t, password, getPassword(rotatedNamespacedName))
+	assert.Equal(t, password, getPassword(secretNamespacedName))
+
+	// A password set in rayStartParams is rejected.
+	invalidCluster := cluster.DeepCopy()
+	invalidCluster.Spec.HeadGroupSpec.RayStartParams["redis-password"] = "LetMeInRay"
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, invalidCluster)
+	assert.NotNil(t, err)
+
+	// An external Redis requires a password.
+	invalidCluster = testRayCluster.DeepCopy()
+	invalidCluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, invalidCluster)
+	assert.NotNil(t, err)
+}
+
+func TestReconcile_RedisPasswordPods(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.ManagedRedisPassword = &rayv1alpha1.ManagedRedisPasswordOptions{}
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	oldSecret := common.BuildRedisPasswordSecret(*cluster, "old-password")
+	newPod := func(name string, nodeType rayv1alpha1.RayNodeType) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespaceStr,
+				Labels:    map[string]string{common.RayClusterLabelKey: cluster.Name, common.RayNodeTypeLabelKey: string(nodeType)},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "ray-head",
+					Image: "rayproject/ray:2.3.0",
+					Env: []corev1.EnvVar{{
+						Name: common.REDIS_PASSWORD,
+						ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: oldSecret.Name},
+							Key:                  common.RedisPasswordKey,
+						}},
+					}},
+				}},
+			},
+		}
+	}
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	err := controllerutil.SetControllerReference(cluster, oldSecret, newScheme)
+	assert.Nil(t, err)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		oldSecret, newPod("worker", rayv1alpha1.WorkerNode), newPod("head", rayv1alpha1.HeadNode),
+	).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	podExists := func(name string) bool {
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: name}, &corev1.Pod{})
+		return err == nil
+	}
+	secretExists := func(name string) bool {
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: name}, &corev1.Secret{})
+		return err == nil
+	}
+
+	// The Pods reference the current Secret. Nothing is recreated.
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileRedisPasswordPods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.True(t, podExists("head"))
+	assert.True(t, podExists("worker"))
+
+	// After a rotation, the new Secret is created, but the Pods keep the password known by the external Redis until
+	// the rollout is confirmed.
+	cluster.Spec.ManagedRedisPassword.RotationID = "2023-10-01"
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.True(t, secretExists(common.GetRedisPasswordSecretName(*cluster)))
+	assert.Equal(t, oldSecret.Name, common.GetRolledOutRedisPasswordSecretName(*cluster))
+	err = testRayClusterReconciler.reconcileRedisPasswordPods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.True(t, podExists("head"))
+	assert.True(t, podExists("worker"))
+	assert.True(t, secretExists(common.GetRedisPasswordSecretName(*cluster)))
+
+	// Once the rollout is confirmed, the head Pod is recreated first, then the worker Pods, one at a time.
+	cluster.Annotations = map[string]string{common.RedisPasswordRolloutAnnotationKey: "2023-10-01"}
+	err = testRayClusterReconciler.reconcileRedisPasswordSecret(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-10-01", cluster.Status.RedisPassword.RotationID)
+	err = testRayClusterReconciler.reconcileRedisPasswordPods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, podExists("head"))
+	assert.True(t, podExists("worker"))
+	assert.True(t, secretExists(oldSecret.Name))
+
+	err = testRayClusterReconciler.reconcileRedisPasswordPods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, podExists("worker"))
+	assert.True(t, secretExists(oldSecret.Name))
+
+	// The Secret of the previous rotation is deleted once no Pod references it.
+	err = testRayClusterReconciler.reconcileRedisPasswordPods(ctx, cluster)
+	assert.Nil(t, err)
+	assert.False(t, secretExists(oldSecret.Name))
+	assert.True(t, secretExists(common.GetRedisPasswordSecretName(*cluster)))
+}
+
+func TestReconcile_KueueWorkload(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Labels = map[string]string{common.KueueQueueNameLabelKey: "user-queue"}
+	assert.True(t, common.IsKueueManagedRayCluster(cluster))
+	flavor := &unstructured.Unstructured{}
+	flavor.SetGroupVersionKind(common.ResourceFlavorGVK)
+	flavor.SetName("gpu")
+	flavor.Object["spec"] = map[string]interface{}{
+		"nodeLabels": map[string]interface{}{"accelerator": "nvidia-tesla-t4"},
+	}
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, flavor).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:       fakeClient,
+		APIReader:    fakeClient,
+		Recorder:     &record.FakeRecorder{},
+		Scheme:       newScheme,
+		Log:          ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		KueueEnabled: true,
+	}
+	workloadNamespacedName := types.NamespacedName{Namespace: namespaceStr, Name: utils.GenerateRayClusterWorkloadName(cluster.Name)}
+	getWorkload := func() *unstructured.Unstructured {
+		workload := &unstructured.Unstructured{}
+		workload.SetGroupVersionKind(common.WorkloadGVK)
+		assert.Nil(t, fakeClient.Get(ctx, workloadNamespacedName, workload))
+		return workload
+	}
+
+	// The Workload is created, and no Pod is created until it is admitted.
+	admittedInstance, err := testRayClusterReconciler.reconcileKueueWorkload(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, admittedInstance)
+	workload := getWorkload()
+	assert.True(t, metav1.IsControlledBy(workload, cluster))
+
+	// Once admitted, the Pods are scheduled on the nodes of the assigned flavors. The RayCluster is not modified.
+	workload.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": common.KueueAdmittedCondition, "status": "True"},
+		},
+		"admission": map[string]interface{}{
+			"podSetAssignments": []interface{}{
+				map[string]interface{}{"name": cluster.Spec.WorkerGroupSpecs[0].GroupName, "flavors": map[string]interface{}{"nvidia.com/gpu": "gpu"}},
+			},
+		},
+	}
+	assert.Nil(t, fakeClient.Update(ctx, workload))
+	admittedInstance, err = testRayClusterReconciler.reconcileKueueWorkload(ctx, cluster)
+	assert.Nil(t, err)
+	assert.NotNil(t, admittedInstance)
+	assert.Equal(t, "nvidia-tesla-t4", admittedInstance.Spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector["accelerator"])
+	assert.Nil(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector)
+
+	// The Workload is recreated when the pod sets change, and the Pods are deleted until it is admitted again.
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker",
+			Namespace: namespaceStr,
+			Labels:    map[string]string{common.RayClusterLabelKey: cluster.Name},
+		},
+	}
+	assert.Nil(t, controllerutil.SetControllerReference(cluster, pod, newScheme))
+	assert.Nil(t, fakeClient.Create(ctx, pod))
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32Ptr(*cluster.Spec.WorkerGroupSpecs[0].MinReplicas + 1)
+	admittedInstance, err = testRayClusterReconciler.reconcileKueueWorkload(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, admittedInstance)
+	err = fakeClient.Get(ctx, workloadNamespacedName, &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "kueue.x-k8s.io/v1beta1", "kind": "Workload"}})
+	assert.True(t, k8serrors.IsNotFound(err))
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: pod.Name}, &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	admittedInstance, err = testRayClusterReconciler.reconcileKueueWorkload(ctx, cluster)
+	assert.Nil(t, err)
+	assert.Nil(t, admittedInstance)
+	outdated, err := common.IsWorkloadOutdated(getWorkload(), cluster.Spec)
+	assert.Nil(t, err)
+	assert.False(t, outdated)
+
+	// The RayClusters of RayJobs are queued through the RayJob.
+	assert.Nil(t, controllerutil.SetControllerReference(&rayv1alpha1.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "rayjob", UID: "rayjob-uid"}}, cluster, newScheme))
+	assert.False(t, common.IsKueueManagedRayCluster(cluster))
+}
+
+func TestReconcile_DashboardAuthSecret(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+
+	// Dashboard auth is disabled. No Secret is created.
+	cluster := testRayCluster.DeepCopy()
+	err := testRayClusterReconciler.reconcileDashboardAuthSecret(ctx, cluster)
+	assert.Nil(t, err)
+	secretNamespacedName := types.NamespacedName{Name: utils.GenerateDashboardAuthSecretName(cluster.Name), Namespace: namespaceStr}
+	secret := corev1.Secret{}
+	err = fakeClient.Get(ctx, secretNamespacedName, &secret)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// Dashboard auth is enabled. KubeRay generates a token, which the operator's dashboard client uses.
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeToken}
+	err = testRayClusterReconciler.reconcileDashboardAuthSecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, secretNamespacedName, &secret)
+	assert.Nil(t, err, "Fail to get dashboard auth Secret after reconciliation")
+	token := string(secret.Data[common.DashboardAuthTokenKey])
+	assert.Len(t, token, 64)
+	fetchedToken, err := utils.FetchDashboardAuthToken(ctx, fakeClient, cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, token, fetchedToken)
+
+	// The Secret is not read when dashboard auth is disabled.
+	disabledCluster := cluster.DeepCopy()
+	disabledCluster.Spec.DashboardAuth = nil
+	fetchedToken, err = utils.FetchDashboardAuthToken(ctx, fakeClient, disabledCluster)
+	assert.Nil(t, err)
+	assert.Empty(t, fetchedToken)
+
+	// The token is not regenerated.
+	err = testRayClusterReconciler.reconcileDashboardAuthSecret(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, secretNamespacedName, &secret)
+	assert.Nil(t, err)
+	assert.Equal(t, token, string(secret.Data[common.DashboardAuthTokenKey]))
+
+	// The OIDC mode requires an issuer and an audience.
+	cluster.Spec.DashboardAuth = &rayv1alpha1.DashboardAuthOptions{Mode: rayv1alpha1.DashboardAuthModeOIDC}
+	err = testRayClusterReconciler.reconcileDashboardAuthSecret(ctx, cluster)
+	assert.NotNil(t, err)
+}
+
 func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 	setupTest(t)
 
@@ -1117,10 +2566,11 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 
 	// Initialize the reconciler
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// If users specify ServiceAccountName for the head Pod, they need to create a ServiceAccount themselves.
@@ -1138,10 +2588,11 @@ func TestReconcile_Autoscaler_ServiceAccountName(t *testing.T) {
 
 	// Initialize the reconciler
 	testRayClusterReconciler = &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	err = testRayClusterReconciler.reconcileAutoscalerServiceAccount(ctx, cluster)
@@ -1164,10 +2615,11 @@ func TestReconcile_AutoscalerRoleBinding(t *testing.T) {
 	assert.True(t, k8serrors.IsNotFound(err), "autoscaler RoleBinding should not exist yet")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	err = testRayClusterReconciler.reconcileAutoscalerRoleBinding(ctx, testRayCluster)
@@ -1197,10 +2649,11 @@ func TestReconcile_UpdateClusterReason(t *testing.T) {
 	assert.Empty(t, cluster.Status.Reason, "Cluster reason should be empty")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 	reason := "test reason"
 
@@ -1218,10 +2671,11 @@ func TestUpdateEndpoints(t *testing.T) {
 	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testServices...).Build()
 	ctx := context.Background()
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	if err := testRayClusterReconciler.updateEndpoints(ctx, testRayCluster); err != nil {
@@ -1285,10 +2739,11 @@ func TestGetHeadPodIP(t *testing.T) {
 			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(tc.pods...).Build()
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    scheme.Scheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			ip, err := testRayClusterReconciler.getHeadPodIP(context.TODO(), testRayCluster)
@@ -1352,10 +2807,11 @@ func TestGetHeadServiceIP(t *testing.T) {
 			fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(tc.services...).Build()
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   scheme.Scheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    scheme.Scheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			ip, err := testRayClusterReconciler.getHeadServiceIP(context.TODO(), testRayCluster)
@@ -1412,10 +2868,11 @@ func TestUpdateStatusObservedGeneration(t *testing.T) {
 
 	// Initialize RayCluster reconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Compare the values of `Generation` and `ObservedGeneration` to check if they match.
@@ -1445,10 +2902,11 @@ func TestReconcile_UpdateClusterState(t *testing.T) {
 	assert.Empty(t, cluster.Status.State, "Cluster state should be empty")
 
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	state := rayv1alpha1.Ready
@@ -1465,10 +2923,11 @@ func TestInconsistentRayClusterStatus(t *testing.T) {
 	_ = rayv1alpha1.AddToScheme(newScheme)
 	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects().Build()
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Mock data
@@ -1583,10 +3042,11 @@ func TestCalculateStatus(t *testing.T) {
 
 	// Initialize a RayCluster reconciler.
 	r := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Test head information
@@ -1639,10 +3099,11 @@ func Test_TerminatedWorkers_NoAutoscaler(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// Since the desired state of the workerGroup is 3 replicas, the controller
@@ -1758,10 +3219,11 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   newScheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// The head Pod will not be deleted because the restart policy is `Always`.
@@ -1833,10 +3295,11 @@ func Test_RunningPods_RayContainerTerminated(t *testing.T) {
 
 	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   newScheme,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 	}
 
 	// The head Pod will be deleted and the controller will return an error
@@ -1959,6 +3422,9 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1alpha1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+	_ = policyv1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
 
 	// Prepare a RayCluster with the GCS FT enabled and Autoscaling disabled.
 	gcsFTEnabledcluster := testRayCluster.DeepCopy()
@@ -2004,10 +3470,11 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 
 			// Initialize the reconciler
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   newScheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    newScheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			rayClusterList := rayv1alpha1.RayClusterList{}
@@ -2063,6 +3530,60 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 	}
 }
 
+func Test_RedisCleanupPolicy(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+	_ = policyv1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.GcsFaultToleranceOptions = &rayv1alpha1.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).Build()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    newScheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
+	}
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	getCluster := func() *rayv1alpha1.RayCluster {
+		instance := &rayv1alpha1.RayCluster{}
+		err := fakeClient.Get(ctx, request.NamespacedName, instance)
+		assert.Nil(t, err)
+		return instance
+	}
+
+	// The Redis cleanup finalizer is added because the default policy is Delete.
+	_, err := testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.Nil(t, err)
+	cluster = getCluster()
+	assert.True(t, controllerutil.ContainsFinalizer(cluster, common.GCSFaultToleranceRedisCleanupFinalizer))
+
+	// The finalizer is removed once the policy is changed to Retain.
+	cluster.Spec.GcsFaultToleranceOptions.RedisCleanupPolicy = rayv1alpha1.RedisCleanupPolicyRetain
+	_, err = testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.Nil(t, err)
+	assert.False(t, controllerutil.ContainsFinalizer(getCluster(), common.GCSFaultToleranceRedisCleanupFinalizer))
+
+	// Invalid options fail the RayCluster before any Pod is created.
+	cluster = getCluster()
+	cluster.Spec.GcsFaultToleranceOptions.RedisAddress = ""
+	_, err = testRayClusterReconciler.rayClusterReconcile(ctx, request, cluster)
+	assert.NotNil(t, err)
+	assert.Equal(t, rayv1alpha1.Failed, getCluster().Status.State)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Empty(t, podList.Items)
+}
+
 func Test_RedisCleanup(t *testing.T) {
 	setupTest(t)
 	newScheme := runtime.NewScheme()
@@ -2173,10 +3694,11 @@ func Test_RedisCleanup(t *testing.T) {
 			}
 
 			testRayClusterReconciler := &RayClusterReconciler{
-				Client:   fakeClient,
-				Recorder: &record.FakeRecorder{},
-				Scheme:   newScheme,
-				Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Recorder:  &record.FakeRecorder{},
+				Scheme:    newScheme,
+				Log:       ctrl.Log.WithName("controllers").WithName("RayCluster"),
 			}
 
 			// Check Job
@@ -12,12 +12,14 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
@@ -37,21 +39,56 @@ const (
 // RayJobReconciler reconciles a RayJob object
 type RayJobReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Log      logr.Logger
-	Recorder record.EventRecorder
+	// APIReader reads the Secrets directly from the API server, so that the manager does not cache every Secret.
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Recorder  record.EventRecorder
+	// KueueEnabled is whether the Kueue CRDs were found when the operator started.
+	KueueEnabled      bool
+	BatchSchedulerMgr *batchscheduler.SchedulerManager
 }
 
 // NewRayJobReconciler returns a new reconcile.Reconciler
 func NewRayJobReconciler(mgr manager.Manager) *RayJobReconciler {
+	log := ctrl.Log.WithName("controllers").WithName("RayJob")
 	return &RayJobReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Log:      ctrl.Log.WithName("controllers").WithName("RayJob"),
-		Recorder: mgr.GetEventRecorderFor("rayjob-controller"),
+		Client:            utils.NewTracingClient(mgr.GetClient()),
+		APIReader:         mgr.GetAPIReader(),
+		Scheme:            mgr.GetScheme(),
+		Log:               log,
+		Recorder:          mgr.GetEventRecorderFor("rayjob-controller"),
+		KueueEnabled:      isKueueInstalled(log),
+		BatchSchedulerMgr: batchscheduler.NewSchedulerManager(mgr.GetConfig()),
 	}
 }
 
+// isKueueInstalled returns whether the Kueue Workload CRD is served by the Kubernetes API server.
+func isKueueInstalled(logger logr.Logger) bool {
+	config, err := ctrl.GetConfig()
+	if err != nil || config == nil {
+		logger.Info("Cannot retrieve config, assuming the Kueue CRDs are not installed")
+		return false
+	}
+	dclient, err := getDiscoveryClient(config)
+	if err != nil || dclient == nil {
+		logger.Info("Cannot retrieve a DiscoveryClient, assuming the Kueue CRDs are not installed")
+		return false
+	}
+	resources, err := dclient.ServerResourcesForGroupVersion(common.WorkloadGVK.GroupVersion().String())
+	if err != nil {
+		logger.Info("The Kueue CRDs are not installed. Queueing with Kueue is disabled.")
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == common.WorkloadGVK.Kind {
+			logger.Info("Detected Kueue CRDs")
+			return true
+		}
+	}
+	return false
+}
+
 // +kubebuilder:rbac:groups=ray.io,resources=rayjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayjobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayjobs/finalizers,verbs=update
@@ -65,6 +102,9 @@ func NewRayJobReconciler(mgr manager.Manager) *RayJobReconciler {
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;list;watch
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
 // Reconcile reads that state of a RayJob object and makes changes based on it
@@ -81,6 +121,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request. Stop reconciliation.
 			r.Log.Info("RayJob resource not found. Ignoring since object must be deleted", "name", request.NamespacedName)
+			common.DeleteRayJobMetrics(request.Namespace, request.Name)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -102,9 +143,17 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	} else {
 		r.Log.Info("RayJob is being deleted", "DeletionTimestamp", rayJobInstance.ObjectMeta.DeletionTimestamp)
 		if isJobPendingOrRunning(rayJobInstance.Status.JobStatus) {
+			authToken := ""
+			rayClusterInstance := &rayv1alpha1.RayCluster{}
+			rayClusterNamespacedName := types.NamespacedName{Namespace: rayJobInstance.Namespace, Name: rayJobInstance.Status.RayClusterName}
+			if err := r.Get(ctx, rayClusterNamespacedName, rayClusterInstance); err != nil {
+				r.Log.Info("Failed to get the RayCluster of RayJob", "error", err)
+			} else if authToken, err = utils.FetchDashboardAuthToken(ctx, r.APIReader, rayClusterInstance); err != nil {
+				r.Log.Info("Failed to get the dashboard auth token for RayJob", "error", err)
+			}
 			rayDashboardClient := utils.GetRayDashboardClientFunc()
-			rayDashboardClient.InitClient(rayJobInstance.Status.DashboardURL)
-			err := rayDashboardClient.StopJob(ctx, rayJobInstance.Status.JobId, &r.Log)
+			rayDashboardClient.InitClient(rayJobInstance.Status.DashboardURL, authToken)
+			err = rayDashboardClient.StopJob(ctx, rayJobInstance.Status.JobId, &r.Log)
 			if err != nil {
 				r.Log.Info("Failed to stop job for RayJob", "error", err)
 			}
@@ -120,6 +169,26 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 	}
 
+	// A RayJob which finished is not validated again, so that its status is kept.
+	if !rayv1alpha1.IsJobTerminal(rayJobInstance.Status.JobStatus) {
+		if err := batchscheduler.ValidateRayJobBatchScheduling(rayJobInstance); err != nil {
+			r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, "InvalidBatchScheduling", err.Error())
+			err = r.updateState(ctx, rayJobInstance, nil, rayJobInstance.Status.JobStatus, rayv1alpha1.JobDeploymentStatusFailedToGetOrCreateRayCluster, err)
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+	}
+
+	// Kueue admits a queued RayJob by unsuspending it.
+	if r.KueueEnabled && common.IsKueueManaged(rayJobInstance) {
+		if err := common.ValidateKueuePodSets(*rayJobInstance.Spec.RayClusterSpec); err != nil {
+			r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, "InvalidKueuePodSets", err.Error())
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+		if updated, err := r.reconcileKueueWorkload(ctx, rayJobInstance); err != nil || updated {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+	}
+
 	// Do not reconcile the RayJob if the deployment status is marked as Complete
 	if rayJobInstance.Status.JobDeploymentStatus == rayv1alpha1.JobDeploymentStatusComplete {
 		r.Log.Info("rayjob is complete, skip reconciliation", "rayjob", rayJobInstance.Name)
@@ -184,6 +253,10 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	// Always update RayClusterStatus along with jobStatus and jobDeploymentStatus updates.
 	rayJobInstance.Status.RayClusterStatus = rayClusterInstance.Status
 
+	if err := r.wakeUpRayClusterIfNeeded(ctx, rayJobInstance, rayClusterInstance); err != nil {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
+
 	clientURL := rayJobInstance.Status.DashboardURL
 	if clientURL == "" {
 		// TODO: dashboard service may be changed. Check it instead of using the same URL always
@@ -197,8 +270,13 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		rayJobInstance.Status.DashboardURL = clientURL
 	}
 
+	authToken, err := utils.FetchDashboardAuthToken(ctx, r.APIReader, rayClusterInstance)
+	if err != nil {
+		r.Log.Error(err, "Failed to get the dashboard auth token", "RayCluster", rayClusterInstance.Name)
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
 	rayDashboardClient := utils.GetRayDashboardClientFunc()
-	rayDashboardClient.InitClient(clientURL)
+	rayDashboardClient.InitClient(clientURL, authToken)
 
 	// Check the current status of ray cluster before submitting.
 	if rayClusterInstance.Status.State != rayv1alpha1.Ready {
@@ -334,6 +412,10 @@ func (r *RayJobReconciler) getOrCreateK8sJob(ctx context.Context, rayJobInstance
 				r.Log.Error(err, "failed to get submitter template")
 				return "", false, err
 			}
+			// The submitter talks to the dashboard through the authenticating proxy of the RayCluster.
+			if common.IsDashboardAuthEnabled(*rayClusterInstance) {
+				common.SetDashboardAuthEnvVars(&submitterTemplate.Spec.Containers[0], rayClusterInstance.Name)
+			}
 			return r.createNewK8sJob(ctx, rayJobInstance, submitterTemplate, rayClusterInstance)
 		}
 
@@ -387,6 +469,13 @@ func (r *RayJobReconciler) getSubmitterTemplate(rayJobInstance *rayv1alpha1.RayJ
 
 // createNewK8sJob creates a new Kubernetes Job. It returns the Job's name and a boolean indicating whether a new Job was created.
 func (r *RayJobReconciler) createNewK8sJob(ctx context.Context, rayJobInstance *rayv1alpha1.RayJob, submitterTemplate v1.PodTemplateSpec, rayClusterInstance *rayv1alpha1.RayCluster) (string, bool, error) {
+	// The submitter Pod joins the gang of the RayCluster created for the RayJob.
+	if EnableBatchScheduler && metav1.IsControlledBy(rayClusterInstance, rayJobInstance) {
+		if err := r.addBatchSchedulingMetadataToSubmitter(rayClusterInstance, &submitterTemplate); err != nil {
+			return "", false, err
+		}
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      rayJobInstance.Name,
@@ -413,6 +502,30 @@ func (r *RayJobReconciler) createNewK8sJob(ctx context.Context, rayJobInstance *
 	return job.Name, true, nil
 }
 
+// addBatchSchedulingMetadataToSubmitter adds the metadata the batch scheduler of the RayCluster adds to the Ray Pods, such
+// as the pod group and scheduler name, to the submitter Pod template.
+func (r *RayJobReconciler) addBatchSchedulingMetadataToSubmitter(rayClusterInstance *rayv1alpha1.RayCluster, submitterTemplate *v1.PodTemplateSpec) error {
+	if r.BatchSchedulerMgr == nil {
+		return nil
+	}
+	scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(rayClusterInstance)
+	if err != nil {
+		return err
+	}
+	pod := v1.Pod{ObjectMeta: *submitterTemplate.ObjectMeta.DeepCopy(), Spec: submitterTemplate.Spec}
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Labels[common.RayNodeGroupLabelKey] = common.RayJobSubmitterGroupName
+	scheduler.AddMetadataToPod(rayClusterInstance, &pod)
+	submitterTemplate.ObjectMeta = pod.ObjectMeta
+	submitterTemplate.Spec = pod.Spec
+	return nil
+}
+
 func (r *RayJobReconciler) deleteCluster(ctx context.Context, rayJobInstance *rayv1alpha1.RayJob) (reconcile.Result, error) {
 	clusterIdentifier := types.NamespacedName{
 		Name:      rayJobInstance.Status.RayClusterName,
@@ -440,6 +553,25 @@ func (r *RayJobReconciler) deleteCluster(ctx context.Context, rayJobInstance *ra
 	return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
 }
 
+// wakeUpRayClusterIfNeeded wakes up the RayCluster of the RayJob if it was hibernated by its idle policy and the job
+// still has to be submitted or run. A finished job, such as one run on a RayCluster selected by spec.clusterSelector,
+// keeps the RayCluster hibernated.
+func (r *RayJobReconciler) wakeUpRayClusterIfNeeded(ctx context.Context, rayJobInstance *rayv1alpha1.RayJob, rayClusterInstance *rayv1alpha1.RayCluster) error {
+	if rayv1alpha1.IsJobTerminal(rayJobInstance.Status.JobStatus) {
+		return nil
+	}
+	if !common.IsRayClusterHibernated(*rayClusterInstance) || common.IsWakeUpRequested(*rayClusterInstance) {
+		return nil
+	}
+	common.RequestWakeUp(rayClusterInstance, time.Now())
+	if err := r.Update(ctx, rayClusterInstance); err != nil {
+		r.Log.Error(err, "Failed to wake up the RayCluster", "RayCluster", rayClusterInstance.Name)
+		return err
+	}
+	r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, "WakeUpRequested", "Requested to wake up RayCluster %s", rayClusterInstance.Name)
+	return nil
+}
+
 // isJobSucceedOrFailed indicates whether the job comes into end status.
 func isJobSucceedOrFailed(status rayv1alpha1.JobStatus) bool {
 	return (status == rayv1alpha1.JobStatusSucceeded) || (status == rayv1alpha1.JobStatusFailed)
@@ -452,11 +584,144 @@ func isJobPendingOrRunning(status rayv1alpha1.JobStatus) bool {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1alpha1.RayJob{}).
 		Owns(&rayv1alpha1.RayCluster{}).
-		Owns(&corev1.Service{}).
-		Complete(r)
+		Owns(&corev1.Service{})
+	if r.KueueEnabled {
+		workload := &unstructured.Unstructured{}
+		workload.SetGroupVersionKind(common.WorkloadGVK)
+		b = b.Owns(workload)
+	}
+	return b.Complete(utils.NewTracingReconciler("RayJob", r))
+}
+
+// reconcileKueueWorkload creates the Kueue Workload of the RayJob, and suspends the RayJob until Kueue admits the Workload.
+// It returns whether the RayJob was updated, in which case the reconciliation continues with the updated RayJob.
+func (r *RayJobReconciler) reconcileKueueWorkload(ctx context.Context, rayJobInstance *rayv1alpha1.RayJob) (bool, error) {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(common.WorkloadGVK)
+	workloadName := utils.GenerateWorkloadName(rayJobInstance.Name)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rayJobInstance.Namespace, Name: workloadName}, workload); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		if isJobSucceedOrFailed(rayJobInstance.Status.JobStatus) {
+			return false, nil
+		}
+		if workload, err = common.BuildWorkloadForRayJob(rayJobInstance); err != nil {
+			return false, err
+		}
+		if err := ctrl.SetControllerReference(rayJobInstance, workload, r.Scheme); err != nil {
+			return false, err
+		}
+		if err := r.Create(ctx, workload); err != nil {
+			return false, err
+		}
+		r.Log.Info("Created Kueue Workload for RayJob", "RayJob", rayJobInstance.Name, "Workload", workloadName)
+		r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, "Created", "Created Workload %s", workloadName)
+	}
+
+	// The quota of a finished RayJob is released by marking its Workload as finished.
+	if isJobSucceedOrFailed(rayJobInstance.Status.JobStatus) {
+		return false, r.finishKueueWorkload(ctx, workload, rayJobInstance.Status.JobStatus)
+	}
+
+	// The pod sets of the Workload follow the RayCluster spec of the RayJob. The RayJob is suspended until the Workload
+	// is recreated and admitted again.
+	admitted := common.IsWorkloadAdmitted(workload)
+	if workload.GetDeletionTimestamp() != nil {
+		admitted = false
+	} else if outdated, err := common.IsWorkloadOutdated(workload, *rayJobInstance.Spec.RayClusterSpec); err != nil {
+		return false, err
+	} else if outdated {
+		if err := r.Delete(ctx, workload); err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		r.Log.Info("Deleted the outdated Kueue Workload of the RayJob", "RayJob", rayJobInstance.Name, "Workload", workloadName)
+		r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, "Deleted", "Deleted Workload %s because the RayCluster spec changed", workloadName)
+		admitted = false
+	}
+	if admitted != rayJobInstance.Spec.Suspend {
+		return false, nil
+	}
+	rayJobInstance.Spec.Suspend = !admitted
+	if err := r.Update(ctx, rayJobInstance); err != nil {
+		return false, err
+	}
+	if admitted {
+		r.Log.Info("Kueue admitted the RayJob", "RayJob", rayJobInstance.Name, "Workload", workloadName)
+		r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, "Admitted", "Workload %s admitted by Kueue", workloadName)
+	} else {
+		r.Log.Info("Suspending the RayJob until Kueue admits it", "RayJob", rayJobInstance.Name, "Workload", workloadName)
+	}
+	return true, nil
+}
+
+// finishKueueWorkload sets the Finished condition of the Workload, so that Kueue releases its quota.
+func (r *RayJobReconciler) finishKueueWorkload(ctx context.Context, workload *unstructured.Unstructured, jobStatus rayv1alpha1.JobStatus) error {
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]interface{}); ok && condition["type"] == common.KueueFinishedCondition {
+			return nil
+		}
+	}
+	conditions = append(conditions, map[string]interface{}{
+		"type":               common.KueueFinishedCondition,
+		"status":             string(metav1.ConditionTrue),
+		"reason":             "JobFinished",
+		"message":            fmt.Sprintf("RayJob finished with status %s", jobStatus),
+		"lastTransitionTime": metav1.Now().UTC().Format(time.RFC3339),
+	})
+	if err := unstructured.SetNestedSlice(workload.Object, conditions, "status", "conditions"); err != nil {
+		return err
+	}
+	return r.Status().Update(ctx, workload)
+}
+
+// injectKueueAdmission schedules the Pods of the RayCluster of an admitted RayJob on the nodes of the ResourceFlavors Kueue
+// assigned to each group.
+func (r *RayJobReconciler) injectKueueAdmission(ctx context.Context, rayJobInstance *rayv1alpha1.RayJob, rayClusterInstance *rayv1alpha1.RayCluster) error {
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(common.WorkloadGVK)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rayJobInstance.Namespace, Name: utils.GenerateWorkloadName(rayJobInstance.Name)}, workload); err != nil {
+		return err
+	}
+	podSetInfos, err := getKueuePodSetInfos(ctx, r.Client, workload)
+	if err != nil {
+		return err
+	}
+	common.InjectKueuePodSetInfo(&rayClusterInstance.Spec, podSetInfos)
+	return nil
+}
+
+// getKueuePodSetInfos returns the node labels and tolerations of the ResourceFlavors Kueue assigned to each pod set of
+// an admitted Workload.
+func getKueuePodSetInfos(ctx context.Context, cli client.Client, workload *unstructured.Unstructured) (map[string]common.KueuePodSetInfo, error) {
+	podSetInfos := map[string]common.KueuePodSetInfo{}
+	for podSetName, flavorNames := range common.GetWorkloadFlavors(workload) {
+		podSetInfo := common.KueuePodSetInfo{}
+		for _, flavorName := range flavorNames {
+			flavor := &unstructured.Unstructured{}
+			flavor.SetGroupVersionKind(common.ResourceFlavorGVK)
+			if err := cli.Get(ctx, types.NamespacedName{Name: flavorName}, flavor); err != nil {
+				return nil, err
+			}
+			info, err := common.GetResourceFlavorPodSetInfo(flavor)
+			if err != nil {
+				return nil, err
+			}
+			if len(info.NodeSelector) > 0 && podSetInfo.NodeSelector == nil {
+				podSetInfo.NodeSelector = map[string]string{}
+			}
+			for key, value := range info.NodeSelector {
+				podSetInfo.NodeSelector[key] = value
+			}
+			podSetInfo.Tolerations = append(podSetInfo.Tolerations, info.Tolerations...)
+		}
+		podSetInfos[podSetName] = podSetInfo
+	}
+	return podSetInfos, nil
 }
 
 func (r *RayJobReconciler) setRayJobIdAndRayClusterNameIfNeed(ctx context.Context, rayJob *rayv1alpha1.RayJob) error {
@@ -502,6 +767,7 @@ func (r *RayJobReconciler) updateState(ctx context.Context, rayJob *rayv1alpha1.
 	}
 
 	r.Log.Info("UpdateState", "oldJobStatus", rayJob.Status.JobStatus, "newJobStatus", jobStatus, "oldJobDeploymentStatus", rayJob.Status.JobDeploymentStatus, "newJobDeploymentStatus", jobDeploymentStatus)
+	oldJobStatus, oldJobDeploymentStatus := rayJob.Status.JobStatus, rayJob.Status.JobDeploymentStatus
 	rayJob.Status.JobStatus = jobStatus
 	rayJob.Status.JobDeploymentStatus = jobDeploymentStatus
 	if jobInfo != nil {
@@ -520,6 +786,7 @@ func (r *RayJobReconciler) updateState(ctx context.Context, rayJob *rayv1alpha1.
 	if errStatus := r.Status().Update(ctx, rayJob); errStatus != nil {
 		return fmtErrors.Errorf("combined error: %v %v", err, errStatus)
 	}
+	common.ObserveRayJobStatus(rayJob, oldJobStatus, oldJobDeploymentStatus)
 	return err
 }
 
@@ -623,6 +890,12 @@ func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, ra
 			// Error construct the RayCluster object - requeue the request.
 			return nil, err
 		}
+		if r.KueueEnabled && common.IsKueueManaged(rayJobInstance) {
+			if err := r.injectKueueAdmission(ctx, rayJobInstance, rayClusterInstance); err != nil {
+				r.Log.Error(err, "unable to apply the Kueue admission to the rayCluster")
+				return nil, err
+			}
+		}
 		if err := r.Create(ctx, rayClusterInstance); err != nil {
 			r.Log.Error(err, "unable to create rayCluster for rayJob", "rayCluster", rayClusterInstance)
 			// Error creating the RayCluster object - requeue the request.
@@ -649,6 +922,18 @@ func (r *RayJobReconciler) constructRayClusterForRayJob(rayJobInstance *rayv1alp
 		},
 		Spec: *rayJobInstance.Spec.RayClusterSpec.DeepCopy(),
 	}
+	if rayCluster.Spec.BatchScheduling == nil && rayJobInstance.Spec.BatchScheduling != nil {
+		rayCluster.Spec.BatchScheduling = rayJobInstance.Spec.BatchScheduling.DeepCopy()
+	}
+	if EnableBatchScheduler && common.GetBatchSchedulerName(rayCluster) != "" {
+		submitterTemplate := common.GetDefaultSubmitterTemplate(rayJobInstance)
+		if rayJobInstance.Spec.SubmitterPodTemplate != nil {
+			submitterTemplate = *rayJobInstance.Spec.SubmitterPodTemplate
+		}
+		if err := common.SetRayJobSubmitterResources(rayCluster, submitterTemplate); err != nil {
+			return nil, err
+		}
+	}
 
 	// Set the ownership in order to do the garbage collection by k8s.
 	if err := ctrl.SetControllerReference(rayJobInstance, rayCluster, r.Scheme); err != nil {
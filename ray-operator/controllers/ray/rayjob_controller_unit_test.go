@@ -5,12 +5,20 @@ import (
 	"testing"
 
 	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -47,10 +55,11 @@ func TestGetOrCreateK8sJob(t *testing.T) {
 	ctx := context.TODO()
 
 	rayJobReconciler := &RayJobReconciler{
-		Client:   fakeClient,
-		Log:      ctrl.Log.WithName("controllers").WithName("RayJob"),
-		Scheme:   newScheme,
-		Recorder: &record.FakeRecorder{},
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Scheme:    newScheme,
+		Recorder:  &record.FakeRecorder{},
 	}
 
 	retrievedJobName, wasCreated, err := rayJobReconciler.getOrCreateK8sJob(ctx, rayJob, rayCluster)
@@ -70,6 +79,96 @@ func TestGetOrCreateK8sJob(t *testing.T) {
 	assert.Equal(t, "test-rayjob", retrievedJobName)
 }
 
+func TestWakeUpRayClusterIfNeeded(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	rayCluster := &rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shared-raycluster",
+			Namespace:   "default",
+			Annotations: map[string]string{common.RayClusterHibernatedReplicasAnnotationKey: "{}"},
+		},
+	}
+	rayJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1alpha1.RayJobSpec{
+			ClusterSelector: map[string]string{RayJobDefaultClusterSelectorKey: rayCluster.Name},
+		},
+		Status: rayv1alpha1.RayJobStatus{
+			JobStatus:           rayv1alpha1.JobStatusSucceeded,
+			JobDeploymentStatus: rayv1alpha1.JobDeploymentStatusRunning,
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCluster).Build()
+	ctx := context.TODO()
+	rayJobReconciler := &RayJobReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Scheme:    newScheme,
+		Recorder:  &record.FakeRecorder{},
+	}
+	getCluster := func() *rayv1alpha1.RayCluster {
+		cluster := &rayv1alpha1.RayCluster{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: rayCluster.Name}, cluster)
+		assert.NoError(t, err)
+		return cluster
+	}
+
+	// A finished job keeps the shared RayCluster hibernated.
+	err := rayJobReconciler.wakeUpRayClusterIfNeeded(ctx, rayJob, getCluster())
+	assert.NoError(t, err)
+	assert.False(t, common.IsWakeUpRequested(*getCluster()))
+
+	// A job that still has to run wakes it up.
+	rayJob.Status.JobStatus = rayv1alpha1.JobStatusPending
+	err = rayJobReconciler.wakeUpRayClusterIfNeeded(ctx, rayJob, getCluster())
+	assert.NoError(t, err)
+	assert.True(t, common.IsWakeUpRequested(*getCluster()))
+}
+
+func TestReconcileInvalidBatchScheduling(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	rayJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Spec: rayv1alpha1.RayJobSpec{
+			Entrypoint:      "python test.py",
+			BatchScheduling: &rayv1alpha1.BatchSchedulingOptions{SchedulerName: "volcan0"},
+			RayClusterSpec:  &rayv1alpha1.RayClusterSpec{},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayJob).Build()
+	ctx := context.TODO()
+	recorder := record.NewFakeRecorder(10)
+	rayJobReconciler := &RayJobReconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Scheme:    newScheme,
+		Recorder:  recorder,
+	}
+
+	// The RayJob fails before its RayCluster is created.
+	_, err := rayJobReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: rayJob.Name}})
+	assert.NotNil(t, err)
+	assert.Contains(t, <-recorder.Events, "InvalidBatchScheduling")
+	updatedRayJob := &rayv1alpha1.RayJob{}
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: rayJob.Name}, updatedRayJob))
+	assert.Equal(t, rayv1alpha1.JobDeploymentStatusFailedToGetOrCreateRayCluster, updatedRayJob.Status.JobDeploymentStatus)
+	assert.Empty(t, updatedRayJob.Status.RayClusterName)
+}
+
 func TestGetSubmitterTemplate(t *testing.T) {
 	// RayJob instance with user-provided submitter pod template.
 	rayJobInstanceWithTemplate := &rayv1alpha1.RayJob{
@@ -147,3 +246,172 @@ func TestGetSubmitterTemplate(t *testing.T) {
 	}
 	assert.True(t, found)
 }
+
+func TestReconcileKueueWorkload(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	rayJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+			Labels:    map[string]string{common.KueueQueueNameLabelKey: "user-queue"},
+		},
+		Spec: rayv1alpha1.RayJobSpec{
+			RayClusterSpec: &rayv1alpha1.RayClusterSpec{
+				HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}},
+					},
+				},
+				WorkerGroupSpecs: []rayv1alpha1.WorkerGroupSpec{
+					{
+						GroupName: "gpu-group",
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+	flavor := &unstructured.Unstructured{}
+	flavor.SetGroupVersionKind(common.ResourceFlavorGVK)
+	flavor.SetName("gpu")
+	flavor.Object["spec"] = map[string]interface{}{
+		"nodeLabels": map[string]interface{}{"accelerator": "nvidia-tesla-t4"},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayJob, flavor).Build()
+	ctx := context.TODO()
+	r := &RayJobReconciler{
+		Client:       fakeClient,
+		APIReader:    fakeClient,
+		Log:          ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Scheme:       newScheme,
+		Recorder:     &record.FakeRecorder{},
+		KueueEnabled: true,
+	}
+	workloadNamespacedName := types.NamespacedName{Namespace: "default", Name: utils.GenerateWorkloadName(rayJob.Name)}
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(common.WorkloadGVK)
+
+	// The Workload is created and the RayJob is suspended until it is admitted.
+	updated, err := r.reconcileKueueWorkload(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.True(t, rayJob.Spec.Suspend)
+	assert.NoError(t, fakeClient.Get(ctx, workloadNamespacedName, workload))
+	assert.True(t, metav1.IsControlledBy(workload, rayJob))
+
+	updated, err = r.reconcileKueueWorkload(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	// The RayJob is unsuspended once Kueue admits the Workload.
+	workload.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": common.KueueAdmittedCondition, "status": "True"},
+		},
+		"admission": map[string]interface{}{
+			"podSetAssignments": []interface{}{
+				map[string]interface{}{"name": "gpu-group", "flavors": map[string]interface{}{"nvidia.com/gpu": "gpu"}},
+			},
+		},
+	}
+	assert.NoError(t, fakeClient.Update(ctx, workload))
+	updated, err = r.reconcileKueueWorkload(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, rayJob.Spec.Suspend)
+
+	// The node labels of the assigned flavors are injected into the RayCluster.
+	rayCluster := &rayv1alpha1.RayCluster{Spec: *rayJob.Spec.RayClusterSpec.DeepCopy()}
+	assert.NoError(t, r.injectKueueAdmission(ctx, rayJob, rayCluster))
+	assert.Equal(t, "nvidia-tesla-t4", rayCluster.Spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector["accelerator"])
+	assert.Nil(t, rayCluster.Spec.HeadGroupSpec.Template.Spec.NodeSelector)
+	assert.Nil(t, rayJob.Spec.RayClusterSpec.WorkerGroupSpecs[0].Template.Spec.NodeSelector)
+
+	// The Workload is recreated when the RayCluster spec changes, and the RayJob is suspended until it is admitted again.
+	rayJob.Spec.RayClusterSpec.WorkerGroupSpecs[0].Replicas = pointer.Int32Ptr(2)
+	updated, err = r.reconcileKueueWorkload(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.True(t, rayJob.Spec.Suspend)
+	assert.True(t, k8serrors.IsNotFound(fakeClient.Get(ctx, workloadNamespacedName, workload)))
+
+	updated, err = r.reconcileKueueWorkload(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	workload = &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(common.WorkloadGVK)
+	assert.NoError(t, fakeClient.Get(ctx, workloadNamespacedName, workload))
+	podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	assert.Equal(t, int64(2), podSets[1].(map[string]interface{})["count"])
+
+	// The Workload is marked as finished when the RayJob finishes.
+	rayJob.Status.JobStatus = rayv1alpha1.JobStatusSucceeded
+	updated, err = r.reconcileKueueWorkload(ctx, rayJob)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.NoError(t, fakeClient.Get(ctx, workloadNamespacedName, workload))
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, common.KueueFinishedCondition, conditions[0].(map[string]interface{})["type"])
+}
+
+func TestCreateNewK8sJobWithBatchScheduler(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	EnableBatchScheduler = true
+	defer func() { EnableBatchScheduler = false }()
+
+	rayJob := &rayv1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+			UID:       "test-rayjob-uid",
+		},
+		Spec: rayv1alpha1.RayJobSpec{
+			BatchScheduling: &rayv1alpha1.BatchSchedulingOptions{SchedulerName: yunikorn.GetPluginName()},
+			RayClusterSpec: &rayv1alpha1.RayClusterSpec{
+				HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+					Replicas: pointer.Int32Ptr(1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.6.3"}}},
+					},
+				},
+			},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayJob).Build()
+	ctx := context.TODO()
+	r := &RayJobReconciler{
+		Client:            fakeClient,
+		APIReader:         fakeClient,
+		Log:               ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Scheme:            newScheme,
+		Recorder:          &record.FakeRecorder{},
+		BatchSchedulerMgr: batchscheduler.NewSchedulerManager(nil),
+	}
+
+	// The RayCluster created for the RayJob reserves the resources of the submitter Pod.
+	rayCluster, err := r.constructRayClusterForRayJob(rayJob, "test-raycluster")
+	assert.NoError(t, err)
+	assert.Equal(t, yunikorn.GetPluginName(), rayCluster.Spec.BatchScheduling.SchedulerName)
+	_, ok := common.GetRayJobSubmitterResources(rayCluster)
+	assert.True(t, ok)
+
+	// The submitter Pod is submitted to the batch scheduler of the RayCluster.
+	_, _, err = r.createNewK8sJob(ctx, rayJob, common.GetDefaultSubmitterTemplate(rayJob), rayCluster)
+	assert.NoError(t, err)
+	job := &batchv1.Job{}
+	assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test-rayjob"}, job))
+	assert.Equal(t, yunikorn.GetPluginName(), job.Spec.Template.Spec.SchedulerName)
+	assert.Equal(t, common.RayJobSubmitterGroupName, job.Spec.Template.Annotations[yunikorn.TaskGroupNameAnnotationKey])
+	assert.NotEmpty(t, job.Spec.Template.Labels[yunikorn.PodApplicationIDLabelKey])
+}
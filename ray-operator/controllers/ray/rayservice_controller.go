@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/json"
@@ -21,14 +22,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -45,6 +51,9 @@ const (
 	ServiceRestartRequeueDuration      = 10 * time.Second
 	RayClusterDeletionDelayDuration    = 60 * time.Second
 	DeploymentUnhealthySecondThreshold = 300.0 // Dashboard agent related health check.
+
+	// Definition of an index field for the name of the ConfigMap referenced by `serveConfigRef`
+	serveConfigRefIndexField = "spec.serveConfigRef.name"
 )
 
 // RayServiceReconciler reconciles a RayService object
@@ -57,21 +66,59 @@ type RayServiceReconciler struct {
 	// To avoid reapplying the same config repeatedly, cache the config in this map.
 	ServeConfigs                 cmap.ConcurrentMap
 	RayClusterDeletionTimestamps cmap.ConcurrentMap
+	// Time of the first of the consecutive failed Serve HTTP proxy probes of each Pod, used to avoid flapping the
+	// Serve readiness gate on a single failed probe.
+	ServeProxyProbeFailures cmap.ConcurrentMap
+	// APIReader reads the objects that are not cached by the manager directly from the API server.
+	APIReader client.Reader
+	// GRPCRouteServed is true if the Gateway API GRPCRoute CRD was found when the operator started.
+	GRPCRouteServed bool
 }
 
 // NewRayServiceReconciler returns a new reconcile.Reconciler
 func NewRayServiceReconciler(mgr manager.Manager) *RayServiceReconciler {
+	log := ctrl.Log.WithName("controllers").WithName("RayService")
 	return &RayServiceReconciler{
-		Client:                       mgr.GetClient(),
+		Client:                       utils.NewTracingClient(mgr.GetClient()),
 		Scheme:                       mgr.GetScheme(),
-		Log:                          ctrl.Log.WithName("controllers").WithName("RayService"),
+		Log:                          log,
 		Recorder:                     mgr.GetEventRecorderFor("rayservice-controller"),
 		ServeConfigs:                 cmap.New(),
 		RayClusterDeletionTimestamps: cmap.New(),
+		ServeProxyProbeFailures:      cmap.New(),
+		APIReader:                    mgr.GetAPIReader(),
+		GRPCRouteServed:              isGRPCRouteServed(log),
 	}
 }
 
+// isGRPCRouteServed returns true if the Kubernetes API server serves the Gateway API GRPCRoute.
+func isGRPCRouteServed(logger logr.Logger) bool {
+	config, err := ctrl.GetConfig()
+	if err != nil || config == nil {
+		logger.Info("Cannot retrieve config, assuming the GRPCRoute CRD is not installed")
+		return false
+	}
+	dclient, err := getDiscoveryClient(config)
+	if err != nil || dclient == nil {
+		logger.Info("Cannot retrieve a DiscoveryClient, assuming the GRPCRoute CRD is not installed")
+		return false
+	}
+	resources, err := dclient.ServerResourcesForGroupVersion(common.GRPCRouteGVK.GroupVersion().String())
+	if err != nil {
+		logger.Info("The Gateway API CRDs are not installed. GRPCRoutes for RayServices are disabled.")
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Kind == common.GRPCRouteGVK.Kind {
+			logger.Info("Detected the GRPCRoute CRD")
+			return true
+		}
+	}
+	return false
+}
+
 // +kubebuilder:rbac:groups=ray.io,resources=rayservices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayservices/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
@@ -82,6 +129,7 @@ func NewRayServiceReconciler(mgr manager.Manager) *RayServiceReconciler {
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
@@ -108,10 +156,18 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 
 	// Resolve the CR from request.
 	if rayServiceInstance, err = r.getRayServiceInstance(ctx, request); err != nil {
+		if errors.IsNotFound(err) {
+			common.DeleteRayServiceMetrics(request.Namespace, request.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	if rayServiceInstance.Spec.ServeConfigV2 != "" && !reflect.DeepEqual(rayServiceInstance.Spec.ServeDeploymentGraphSpec, rayv1alpha1.ServeDeploymentGraphSpec{}) {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, fmt.Errorf("Found non-nil specifications for both serveConfigV1 and serveConfigV2. Please specify only one of the fields.")
+	if err = validateRayServiceSpec(rayServiceInstance); err != nil {
+		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+	}
+	var serveConfigResourceVersion string
+	if serveConfigResourceVersion, err = r.resolveServeConfigRef(ctx, rayServiceInstance); err != nil {
+		err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToGetServeConfig, err)
+		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 	}
 	originalRayServiceInstance := rayServiceInstance.DeepCopy()
 	r.cleanUpServeConfigCache(rayServiceInstance)
@@ -148,7 +204,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	if activeRayClusterInstance != nil && pendingRayClusterInstance == nil {
 		logger.Info("Reconciling the Serve component. Only the active Ray cluster exists.")
 		rayServiceInstance.Status.PendingServiceStatus = rayv1alpha1.RayServiceStatus{}
-		if ctrlResult, isHealthy, isReady, err = r.reconcileServe(ctx, rayServiceInstance, activeRayClusterInstance, true, logger); err != nil {
+		if ctrlResult, isHealthy, isReady, err = r.reconcileServe(ctx, rayServiceInstance, activeRayClusterInstance, true, serveConfigResourceVersion, logger); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
 			return ctrlResult, nil
 		}
@@ -159,13 +215,13 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 			logger.Error(err, "Failed to update active Ray cluster's status.")
 		}
 
-		if ctrlResult, isHealthy, isReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false, logger); err != nil {
+		if ctrlResult, isHealthy, isReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false, serveConfigResourceVersion, logger); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
 			return ctrlResult, nil
 		}
 	} else if activeRayClusterInstance == nil && pendingRayClusterInstance != nil {
 		rayServiceInstance.Status.ActiveServiceStatus = rayv1alpha1.RayServiceStatus{}
-		if ctrlResult, isHealthy, isReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false, logger); err != nil {
+		if ctrlResult, isHealthy, isReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false, serveConfigResourceVersion, logger); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
 			return ctrlResult, nil
 		}
@@ -209,7 +265,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToUpdateService, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 		}
-		if err := r.labelHealthyServePods(ctx, rayClusterInstance); err != nil {
+		if err := r.updateServeReadiness(ctx, rayClusterInstance); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToUpdateServingPodLabel, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 		}
@@ -217,6 +273,18 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToUpdateService, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
 		}
+		if err := r.reconcileServeApplicationServices(ctx, rayServiceInstance, rayClusterInstance); err != nil {
+			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToUpdateService, err)
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		}
+		if err := r.reconcileServeGRPCIngress(ctx, rayServiceInstance, rayClusterInstance); err != nil {
+			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToUpdateIngress, err)
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		}
+		if err := r.reconcileServeGRPCRoute(ctx, rayServiceInstance, rayClusterInstance); err != nil {
+			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToUpdateIngress, err)
+			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		}
 	}
 
 	// Final status update for any CR modification.
@@ -240,6 +308,11 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(oldStatus rayv1alpha
 		return true
 	}
 
+	if oldStatus.ServeConfigResourceVersion != newStatus.ServeConfigResourceVersion {
+		r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService ServeConfigResourceVersion changed from %s to %s", oldStatus.ServeConfigResourceVersion, newStatus.ServeConfigResourceVersion))
+		return true
+	}
+
 	if oldStatus.DashboardStatus.IsHealthy != newStatus.DashboardStatus.IsHealthy {
 		r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService DashboardStatus changed from %v to %v", oldStatus.DashboardStatus, newStatus.DashboardStatus))
 		return true
@@ -263,6 +336,9 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(oldStatus rayv1alpha
 		} else if oldAppStatus.Message != newAppStatus.Message {
 			r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService application %s status message changed from %v to %v", appName, oldAppStatus.Message, newAppStatus.Message))
 			return true
+		} else if oldAppStatus.ServiceEndpoint != newAppStatus.ServiceEndpoint {
+			r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService application %s service endpoint changed from %v to %v", appName, oldAppStatus.ServiceEndpoint, newAppStatus.ServiceEndpoint))
+			return true
 		}
 
 		if len(oldAppStatus.Deployments) != len(newAppStatus.Deployments) {
@@ -282,6 +358,16 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(oldStatus rayv1alpha
 			} else if oldDeploymentStatus.Message != newDeploymentStatus.Message {
 				r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService deployment status message changed from %v to %v", oldDeploymentStatus.Message, newDeploymentStatus.Message))
 				return true
+			} else if !reflect.DeepEqual(oldDeploymentStatus.TargetReplicas, newDeploymentStatus.TargetReplicas) ||
+				!reflect.DeepEqual(oldDeploymentStatus.RunningReplicas, newDeploymentStatus.RunningReplicas) {
+				r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService deployment %s replicas changed", deploymentName))
+				return true
+			} else if oldDeploymentStatus.AutoscalingConfig != newDeploymentStatus.AutoscalingConfig {
+				r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService deployment autoscaling config changed from %v to %v", oldDeploymentStatus.AutoscalingConfig, newDeploymentStatus.AutoscalingConfig))
+				return true
+			} else if len(oldDeploymentStatus.ScaleEvents) != len(newDeploymentStatus.ScaleEvents) {
+				r.Log.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService deployment %s has new scale events", deploymentName))
+				return true
 			}
 		}
 	}
@@ -311,6 +397,16 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatuses(oldStatus rayv1alp
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &rayv1alpha1.RayService{}, serveConfigRefIndexField, func(rawObj client.Object) []string {
+		rayService := rawObj.(*rayv1alpha1.RayService)
+		if rayService.Spec.ServeConfigRef == nil {
+			return nil
+		}
+		return []string{rayService.Spec.ServeConfigRef.Name}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1alpha1.RayService{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
@@ -320,7 +416,69 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&rayv1alpha1.RayCluster{}).
 		Owns(&corev1.Service{}).
 		Owns(&networkingv1.Ingress{}).
-		Complete(r)
+		// Only the metadata of the ConfigMaps is cached. See `resolveServeConfigRef` for how their data is read.
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.rayServicesForConfigMap), builder.OnlyMetadata).
+		Complete(utils.NewTracingReconciler("RayService", r))
+}
+
+// rayServicesForConfigMap maps a ConfigMap to the RayServices whose `serveConfigRef` references it, so that
+// the Serve applications are updated when the ConfigMap is edited.
+func (r *RayServiceReconciler) rayServicesForConfigMap(configMap client.Object) []reconcile.Request {
+	rayServiceList := rayv1alpha1.RayServiceList{}
+	if err := r.List(context.Background(), &rayServiceList, client.InNamespace(configMap.GetNamespace()),
+		client.MatchingFields{serveConfigRefIndexField: configMap.GetName()}); err != nil {
+		r.Log.Error(err, "Fail to list RayServices for ConfigMap", "ConfigMap", configMap.GetName())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, rayService := range rayServiceList.Items {
+		if rayService.Spec.ServeConfigRef != nil && rayService.Spec.ServeConfigRef.Name == configMap.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: rayService.Namespace, Name: rayService.Name},
+			})
+		}
+	}
+	return requests
+}
+
+// validateRayServiceSpec checks that exactly one source of the Serve config is set.
+func validateRayServiceSpec(rayServiceInstance *rayv1alpha1.RayService) error {
+	hasServeConfigV1 := !reflect.DeepEqual(rayServiceInstance.Spec.ServeDeploymentGraphSpec, rayv1alpha1.ServeDeploymentGraphSpec{})
+	if rayServiceInstance.Spec.ServeConfigV2 != "" && hasServeConfigV1 {
+		return fmt.Errorf("Found non-nil specifications for both serveConfigV1 and serveConfigV2. Please specify only one of the fields.")
+	}
+	if rayServiceInstance.Spec.ServeConfigRef != nil && (rayServiceInstance.Spec.ServeConfigV2 != "" || hasServeConfigV1) {
+		return fmt.Errorf("Found non-nil specifications for serveConfigRef and serveConfigV1 or serveConfigV2. Please specify only one of the fields.")
+	}
+	if rayServiceInstance.Spec.ServeConfigRef != nil && (rayServiceInstance.Spec.ServeConfigRef.Name == "" || rayServiceInstance.Spec.ServeConfigRef.Key == "") {
+		return fmt.Errorf("Both serveConfigRef.name and serveConfigRef.key must be set.")
+	}
+	return nil
+}
+
+// resolveServeConfigRef reads the Serve config referenced by `serveConfigRef` into `serveConfigV2` of the in-memory
+// RayService, so that the rest of the reconciliation treats both sources the same way. The spec is never written back
+// to the API server. It returns the resourceVersion of the ConfigMap, or an empty string if `serveConfigRef` is not set.
+// The ConfigMap is read through the uncached APIReader, so that the manager does not cache every ConfigMap.
+func (r *RayServiceReconciler) resolveServeConfigRef(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService) (string, error) {
+	serveConfigRef := rayServiceInstance.Spec.ServeConfigRef
+	if serveConfigRef == nil {
+		return "", nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.APIReader.Get(ctx, client.ObjectKey{Namespace: rayServiceInstance.Namespace, Name: serveConfigRef.Name}, configMap); err != nil {
+		r.Log.Error(err, "Fail to get the ConfigMap referenced by serveConfigRef", "ConfigMap", serveConfigRef.Name)
+		return "", err
+	}
+
+	serveConfig, ok := configMap.Data[serveConfigRef.Key]
+	if !ok || serveConfig == "" {
+		return "", fmt.Errorf("Key %s is not found or empty in ConfigMap %s/%s referenced by serveConfigRef", serveConfigRef.Key, configMap.Namespace, configMap.Name)
+	}
+	rayServiceInstance.Spec.ServeConfigV2 = serveConfig
+	return configMap.ResourceVersion, nil
 }
 
 func (r *RayServiceReconciler) getRayServiceInstance(ctx context.Context, request ctrl.Request) (*rayv1alpha1.RayService, error) {
@@ -367,6 +525,9 @@ func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServi
 	}
 
 	if r.shouldPrepareNewRayCluster(rayServiceInstance, activeRayCluster) {
+		if activeRayCluster != nil {
+			common.RayServiceUpgradesCounterInc(rayServiceInstance.Namespace, rayServiceInstance.Name)
+		}
 		r.markRestart(rayServiceInstance)
 		return activeRayCluster, nil, nil
 	}
@@ -599,7 +760,14 @@ func (r *RayServiceReconciler) constructRayClusterForRayService(rayService *rayv
 			Name:        rayClusterName,
 			Namespace:   rayService.Namespace,
 		},
-		Spec: rayService.Spec.RayClusterSpec,
+		Spec: *rayService.Spec.RayClusterSpec.DeepCopy(),
+	}
+
+	// Only Pods with a healthy Serve HTTP proxy should receive traffic from the serve service. The RayService controller
+	// reports the proxy health through a readiness gate. See `updateServeReadiness` for more details.
+	addServeReadinessGate(&rayCluster.Spec.HeadGroupSpec.Template)
+	for i := range rayCluster.Spec.WorkerGroupSpecs {
+		addServeReadinessGate(&rayCluster.Spec.WorkerGroupSpecs[i].Template)
 	}
 
 	// Set the ownership in order to do the garbage collection by k8s.
@@ -802,6 +970,7 @@ func (r *RayServiceReconciler) getAndCheckServeStatus(ctx context.Context, dashb
 			LastUpdateTime:       &timeNow,
 			HealthLastUpdateTime: &timeNow,
 			Deployments:          make(map[string]rayv1alpha1.ServeDeploymentStatus),
+			ServiceEndpoint:      prevApplicationStatus.ServiceEndpoint,
 		}
 
 		// `isHealthy` is used to determine whether restart the RayCluster or not. If the serve application is `UNHEALTHY` or `DEPLOY_FAILED`
@@ -854,6 +1023,7 @@ func (r *RayServiceReconciler) getAndCheckServeStatus(ctx context.Context, dashb
 					}
 				}
 			}
+			setServeDeploymentScaleStatus(&deploymentStatus, deployment, prevApplicationStatus.Deployments[deploymentName], timeNow)
 			applicationStatus.Deployments[deploymentName] = deploymentStatus
 		}
 		newApplications[appName] = applicationStatus
@@ -868,6 +1038,57 @@ func (r *RayServiceReconciler) getAndCheckServeStatus(ctx context.Context, dashb
 	return isHealthy, isReady, nil
 }
 
+// setServeDeploymentScaleStatus copies the replica counts and the autoscaling config of a Serve deployment into its status,
+// and records a scale event when the target number of replicas differs from the previous status.
+func setServeDeploymentScaleStatus(deploymentStatus *rayv1alpha1.ServeDeploymentStatus, deployment utils.ServeDeploymentStatus, prevStatus rayv1alpha1.ServeDeploymentStatus, timeNow metav1.Time) {
+	// Replica information is only available from the Serve details API used by multi-application RayServices.
+	if deployment.DeploymentConfig == nil && deployment.TargetNumReplicas == nil {
+		return
+	}
+
+	if deployment.TargetNumReplicas != nil {
+		targetReplicas := *deployment.TargetNumReplicas
+		deploymentStatus.TargetReplicas = &targetReplicas
+	} else if deployment.DeploymentConfig.AutoscalingConfig == nil && deployment.DeploymentConfig.NumReplicas != nil {
+		// Older Ray versions do not report the target; without autoscaling it is the configured number of replicas.
+		targetReplicas := *deployment.DeploymentConfig.NumReplicas
+		deploymentStatus.TargetReplicas = &targetReplicas
+	}
+
+	runningReplicas := int32(0)
+	for _, replica := range deployment.Replicas {
+		if replica.State == common.ServeReplicaStateRunning {
+			runningReplicas++
+		}
+	}
+	deploymentStatus.RunningReplicas = &runningReplicas
+
+	if deployment.DeploymentConfig != nil && len(deployment.DeploymentConfig.AutoscalingConfig) > 0 {
+		if autoscalingConfig, err := json.Marshal(deployment.DeploymentConfig.AutoscalingConfig); err == nil {
+			deploymentStatus.AutoscalingConfig = string(autoscalingConfig)
+		}
+	}
+
+	deploymentStatus.ScaleEvents = prevStatus.ScaleEvents
+	if prevStatus.TargetReplicas != nil && deploymentStatus.TargetReplicas != nil && *prevStatus.TargetReplicas != *deploymentStatus.TargetReplicas {
+		trigger := deployment.StatusTrigger
+		if trigger == "" {
+			trigger = deployment.Status
+		}
+		scaleEvents := append([]rayv1alpha1.ServeDeploymentScaleEvent{}, prevStatus.ScaleEvents...)
+		scaleEvents = append(scaleEvents, rayv1alpha1.ServeDeploymentScaleEvent{
+			Time:         timeNow,
+			FromReplicas: *prevStatus.TargetReplicas,
+			ToReplicas:   *deploymentStatus.TargetReplicas,
+			Trigger:      trigger,
+		})
+		if len(scaleEvents) > common.MaxServeDeploymentScaleEvents {
+			scaleEvents = scaleEvents[len(scaleEvents)-common.MaxServeDeploymentScaleEvents:]
+		}
+		deploymentStatus.ScaleEvents = scaleEvents
+	}
+}
+
 func (r *RayServiceReconciler) generateConfigKey(rayServiceInstance *rayv1alpha1.RayService, clusterName string) string {
 	return r.generateConfigKeyPrefix(rayServiceInstance) + clusterName
 }
@@ -1025,6 +1246,225 @@ func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayService
 	return nil
 }
 
+// reconcileServeApplicationServices creates, updates, and deletes the per-application serve services (and their
+// ingress) so that they match the applications in `serveConfigV2`, and records each application's endpoint in the
+// status of the RayCluster that serves the traffic.
+func (r *RayServiceReconciler) reconcileServeApplicationServices(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster) error {
+	enabled := rayServiceInstance.Spec.ServeApplicationServices != nil && r.determineServeConfigType(rayServiceInstance) == utils.MULTI_APP
+
+	desiredSvcs := []*corev1.Service{}
+	if enabled {
+		var err error
+		if desiredSvcs, err = common.BuildServeServicesForApplications(*rayServiceInstance, *rayClusterInstance); err != nil {
+			return err
+		}
+	}
+
+	desiredSvcNames := make(map[string]struct{}, len(desiredSvcs))
+	for _, newSvc := range desiredSvcs {
+		desiredSvcNames[newSvc.Name] = struct{}{}
+
+		oldSvc := &corev1.Service{}
+		err := r.Get(ctx, client.ObjectKey{Name: newSvc.Name, Namespace: rayServiceInstance.Namespace}, oldSvc)
+		if errors.IsNotFound(err) {
+			if err := ctrl.SetControllerReference(rayServiceInstance, newSvc, r.Scheme); err != nil {
+				return err
+			}
+			r.Log.Info("Create a Kubernetes Service for Serve application", "service", newSvc.Name)
+			if err := r.Create(ctx, newSvc); err != nil {
+				r.Log.Error(err, "Fail to create Kubernetes Service for Serve application", "service", newSvc.Name)
+				return err
+			}
+		} else if err != nil {
+			r.Log.Error(err, "Fail to retrieve the Kubernetes Service from the cluster!", "service", newSvc.Name)
+			return err
+		} else if !metav1.IsControlledBy(oldSvc, rayServiceInstance) {
+			// The name of the service of another RayService, or of an unrelated service, is truncated to the same name.
+			return fmt.Errorf("the Kubernetes Service %s for Serve application %s already exists and is not owned by RayService %s",
+				newSvc.Name, newSvc.Labels[common.RayServeApplicationLabelKey], rayServiceInstance.Name)
+		} else if !reflect.DeepEqual(oldSvc.Spec.Selector, newSvc.Spec.Selector) || !reflect.DeepEqual(oldSvc.Spec.Ports, newSvc.Spec.Ports) {
+			// ClusterIP is immutable, so keep the old one. See `reconcileServices` for more details.
+			if newSvc.Spec.ClusterIP == "" {
+				newSvc.Spec.ClusterIP = oldSvc.Spec.ClusterIP
+			}
+			oldSvc.Spec = *newSvc.Spec.DeepCopy()
+			r.Log.Info("Update Kubernetes Service for Serve application", "service", newSvc.Name)
+			if err := r.Update(ctx, oldSvc); err != nil {
+				r.Log.Error(err, "Fail to update Kubernetes Service for Serve application", "service", newSvc.Name)
+				return err
+			}
+		}
+	}
+
+	// Delete the services of applications that have been removed from `serveConfigV2`.
+	svcList := corev1.ServiceList{}
+	filterLabels := client.MatchingLabels{common.RayServiceLabelKey: rayServiceInstance.Name}
+	if err := r.List(ctx, &svcList, client.InNamespace(rayServiceInstance.Namespace), filterLabels, client.HasLabels{common.RayServeApplicationLabelKey}); err != nil {
+		return err
+	}
+	for i := range svcList.Items {
+		svc := &svcList.Items[i]
+		if _, ok := desiredSvcNames[svc.Name]; ok || !metav1.IsControlledBy(svc, rayServiceInstance) {
+			continue
+		}
+		r.Log.Info("Delete the Kubernetes Service of a removed Serve application", "service", svc.Name)
+		if err := r.Delete(ctx, svc); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcileServeApplicationIngress(ctx, rayServiceInstance, rayClusterInstance, enabled); err != nil {
+		return err
+	}
+
+	// Report the endpoint of every application in the status of the RayCluster that serves the traffic.
+	for _, serveStatus := range []*rayv1alpha1.RayServiceStatus{&rayServiceInstance.Status.ActiveServiceStatus, &rayServiceInstance.Status.PendingServiceStatus} {
+		if serveStatus.RayClusterName != rayClusterInstance.Name {
+			continue
+		}
+		endpoints := map[string]string{}
+		if enabled {
+			apps, err := utils.ParseServeConfigV2Applications(rayServiceInstance.Spec.ServeConfigV2)
+			if err != nil {
+				return err
+			}
+			svcsByName := make(map[string]*corev1.Service, len(desiredSvcs))
+			for _, svc := range desiredSvcs {
+				svcsByName[svc.Name] = svc
+			}
+			for _, app := range apps {
+				svc, ok := svcsByName[utils.GenerateServeApplicationServiceName(rayServiceInstance.Name, app.Name)]
+				if !ok {
+					continue
+				}
+				endpoints[app.Name] = fmt.Sprintf("%s.%s.svc.%s:%d",
+					svc.Name, svc.Namespace, utils.GetClusterDomainName(), svc.Spec.Ports[0].Port)
+			}
+		}
+		for appName, appStatus := range serveStatus.Applications {
+			appStatus.ServiceEndpoint = endpoints[appName]
+			serveStatus.Applications[appName] = appStatus
+		}
+	}
+
+	return nil
+}
+
+// reconcileServeApplicationIngress creates, updates, or deletes the ingress that routes to the per-application serve services.
+func (r *RayServiceReconciler) reconcileServeApplicationIngress(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster, enabled bool) error {
+	var ingress *networkingv1.Ingress
+	if enabled && rayServiceInstance.Spec.ServeApplicationServices.EnableIngress != nil && *rayServiceInstance.Spec.ServeApplicationServices.EnableIngress {
+		var err error
+		if ingress, err = common.BuildServeIngressForApplications(*rayServiceInstance, *rayClusterInstance); err != nil {
+			return err
+		}
+	}
+	return r.reconcileServeIngress(ctx, rayServiceInstance, utils.GenerateServeApplicationIngressName(rayServiceInstance.Name), ingress)
+}
+
+// reconcileServeGRPCIngress creates, updates, or deletes the ingress that routes to the gRPC port of the serve service.
+func (r *RayServiceReconciler) reconcileServeGRPCIngress(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster) error {
+	var ingress *networkingv1.Ingress
+	if rayServiceInstance.Spec.EnableGRPCIngress != nil && *rayServiceInstance.Spec.EnableGRPCIngress {
+		var err error
+		if ingress, err = common.BuildServeGRPCIngressForRayService(*rayServiceInstance, *rayClusterInstance); err != nil {
+			return err
+		}
+	}
+	return r.reconcileServeIngress(ctx, rayServiceInstance, utils.GenerateServeGRPCIngressName(rayServiceInstance.Name), ingress)
+}
+
+// reconcileServeGRPCRoute creates, updates, or deletes the GRPCRoute that routes to the gRPC port of the serve service.
+// Nothing is done if the GRPCRoute CRD was not found when the operator started.
+func (r *RayServiceReconciler) reconcileServeGRPCRoute(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster) error {
+	if !r.GRPCRouteServed {
+		if common.IsGRPCRouteEnabled(*rayServiceInstance) {
+			r.Log.Info("The GRPCRoute CRD is not installed. Skipping the GRPCRoute of the RayService.", "RayService", rayServiceInstance.Name)
+		}
+		return nil
+	}
+
+	routeName := utils.GenerateServeGRPCRouteName(rayServiceInstance.Name)
+	oldRoute := &unstructured.Unstructured{}
+	oldRoute.SetGroupVersionKind(common.GRPCRouteGVK)
+	err := r.Get(ctx, client.ObjectKey{Name: routeName, Namespace: rayServiceInstance.Namespace}, oldRoute)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if !common.IsGRPCRouteEnabled(*rayServiceInstance) {
+		if exists && metav1.IsControlledBy(oldRoute, rayServiceInstance) {
+			r.Log.Info("Delete the GRPCRoute of the serve service", "GRPCRoute", routeName)
+			return client.IgnoreNotFound(r.Delete(ctx, oldRoute))
+		}
+		return nil
+	}
+
+	route, err := common.BuildServeGRPCRouteForRayService(*rayServiceInstance, *rayClusterInstance)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := ctrl.SetControllerReference(rayServiceInstance, route, r.Scheme); err != nil {
+			return err
+		}
+		r.Log.Info("Create the GRPCRoute of the serve service", "GRPCRoute", routeName)
+		return r.Create(ctx, route)
+	}
+	if !metav1.IsControlledBy(oldRoute, rayServiceInstance) {
+		return fmt.Errorf("the GRPCRoute %s/%s already exists and is not controlled by the RayService", oldRoute.GetNamespace(), oldRoute.GetName())
+	}
+	if reflect.DeepEqual(oldRoute.Object["spec"], route.Object["spec"]) {
+		return nil
+	}
+	oldRoute.Object["spec"] = route.Object["spec"]
+	r.Log.Info("Update the GRPCRoute of the serve service", "GRPCRoute", routeName)
+	return r.Update(ctx, oldRoute)
+}
+
+// reconcileServeIngress creates or updates `ingress`. If `ingress` is nil, the ingress named `ingressName` is deleted
+// if it is owned by the RayService.
+func (r *RayServiceReconciler) reconcileServeIngress(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, ingressName string, ingress *networkingv1.Ingress) error {
+	oldIngress := &networkingv1.Ingress{}
+	err := r.Get(ctx, client.ObjectKey{Name: ingressName, Namespace: rayServiceInstance.Namespace}, oldIngress)
+	if err != nil && !errors.IsNotFound(err) {
+		r.Log.Error(err, "Ingress get error!")
+		return err
+	}
+	exists := err == nil
+
+	if ingress == nil {
+		if exists && metav1.IsControlledBy(oldIngress, rayServiceInstance) {
+			r.Log.Info("Delete the ingress of the serve service", "ingress", ingressName)
+			return client.IgnoreNotFound(r.Delete(ctx, oldIngress))
+		}
+		return nil
+	}
+
+	if exists {
+		if reflect.DeepEqual(oldIngress.Spec, ingress.Spec) && reflect.DeepEqual(oldIngress.Annotations, ingress.Annotations) {
+			return nil
+		}
+		oldIngress.Spec = ingress.Spec
+		oldIngress.Annotations = ingress.Annotations
+		if updateErr := r.Update(ctx, oldIngress); updateErr != nil {
+			r.Log.Error(updateErr, "Ingress Update error!", "Ingress.Error", updateErr)
+			return updateErr
+		}
+		return nil
+	}
+
+	if err := ctrl.SetControllerReference(rayServiceInstance, ingress, r.Scheme); err != nil {
+		return err
+	}
+	if createErr := r.Create(ctx, ingress); createErr != nil && !errors.IsAlreadyExists(createErr) {
+		r.Log.Error(createErr, "Ingress create error!", "Ingress.Error", createErr)
+		return createErr
+	}
+	return nil
+}
+
 func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster, logger logr.Logger) error {
 	rayServiceInstance.Status.ActiveServiceStatus.RayClusterStatus = rayClusterInstance.Status
 
@@ -1037,14 +1477,20 @@ func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context,
 		return err
 	}
 
+	authToken, err := utils.FetchDashboardAuthToken(ctx, r.APIReader, rayClusterInstance)
+	if err != nil {
+		r.updateAndCheckDashboardStatus(rayServiceStatus, false, rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold)
+		return err
+	}
 	rayDashboardClient := utils.GetRayDashboardClientFunc()
-	rayDashboardClient.InitClient(clientURL)
+	rayDashboardClient.InitClient(clientURL, authToken)
 
 	var isHealthy, isReady bool
 	if isHealthy, isReady, err = r.getAndCheckServeStatus(ctx, rayDashboardClient, rayServiceStatus, r.determineServeConfigType(rayServiceInstance), rayServiceInstance.Spec.ServiceUnhealthySecondThreshold); err != nil {
 		r.updateAndCheckDashboardStatus(rayServiceStatus, false, rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold)
 		return err
 	}
+	common.UpdateServeDeploymentMetrics(rayServiceInstance)
 
 	r.updateAndCheckDashboardStatus(rayServiceStatus, true, rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold)
 
@@ -1059,7 +1505,7 @@ func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context,
 // (including all deployments) is running. E.g. while the Serve app is
 // deploying/updating, isHealthy is true while isReady is false. If isHealthy
 // is false, isReady is guaranteed to be false.
-func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster, isActive bool, logger logr.Logger) (ctrl.Result, bool, bool, error) {
+func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceInstance *rayv1alpha1.RayService, rayClusterInstance *rayv1alpha1.RayCluster, isActive bool, serveConfigResourceVersion string, logger logr.Logger) (ctrl.Result, bool, bool, error) {
 	rayServiceInstance.Status.ActiveServiceStatus.RayClusterStatus = rayClusterInstance.Status
 	var err error
 	var clientURL string
@@ -1090,8 +1536,12 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	if clientURL, err = utils.FetchHeadServiceURL(ctx, &r.Log, r.Client, rayClusterInstance, common.DefaultDashboardAgentListenPortName); err != nil || clientURL == "" {
 		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, false, err
 	}
+	authToken, err := utils.FetchDashboardAuthToken(ctx, r.APIReader, rayClusterInstance)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, false, err
+	}
 	rayDashboardClient := utils.GetRayDashboardClientFunc()
-	rayDashboardClient.InitClient(clientURL)
+	rayDashboardClient.InitClient(clientURL, authToken)
 
 	shouldUpdate := r.checkIfNeedSubmitServeDeployment(rayServiceInstance, rayClusterInstance, rayServiceStatus)
 
@@ -1099,11 +1549,13 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 		if err = r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, rayClusterInstance.Name); err != nil {
 			if !r.updateAndCheckDashboardStatus(rayServiceStatus, false, rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold) {
 				logger.Info("Dashboard is unhealthy, restart the cluster.")
+				common.RayServiceUnhealthyRestartsCounterInc(rayServiceInstance.Namespace, rayServiceInstance.Name, rayClusterInstance.Name, common.RayServiceRestartReasonDashboardUnhealthy)
 				r.markRestart(rayServiceInstance)
 			}
 			err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.WaitForServeDeploymentReady, err)
 			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, false, err
 		}
+		rayServiceStatus.ServeConfigResourceVersion = serveConfigResourceVersion
 
 		r.Recorder.Eventf(rayServiceInstance, "Normal", "SubmittedServeDeployment",
 			"Controller sent API request to update Serve deployments on cluster %s", rayClusterInstance.Name)
@@ -1113,11 +1565,14 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	if isHealthy, isReady, err = r.getAndCheckServeStatus(ctx, rayDashboardClient, rayServiceStatus, r.determineServeConfigType(rayServiceInstance), rayServiceInstance.Spec.ServiceUnhealthySecondThreshold); err != nil {
 		if !r.updateAndCheckDashboardStatus(rayServiceStatus, false, rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold) {
 			logger.Info("Dashboard is unhealthy, restart the cluster.")
+			common.RayServiceUnhealthyRestartsCounterInc(rayServiceInstance.Namespace, rayServiceInstance.Name, rayClusterInstance.Name, common.RayServiceRestartReasonDashboardUnhealthy)
 			r.markRestart(rayServiceInstance)
 		}
 		err = r.updateState(ctx, rayServiceInstance, rayv1alpha1.FailedToGetServeDeploymentStatus, err)
 		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, false, err
 	}
+	// The gauges are updated before the status is persisted, since the reconciliation can return early.
+	common.UpdateServeDeploymentMetrics(rayServiceInstance)
 
 	r.updateAndCheckDashboardStatus(rayServiceStatus, true, rayServiceInstance.Spec.DeploymentUnhealthySecondThreshold)
 
@@ -1125,6 +1580,13 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 
 	if isHealthy && isReady {
 		rayServiceInstance.Status.ServiceStatus = rayv1alpha1.Running
+		if activeClusterName := rayServiceInstance.Status.ActiveServiceStatus.RayClusterName; activeClusterName != "" && activeClusterName != rayClusterInstance.Name {
+			// The restarts of an unhealthy active RayCluster keep its spec, and are not upgrades.
+			activeRayCluster, err := r.getRayClusterByNamespacedName(ctx, client.ObjectKey{Name: activeClusterName, Namespace: rayServiceInstance.Namespace})
+			if err == nil && activeRayCluster.Annotations[common.RayServiceClusterHashKey] != rayClusterInstance.Annotations[common.RayServiceClusterHashKey] {
+				common.ObserveRayServiceUpgradeDuration(rayServiceInstance.Namespace, rayClusterInstance)
+			}
+		}
 		r.updateRayClusterInfo(rayServiceInstance, rayClusterInstance.Name)
 		r.Recorder.Event(rayServiceInstance, "Normal", "Running", "The Serve applicaton is now running and healthy.")
 	} else if isHealthy && !isReady {
@@ -1135,6 +1597,7 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 		logger.Info("Mark cluster as waiting for Serve deployments", "rayCluster", rayClusterInstance)
 	} else if !isHealthy {
 		// NOTE: When isHealthy is false, isReady is guaranteed to be false.
+		common.RayServiceUnhealthyRestartsCounterInc(rayServiceInstance.Namespace, rayServiceInstance.Name, rayClusterInstance.Name, common.RayServiceRestartReasonServeUnhealthy)
 		r.markRestart(rayServiceInstance)
 		rayServiceInstance.Status.ServiceStatus = rayv1alpha1.Restarting
 		if err := r.Status().Update(ctx, rayServiceInstance); err != nil {
@@ -1161,7 +1624,11 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, isHealthy, isReady, nil
 }
 
-func (r *RayServiceReconciler) labelHealthyServePods(ctx context.Context, rayClusterInstance *rayv1alpha1.RayCluster) error {
+// updateServeReadiness probes the Serve HTTP proxy of every Pod in the RayCluster concurrently and reports the result
+// through the `ray.io/serve-ready` readiness gate of the Pod. A healthy probe marks the Pod as ready immediately, while
+// a Pod is only marked as not ready once its probes have been failing for `DefaultServeProxyUnhealthyThresholdSeconds`.
+// Pods created without the readiness gate fall back to toggling the `ray.io/serve` label.
+func (r *RayServiceReconciler) updateServeReadiness(ctx context.Context, rayClusterInstance *rayv1alpha1.RayCluster) error {
 	allPods := corev1.PodList{}
 	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: rayClusterInstance.Name}
 
@@ -1169,39 +1636,202 @@ func (r *RayServiceReconciler) labelHealthyServePods(ctx context.Context, rayClu
 		return err
 	}
 
-	httpProxyClient := utils.GetRayHttpProxyClientFunc()
-	httpProxyClient.InitClient()
-	for _, pod := range allPods.Items {
-		rayContainer := pod.Spec.Containers[common.RayContainerIndex]
-		servingPort := utils.FindContainerPort(&rayContainer, common.DefaultServingPortName, common.DefaultServingPort)
-		httpProxyClient.SetHostIp(pod.Status.PodIP, servingPort)
-		if pod.Labels == nil {
-			pod.Labels = make(map[string]string)
-		}
+	probeErrs := r.probeServeProxies(ctx, allPods.Items)
+	now := time.Now()
+
+	keyPrefix := r.generateServeProxyProbeKeyPrefix(rayClusterInstance)
+	podKeys := make(map[string]struct{}, len(allPods.Items))
+	for i := range allPods.Items {
+		pod := &allPods.Items[i]
+		isHealthy := probeErrs[i] == nil
 
-		// Make a copy of the labels for comparison later, to decide whether we need to push an update.
-		originalLabels := make(map[string]string, len(pod.Labels))
-		for key, value := range pod.Labels {
-			originalLabels[key] = value
+		if !utils.HasReadinessGate(pod.Spec, common.RayServeReadyConditionType) {
+			if err := r.updateServePodLabel(ctx, pod, isHealthy); err != nil {
+				return err
+			}
+			continue
 		}
 
-		if httpProxyClient.CheckHealth() == nil {
-			pod.Labels[common.RayClusterServingServiceLabelKey] = common.EnableRayClusterServingServiceTrue
+		key := keyPrefix + pod.Name
+		podKeys[key] = struct{}{}
+		failingSince := now
+		if !isHealthy {
+			if cachedTime, exist := r.ServeProxyProbeFailures.Get(key); exist {
+				failingSince = cachedTime.(time.Time)
+			} else {
+				r.ServeProxyProbeFailures.Set(key, failingSince)
+			}
 		} else {
-			pod.Labels[common.RayClusterServingServiceLabelKey] = common.EnableRayClusterServingServiceFalse
+			r.ServeProxyProbeFailures.Remove(key)
 		}
 
-		if !reflect.DeepEqual(originalLabels, pod.Labels) {
-			if updateErr := r.Update(ctx, &pod); updateErr != nil {
-				r.Log.Error(updateErr, "Pod label Update error!", "Pod.Error", updateErr)
+		var condition corev1.PodCondition
+		switch {
+		case isHealthy:
+			condition = corev1.PodCondition{Type: common.RayServeReadyConditionType, Status: corev1.ConditionTrue, Reason: "ServeProxyHealthy"}
+		case now.Sub(failingSince) >= common.DefaultServeProxyUnhealthyThresholdSeconds*time.Second || !isServeReady(pod):
+			condition = corev1.PodCondition{Type: common.RayServeReadyConditionType, Status: corev1.ConditionFalse, Reason: "ServeProxyUnhealthy", Message: probeErrs[i].Error()}
+		default:
+			r.Log.V(1).Info("Serve HTTP proxy probe failed", "pod", pod.Name, "failing since", failingSince, "error", probeErrs[i])
+			continue
+		}
+
+		if setPodCondition(pod, condition) {
+			r.Log.Info("Update Serve readiness of Pod", "pod", pod.Name, "status", condition.Status)
+			if updateErr := r.Status().Update(ctx, pod); updateErr != nil {
+				r.Log.Error(updateErr, "Pod status Update error!", "Pod.Error", updateErr)
 				return updateErr
 			}
 		}
 	}
 
+	// Clean up the probe failures of the Pods that no longer exist.
+	for key := range r.ServeProxyProbeFailures.Items() {
+		if _, ok := podKeys[key]; !ok && strings.HasPrefix(key, keyPrefix) {
+			r.ServeProxyProbeFailures.Remove(key)
+		}
+	}
+
+	return nil
+}
+
+// probeServeProxies checks the health of the Serve HTTP proxy on each Pod with at most `DefaultServeProxyProbeConcurrency`
+// probes in flight. If the Ray container has a port named "serve-grpc", the Serve gRPC proxy must be healthy as well.
+// Each probe is bounded by the timeout of the proxy client. The returned errors are in the same order as the Pods.
+func (r *RayServiceReconciler) probeServeProxies(ctx context.Context, pods []corev1.Pod) []error {
+	probeErrs := make([]error, len(pods))
+	semaphore := make(chan struct{}, common.DefaultServeProxyProbeConcurrency)
+	var wg sync.WaitGroup
+	for i := range pods {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			rayContainer := pods[i].Spec.Containers[common.RayContainerIndex]
+			servingPort := utils.FindContainerPort(&rayContainer, common.DefaultServingPortName, common.DefaultServingPort)
+			httpProxyClient := utils.GetRayHttpProxyClientFunc()
+			httpProxyClient.InitClient()
+			httpProxyClient.SetHostIp(pods[i].Status.PodIP, servingPort)
+			if probeErrs[i] = httpProxyClient.CheckHealth(ctx); probeErrs[i] != nil {
+				return
+			}
+			if grpcPort := utils.FindContainerPort(&rayContainer, common.DefaultServingGRPCPortName, -1); grpcPort != -1 {
+				grpcProxyClient := utils.GetRayGrpcProxyClientFunc()
+				grpcProxyClient.InitClient()
+				grpcProxyClient.SetHostIp(pods[i].Status.PodIP, grpcPort)
+				probeErrs[i] = grpcProxyClient.CheckHealth(ctx)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return probeErrs
+}
+
+// updateServePodLabel toggles the `ray.io/serve` label of a Pod that does not have the Serve readiness gate.
+func (r *RayServiceReconciler) updateServePodLabel(ctx context.Context, pod *corev1.Pod, isHealthy bool) error {
+	value := common.EnableRayClusterServingServiceFalse
+	if isHealthy {
+		value = common.EnableRayClusterServingServiceTrue
+	}
+	if pod.Labels[common.RayClusterServingServiceLabelKey] == value {
+		return nil
+	}
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	pod.Labels[common.RayClusterServingServiceLabelKey] = value
+	if updateErr := r.Update(ctx, pod); updateErr != nil {
+		r.Log.Error(updateErr, "Pod label Update error!", "Pod.Error", updateErr)
+		return updateErr
+	}
 	return nil
 }
 
+func (r *RayServiceReconciler) generateServeProxyProbeKeyPrefix(rayClusterInstance *rayv1alpha1.RayCluster) string {
+	return rayClusterInstance.Namespace + "/" + rayClusterInstance.Name + "/"
+}
+
+// addServeReadinessGate adds the Serve readiness gate to the Pod template. The `ray.io/serve` label is set statically so
+// that the serve service selects the Pod, and the readiness gate decides whether the Pod receives traffic. What is added
+// is recorded in the `ray.io/serve-readiness-gate-added` annotation so that `removeServeReadinessGate` can revert it.
+func addServeReadinessGate(template *corev1.PodTemplateSpec) {
+	var added []string
+	if _, ok := template.Labels[common.RayClusterServingServiceLabelKey]; !ok {
+		if template.Labels == nil {
+			template.Labels = make(map[string]string)
+		}
+		template.Labels[common.RayClusterServingServiceLabelKey] = common.EnableRayClusterServingServiceTrue
+		added = append(added, common.ServeReadinessGateAddedLabel)
+	}
+	if !utils.HasReadinessGate(template.Spec, common.RayServeReadyConditionType) {
+		template.Spec.ReadinessGates = append(template.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: common.RayServeReadyConditionType})
+		added = append(added, common.ServeReadinessGateAddedGate)
+	}
+	if len(added) == 0 {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[common.RayServeReadinessGateAddedAnnotationKey] = strings.Join(added, ",")
+}
+
+// removeServeReadinessGate reverts `addServeReadinessGate`. The label and readiness gate set by the user are kept.
+func removeServeReadinessGate(template *corev1.PodTemplateSpec) {
+	value, ok := template.Annotations[common.RayServeReadinessGateAddedAnnotationKey]
+	if !ok {
+		return
+	}
+	delete(template.Annotations, common.RayServeReadinessGateAddedAnnotationKey)
+	for _, added := range strings.Split(value, ",") {
+		switch added {
+		case common.ServeReadinessGateAddedLabel:
+			delete(template.Labels, common.RayClusterServingServiceLabelKey)
+		case common.ServeReadinessGateAddedGate:
+			readinessGates := []corev1.PodReadinessGate{}
+			for _, gate := range template.Spec.ReadinessGates {
+				if gate.ConditionType != common.RayServeReadyConditionType {
+					readinessGates = append(readinessGates, gate)
+				}
+			}
+			if len(readinessGates) == 0 {
+				readinessGates = nil
+			}
+			template.Spec.ReadinessGates = readinessGates
+		}
+	}
+}
+
+// isServeReady returns true if the Serve readiness condition of the Pod is true.
+func isServeReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == common.RayServeReadyConditionType {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// setPodCondition sets the condition in the Pod status and returns true if the status of the condition changed.
+func setPodCondition(pod *corev1.Pod, condition corev1.PodCondition) bool {
+	condition.LastTransitionTime = metav1.Now()
+	for i, cond := range pod.Status.Conditions {
+		if cond.Type != condition.Type {
+			continue
+		}
+		if cond.Status == condition.Status {
+			return false
+		}
+		pod.Status.Conditions[i] = condition
+		return true
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	return true
+}
+
 func generateRayClusterJsonHash(rayClusterSpec rayv1alpha1.RayClusterSpec) (string, error) {
 	// Mute all fields that will not trigger new RayCluster preparation. For example,
 	// Autoscaler will update `Replicas` and `WorkersToDelete` when scaling up/down.
@@ -1209,7 +1839,10 @@ func generateRayClusterJsonHash(rayClusterSpec rayv1alpha1.RayClusterSpec) (stri
 	for i := 0; i < len(updatedRayClusterSpec.WorkerGroupSpecs); i++ {
 		updatedRayClusterSpec.WorkerGroupSpecs[i].Replicas = nil
 		updatedRayClusterSpec.WorkerGroupSpecs[i].ScaleStrategy.WorkersToDelete = nil
+		removeServeReadinessGate(&updatedRayClusterSpec.WorkerGroupSpecs[i].Template)
 	}
+	// The Serve readiness gate is added by the RayService controller, so it should not trigger new RayCluster preparation.
+	removeServeReadinessGate(&updatedRayClusterSpec.HeadGroupSpec.Template)
 
 	// Generate a hash for the RayClusterSpec.
 	return utils.GenerateJsonHash(updatedRayClusterSpec)
@@ -1242,7 +1875,9 @@ func (r *RayServiceReconciler) isHeadPodRunningAndReady(ctx context.Context, ins
 		return false, fmt.Errorf("Found %d head pods for RayCluster %s in the namespace %s", len(podList.Items), instance.Name, instance.Namespace)
 	}
 
-	return utils.IsRunningAndReady(&podList.Items[0]), nil
+	// The head Pod does not become ready until the Serve applications are running if it has the Serve readiness gate.
+	// Hence, only the containers are checked here.
+	return utils.IsRunningAndContainersReady(&podList.Items[0]), nil
 }
 
 func isServeAppUnhealthyOrDeployedFailed(appStatus string) bool {
@@ -262,6 +262,7 @@ applications:
 	}
 
 	utils.GetRayHttpProxyClientFunc = utils.GetFakeRayHttpProxyClient
+	utils.GetRayGrpcProxyClientFunc = utils.GetFakeRayGrpcProxyClient
 
 	myRayCluster := &rayv1alpha1.RayCluster{}
 
@@ -14,7 +14,10 @@ import (
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
@@ -289,6 +292,273 @@ func TestIsHeadPodRunningAndReady(t *testing.T) {
 	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
 	assert.Nil(t, err)
 	assert.True(t, isReady)
+
+	// Test 4: The head pod has the Serve readiness gate, which is still false. Only the containers
+	// are checked, so `isHeadPodRunningAndReady` should return true.
+	gatedHeadPod := headPod.DeepCopy()
+	gatedHeadPod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: common.RayServeReadyConditionType}}
+	gatedHeadPod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionFalse,
+			},
+			{
+				Type:   corev1.ContainersReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+	}
+	runtimeObjects = []runtime.Object{gatedHeadPod}
+	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	r.Client = fakeClient
+	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.True(t, isReady)
+}
+
+// fakeServeProxyClient is a RayHttpProxyClientInterface whose health is controlled by the test.
+type fakeServeProxyClient struct {
+	healthy map[string]bool
+	hostIp  string
+}
+
+func (c *fakeServeProxyClient) InitClient() {}
+
+func (c *fakeServeProxyClient) SetHostIp(hostIp string, port int) {
+	c.hostIp = hostIp
+}
+
+func (c *fakeServeProxyClient) CheckHealth(_ context.Context) error {
+	if !c.healthy[c.hostIp] {
+		return fmt.Errorf("proxy on %s is unhealthy", c.hostIp)
+	}
+	return nil
+}
+
+func TestUpdateServeReadiness(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	cluster := rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+	}
+	newPod := func(name string, ip string, withGate bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					common.RayClusterLabelKey: cluster.Name,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "ray"}},
+			},
+			Status: corev1.PodStatus{PodIP: ip},
+		}
+		if withGate {
+			pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: common.RayServeReadyConditionType}}
+		}
+		return pod
+	}
+
+	healthy := map[string]bool{"10.0.0.1": true, "10.0.0.2": true, "10.0.0.3": false}
+	oldProxyClientFunc := utils.GetRayHttpProxyClientFunc
+	utils.GetRayHttpProxyClientFunc = func() utils.RayHttpProxyClientInterface {
+		return &fakeServeProxyClient{healthy: healthy}
+	}
+	defer func() { utils.GetRayHttpProxyClientFunc = oldProxyClientFunc }()
+
+	runtimeObjects := []runtime.Object{
+		newPod("pod-1", "10.0.0.1", true),
+		newPod("pod-2", "10.0.0.2", true),
+		newPod("legacy-pod", "10.0.0.3", false),
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	r := &RayServiceReconciler{
+		Client:                  fakeClient,
+		Recorder:                &record.FakeRecorder{},
+		Scheme:                  scheme.Scheme,
+		Log:                     ctrl.Log.WithName("controllers").WithName("RayService"),
+		ServeProxyProbeFailures: cmap.New(),
+	}
+	ctx := context.TODO()
+
+	getPod := func(name string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, pod)
+		assert.Nil(t, err)
+		return pod
+	}
+
+	// Test 1: Pods with a healthy proxy become ready, and the Pod without the readiness gate falls back to the label.
+	err := r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.True(t, isServeReady(getPod("pod-1")))
+	assert.True(t, isServeReady(getPod("pod-2")))
+	assert.Equal(t, common.EnableRayClusterServingServiceFalse, getPod("legacy-pod").Labels[common.RayClusterServingServiceLabelKey])
+	assert.Equal(t, "", getPod("pod-1").Labels[common.RayClusterServingServiceLabelKey])
+
+	// Test 2: A Pod stays ready until its probes have been failing for longer than the threshold, however many
+	// reconciliations happen in the meantime.
+	healthy["10.0.0.2"] = false
+	for i := 0; i < 5; i++ {
+		err = r.updateServeReadiness(ctx, &cluster)
+		assert.Nil(t, err)
+		assert.True(t, isServeReady(getPod("pod-2")))
+	}
+	key := r.generateServeProxyProbeKeyPrefix(&cluster) + "pod-2"
+	r.ServeProxyProbeFailures.Set(key, time.Now().Add(-common.DefaultServeProxyUnhealthyThresholdSeconds*time.Second))
+	err = r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.False(t, isServeReady(getPod("pod-2")))
+	assert.True(t, isServeReady(getPod("pod-1")))
+
+	// Test 3: A single successful probe makes the Pod ready again and resets the failure count.
+	healthy["10.0.0.2"] = true
+	err = r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.True(t, isServeReady(getPod("pod-2")))
+	assert.Equal(t, 0, r.ServeProxyProbeFailures.Count())
+
+	// Test 4: The failure counts of deleted Pods are cleaned up.
+	healthy["10.0.0.2"] = false
+	err = r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, r.ServeProxyProbeFailures.Count())
+	err = fakeClient.Delete(ctx, getPod("pod-2"))
+	assert.Nil(t, err)
+	err = r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, r.ServeProxyProbeFailures.Count())
+}
+
+func TestUpdateServeReadiness_GRPC(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	cluster := rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-1",
+			Namespace: namespace,
+			Labels: map[string]string{
+				common.RayClusterLabelKey: cluster.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "ray",
+					Ports: []corev1.ContainerPort{
+						{Name: common.DefaultServingPortName, ContainerPort: 8000},
+						{Name: common.DefaultServingGRPCPortName, ContainerPort: 9000},
+					},
+				},
+			},
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: common.RayServeReadyConditionType}},
+		},
+		Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+
+	// The HTTP proxy is healthy, but the gRPC proxy is not.
+	oldHttpProxyClientFunc := utils.GetRayHttpProxyClientFunc
+	oldGrpcProxyClientFunc := utils.GetRayGrpcProxyClientFunc
+	utils.GetRayHttpProxyClientFunc = utils.GetFakeRayHttpProxyClient
+	utils.GetRayGrpcProxyClientFunc = func() utils.RayGrpcProxyClientInterface {
+		return &fakeServeProxyClient{healthy: map[string]bool{}}
+	}
+	defer func() {
+		utils.GetRayHttpProxyClientFunc = oldHttpProxyClientFunc
+		utils.GetRayGrpcProxyClientFunc = oldGrpcProxyClientFunc
+	}()
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(pod).Build()
+	r := &RayServiceReconciler{
+		Client:                  fakeClient,
+		Recorder:                &record.FakeRecorder{},
+		Scheme:                  scheme.Scheme,
+		Log:                     ctrl.Log.WithName("controllers").WithName("RayService"),
+		ServeProxyProbeFailures: cmap.New(),
+	}
+	ctx := context.TODO()
+
+	err := r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: pod.Name, Namespace: namespace}, pod)
+	assert.Nil(t, err)
+	assert.False(t, isServeReady(pod))
+
+	// Both proxies are healthy.
+	utils.GetRayGrpcProxyClientFunc = utils.GetFakeRayGrpcProxyClient
+	err = r.updateServeReadiness(ctx, &cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: pod.Name, Namespace: namespace}, pod)
+	assert.Nil(t, err)
+	assert.True(t, isServeReady(pod))
+}
+
+func TestConstructRayClusterForRayService_ServeReadinessGate(t *testing.T) {
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: "ray",
+		},
+		Spec: rayv1alpha1.RayServiceSpec{
+			RayClusterSpec: rayv1alpha1.RayClusterSpec{
+				WorkerGroupSpecs: []rayv1alpha1.WorkerGroupSpec{{GroupName: "small-group"}},
+			},
+		},
+	}
+	r := &RayServiceReconciler{
+		Scheme: scheme.Scheme,
+		Log:    ctrl.Log.WithName("controllers").WithName("RayService"),
+	}
+
+	rayCluster, err := r.constructRayClusterForRayService(&rayService, "test-cluster")
+	assert.Nil(t, err)
+	for _, template := range []corev1.PodTemplateSpec{rayCluster.Spec.HeadGroupSpec.Template, rayCluster.Spec.WorkerGroupSpecs[0].Template} {
+		assert.True(t, utils.HasReadinessGate(template.Spec, common.RayServeReadyConditionType))
+		assert.Equal(t, common.EnableRayClusterServingServiceTrue, template.Labels[common.RayClusterServingServiceLabelKey])
+	}
+
+	// The RayService spec should not be modified.
+	assert.Nil(t, rayService.Spec.RayClusterSpec.WorkerGroupSpecs[0].Template.Spec.ReadinessGates)
+
+	// The readiness gate should not trigger new RayCluster preparation.
+	equal, err := compareRayClusterJsonHash(rayCluster.Spec, rayService.Spec.RayClusterSpec)
+	assert.Nil(t, err)
+	assert.True(t, equal)
+
+	// A `ray.io/serve` label set by the user is kept, and still counts in the hash.
+	hash, err := generateRayClusterJsonHash(rayService.Spec.RayClusterSpec)
+	assert.Nil(t, err)
+	rayService.Spec.RayClusterSpec.WorkerGroupSpecs[0].Template.Labels = map[string]string{
+		common.RayClusterServingServiceLabelKey: common.EnableRayClusterServingServiceFalse,
+	}
+	userHash, err := generateRayClusterJsonHash(rayService.Spec.RayClusterSpec)
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, userHash)
+	rayCluster, err = r.constructRayClusterForRayService(&rayService, "test-cluster")
+	assert.Nil(t, err)
+	assert.Equal(t, common.EnableRayClusterServingServiceFalse, rayCluster.Spec.WorkerGroupSpecs[0].Template.Labels[common.RayClusterServingServiceLabelKey])
+	equal, err = compareRayClusterJsonHash(rayCluster.Spec, rayService.Spec.RayClusterSpec)
+	assert.Nil(t, err)
+	assert.True(t, equal)
 }
 
 func TestReconcileServices_UpdateService(t *testing.T) {
@@ -377,6 +647,397 @@ func TestReconcileServices_UpdateService(t *testing.T) {
 	assert.False(t, reflect.DeepEqual(*oldSvc, svcList.Items[0]))
 }
 
+func TestReconcileServeApplicationServices(t *testing.T) {
+	// Create a new scheme with CRDs, Service, and Ingress schemes.
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+
+	// Mock data
+	namespace := "ray"
+	cluster := rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "ray-head",
+								Ports: []corev1.ContainerPort{
+									{Name: common.DefaultServingPortName, ContainerPort: 8000},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1alpha1.RayServiceSpec{
+			ServeConfigV2: `
+applications:
+  - name: app1
+    route_prefix: /app1
+    import_path: app1.app
+  - name: app2
+    route_prefix: /app2
+    import_path: app2.app
+`,
+			ServeApplicationServices: &rayv1alpha1.ServeApplicationServicesOptions{
+				EnableIngress: pointer.BoolPtr(true),
+			},
+		},
+		Status: rayv1alpha1.RayServiceStatuses{
+			ActiveServiceStatus: rayv1alpha1.RayServiceStatus{
+				RayClusterName: cluster.Name,
+				Applications: map[string]rayv1alpha1.AppStatus{
+					"app1": {Status: rayv1alpha1.ApplicationStatusEnum.RUNNING},
+					"app2": {Status: rayv1alpha1.ApplicationStatusEnum.RUNNING},
+				},
+			},
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+		Log:      ctrl.Log.WithName("controllers").WithName("RayService"),
+	}
+	ctx := context.TODO()
+
+	// Test 1: One service per application and an ingress are created, and the endpoints are reported.
+	err := r.reconcileServeApplicationServices(ctx, &rayService, &cluster)
+	assert.Nil(t, err, "Fail to reconcile serve application services")
+
+	svcList := corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, 2, len(svcList.Items))
+
+	ingress := &networkingv1.Ingress{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: utils.GenerateServeApplicationIngressName(rayService.Name), Namespace: namespace}, ingress)
+	assert.Nil(t, err, "Fail to get ingress")
+	assert.Equal(t, 2, len(ingress.Spec.Rules[0].HTTP.Paths))
+
+	assert.Equal(t, "test-service-app1-serve-svc.ray.svc.cluster.local:8000", rayService.Status.ActiveServiceStatus.Applications["app1"].ServiceEndpoint)
+	assert.Equal(t, "test-service-app2-serve-svc.ray.svc.cluster.local:8000", rayService.Status.ActiveServiceStatus.Applications["app2"].ServiceEndpoint)
+
+	// Test 2: The service of a removed application is deleted.
+	rayService.Spec.ServeConfigV2 = `
+applications:
+  - name: app1
+    route_prefix: /app1
+    import_path: app1.app
+`
+	err = r.reconcileServeApplicationServices(ctx, &rayService, &cluster)
+	assert.Nil(t, err, "Fail to reconcile serve application services")
+
+	svcList = corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, 1, len(svcList.Items))
+	assert.Equal(t, "test-service-app1-serve-svc", svcList.Items[0].Name)
+
+	// Test 3: When the RayCluster switches, the selector of the service is updated.
+	cluster.Name = "new-cluster"
+	err = r.reconcileServeApplicationServices(ctx, &rayService, &cluster)
+	assert.Nil(t, err, "Fail to reconcile serve application services")
+
+	svcList = corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, "new-cluster", svcList.Items[0].Spec.Selector[common.RayClusterLabelKey])
+
+	// Test 4: Disabling the feature removes the services and the ingress.
+	rayService.Spec.ServeApplicationServices = nil
+	err = r.reconcileServeApplicationServices(ctx, &rayService, &cluster)
+	assert.Nil(t, err, "Fail to reconcile serve application services")
+
+	svcList = corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, 0, len(svcList.Items))
+
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: utils.GenerateServeApplicationIngressName(rayService.Name), Namespace: namespace}, ingress)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Test 5: A service with the same name that is not owned by the RayService is not taken over.
+	rayService.Spec.ServeApplicationServices = &rayv1alpha1.ServeApplicationServicesOptions{}
+	err = fakeClient.Create(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service-app1-serve-svc", Namespace: namespace}})
+	assert.Nil(t, err)
+	err = r.reconcileServeApplicationServices(ctx, &rayService, &cluster)
+	assert.NotNil(t, err)
+}
+
+func TestValidateRayServiceSpec(t *testing.T) {
+	rayService := &rayv1alpha1.RayService{}
+	assert.Nil(t, validateRayServiceSpec(rayService))
+
+	rayService.Spec.ServeConfigRef = &rayv1alpha1.ServeConfigReference{Name: "serve-config", Key: "config.yaml"}
+	assert.Nil(t, validateRayServiceSpec(rayService))
+
+	rayService.Spec.ServeConfigV2 = "applications: []"
+	assert.NotNil(t, validateRayServiceSpec(rayService))
+
+	rayService.Spec.ServeConfigV2 = ""
+	rayService.Spec.ServeDeploymentGraphSpec.ImportPath = "fruit.deployment_graph"
+	assert.NotNil(t, validateRayServiceSpec(rayService))
+
+	rayService.Spec.ServeConfigRef = nil
+	rayService.Spec.ServeConfigV2 = "applications: []"
+	assert.NotNil(t, validateRayServiceSpec(rayService))
+
+	// Both the name and the key of the ConfigMap must be set.
+	rayService.Spec.ServeDeploymentGraphSpec = rayv1alpha1.ServeDeploymentGraphSpec{}
+	rayService.Spec.ServeConfigV2 = ""
+	rayService.Spec.ServeConfigRef = &rayv1alpha1.ServeConfigReference{Name: "serve-config"}
+	assert.NotNil(t, validateRayServiceSpec(rayService))
+	rayService.Spec.ServeConfigRef = &rayv1alpha1.ServeConfigReference{Key: "config.yaml"}
+	assert.NotNil(t, validateRayServiceSpec(rayService))
+}
+
+func TestResolveServeConfigRef(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	serveConfig := "applications:\n  - name: app1\n    import_path: app1.app\n"
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "serve-config",
+			Namespace: namespace,
+		},
+		Data: map[string]string{"config.yaml": serveConfig},
+	}
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+	}
+	otherRayService := rayService.DeepCopy()
+	otherRayService.Name = "other-service"
+	otherRayService.Spec.ServeConfigRef = &rayv1alpha1.ServeConfigReference{Name: "other-config", Key: "config.yaml"}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(configMap, otherRayService).Build()
+	r := &RayServiceReconciler{
+		Client:    fakeClient,
+		Recorder:  &record.FakeRecorder{},
+		Scheme:    scheme.Scheme,
+		Log:       ctrl.Log.WithName("controllers").WithName("RayService"),
+		APIReader: fakeClient,
+	}
+	ctx := context.TODO()
+
+	// Test 1: `serveConfigRef` is not set.
+	resourceVersion, err := r.resolveServeConfigRef(ctx, &rayService)
+	assert.Nil(t, err)
+	assert.Equal(t, "", resourceVersion)
+	assert.Equal(t, "", rayService.Spec.ServeConfigV2)
+
+	// Test 2: The Serve config is read from the ConfigMap.
+	rayService.Spec.ServeConfigRef = &rayv1alpha1.ServeConfigReference{Name: "serve-config", Key: "config.yaml"}
+	err = fakeClient.Create(ctx, rayService.DeepCopy())
+	assert.Nil(t, err)
+	resourceVersion, err = r.resolveServeConfigRef(ctx, &rayService)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", resourceVersion)
+	assert.Equal(t, serveConfig, rayService.Spec.ServeConfigV2)
+	assert.Equal(t, utils.MULTI_APP, r.determineServeConfigType(&rayService))
+
+	// Test 3: The key does not exist in the ConfigMap.
+	rayService.Spec.ServeConfigV2 = ""
+	rayService.Spec.ServeConfigRef.Key = "missing.yaml"
+	_, err = r.resolveServeConfigRef(ctx, &rayService)
+	assert.NotNil(t, err)
+
+	// Test 4: The ConfigMap does not exist.
+	rayService.Spec.ServeConfigRef = &rayv1alpha1.ServeConfigReference{Name: "missing-config", Key: "config.yaml"}
+	_, err = r.resolveServeConfigRef(ctx, &rayService)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Test 5: Only the RayServices referencing the ConfigMap are enqueued when it changes.
+	requests := r.rayServicesForConfigMap(configMap)
+	assert.Equal(t, 1, len(requests))
+	assert.Equal(t, "test-service", requests[0].Name)
+	assert.Equal(t, namespace, requests[0].Namespace)
+}
+
+func TestReconcileServeGRPCIngress(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	cluster := rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "ray-head",
+								Ports: []corev1.ContainerPort{
+									{Name: common.DefaultServingPortName, ContainerPort: 8000},
+									{Name: common.DefaultServingGRPCPortName, ContainerPort: 9000},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1alpha1.RayServiceSpec{
+			EnableGRPCIngress: pointer.BoolPtr(true),
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+		Log:      ctrl.Log.WithName("controllers").WithName("RayService"),
+	}
+	ctx := context.TODO()
+	ingressKey := client.ObjectKey{Name: utils.GenerateServeGRPCIngressName(rayService.Name), Namespace: namespace}
+
+	// Test 1: The ingress is created.
+	err := r.reconcileServeGRPCIngress(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	ingress := &networkingv1.Ingress{}
+	err = fakeClient.Get(ctx, ingressKey, ingress)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(9000), ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+
+	// Test 2: The annotations of the RayService are propagated to the existing ingress.
+	rayService.Annotations = map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "true"}
+	err = r.reconcileServeGRPCIngress(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, ingressKey, ingress)
+	assert.Nil(t, err)
+	assert.Equal(t, "true", ingress.Annotations["nginx.ingress.kubernetes.io/ssl-redirect"])
+
+	// Test 3: The ingress is deleted when it is disabled.
+	rayService.Spec.EnableGRPCIngress = nil
+	err = r.reconcileServeGRPCIngress(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, ingressKey, ingress)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileServeGRPCRoute(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1alpha1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	cluster := rayv1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayv1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "ray-head",
+								Ports: []corev1.ContainerPort{
+									{Name: common.DefaultServingPortName, ContainerPort: 8000},
+									{Name: common.DefaultServingGRPCPortName, ContainerPort: 9000},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+			UID:       "test-service-uid",
+		},
+		Spec: rayv1alpha1.RayServiceSpec{
+			GRPCRoute: &rayv1alpha1.GRPCRouteOptions{
+				ParentRefs: []rayv1alpha1.GatewayParentReference{{Name: "gateway"}},
+			},
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+		Log:      ctrl.Log.WithName("controllers").WithName("RayService"),
+	}
+	ctx := context.TODO()
+	getRoute := func() (*unstructured.Unstructured, error) {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(common.GRPCRouteGVK)
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: utils.GenerateServeGRPCRouteName(rayService.Name), Namespace: namespace}, route)
+		return route, err
+	}
+
+	// Test 1: Nothing is created when the GRPCRoute CRD is not installed.
+	err := r.reconcileServeGRPCRoute(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	_, err = getRoute()
+	assert.True(t, errors.IsNotFound(err))
+
+	// Test 2: The GRPCRoute is created.
+	r.GRPCRouteServed = true
+	err = r.reconcileServeGRPCRoute(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	route, err := getRoute()
+	assert.Nil(t, err)
+	assert.True(t, metav1.IsControlledBy(route, &rayService))
+
+	// Test 3: The existing GRPCRoute is updated.
+	rayService.Spec.GRPCRoute.Hostnames = []string{"inference.example.com"}
+	err = r.reconcileServeGRPCRoute(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	route, err = getRoute()
+	assert.Nil(t, err)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"inference.example.com"}, hostnames)
+
+	// Test 4: The GRPCRoute is deleted when it is disabled.
+	rayService.Spec.GRPCRoute = nil
+	err = r.reconcileServeGRPCRoute(ctx, &rayService, &cluster)
+	assert.Nil(t, err)
+	_, err = getRoute()
+	assert.True(t, errors.IsNotFound(err))
+}
+
 func TestFetchHeadServiceURL(t *testing.T) {
 	// Create a new scheme with CRDs, Pod, Service schemes.
 	newScheme := runtime.NewScheme()
@@ -564,6 +1225,69 @@ func TestGetAndCheckServeStatus(t *testing.T) {
 	assert.False(t, isReady)
 }
 
+func TestSetServeDeploymentScaleStatus(t *testing.T) {
+	timeNow := metav1.Now()
+
+	// Test 1: The deployment status is from the single-application API, which does not report replicas.
+	deploymentStatus := rayv1alpha1.ServeDeploymentStatus{Status: rayv1alpha1.DeploymentStatusEnum.HEALTHY}
+	setServeDeploymentScaleStatus(&deploymentStatus, utils.ServeDeploymentStatus{Status: rayv1alpha1.DeploymentStatusEnum.HEALTHY}, rayv1alpha1.ServeDeploymentStatus{}, timeNow)
+	assert.Nil(t, deploymentStatus.TargetReplicas)
+	assert.Nil(t, deploymentStatus.RunningReplicas)
+	assert.Empty(t, deploymentStatus.ScaleEvents)
+
+	// Test 2: Without autoscaling and target_num_replicas, the target is the configured number of replicas.
+	deployment := utils.ServeDeploymentStatus{
+		Status:           rayv1alpha1.DeploymentStatusEnum.UPDATING,
+		DeploymentConfig: &utils.ServeDeploymentConfig{NumReplicas: pointer.Int32(2)},
+		Replicas: []utils.ServeReplicaDetails{
+			{ReplicaId: "r1", State: common.ServeReplicaStateRunning},
+			{ReplicaId: "r2", State: "STARTING"},
+		},
+	}
+	deploymentStatus = rayv1alpha1.ServeDeploymentStatus{}
+	setServeDeploymentScaleStatus(&deploymentStatus, deployment, rayv1alpha1.ServeDeploymentStatus{}, timeNow)
+	assert.Equal(t, int32(2), *deploymentStatus.TargetReplicas)
+	assert.Equal(t, int32(1), *deploymentStatus.RunningReplicas)
+	assert.Empty(t, deploymentStatus.AutoscalingConfig)
+	assert.Empty(t, deploymentStatus.ScaleEvents)
+
+	// Test 3: The autoscaler changes the target number of replicas. A scale event is recorded.
+	deployment = utils.ServeDeploymentStatus{
+		Status:            rayv1alpha1.DeploymentStatusEnum.UPDATING,
+		StatusTrigger:     "AUTOSCALING",
+		TargetNumReplicas: pointer.Int32(3),
+		DeploymentConfig: &utils.ServeDeploymentConfig{
+			AutoscalingConfig: map[string]interface{}{"min_replicas": 1, "max_replicas": 5},
+		},
+	}
+	prevStatus := deploymentStatus
+	deploymentStatus = rayv1alpha1.ServeDeploymentStatus{}
+	setServeDeploymentScaleStatus(&deploymentStatus, deployment, prevStatus, timeNow)
+	assert.Equal(t, int32(3), *deploymentStatus.TargetReplicas)
+	assert.Equal(t, int32(0), *deploymentStatus.RunningReplicas)
+	assert.Equal(t, `{"max_replicas":5,"min_replicas":1}`, deploymentStatus.AutoscalingConfig)
+	assert.Equal(t, []rayv1alpha1.ServeDeploymentScaleEvent{
+		{Time: timeNow, FromReplicas: 2, ToReplicas: 3, Trigger: "AUTOSCALING"},
+	}, deploymentStatus.ScaleEvents)
+
+	// Test 4: Only the most recent scale events are kept.
+	for i := 0; i < common.MaxServeDeploymentScaleEvents+2; i++ {
+		deployment.TargetNumReplicas = pointer.Int32(int32(4 + i))
+		prevStatus = deploymentStatus
+		deploymentStatus = rayv1alpha1.ServeDeploymentStatus{}
+		setServeDeploymentScaleStatus(&deploymentStatus, deployment, prevStatus, timeNow)
+	}
+	assert.Len(t, deploymentStatus.ScaleEvents, common.MaxServeDeploymentScaleEvents)
+	lastScaleEvent := deploymentStatus.ScaleEvents[common.MaxServeDeploymentScaleEvents-1]
+	assert.Equal(t, *deploymentStatus.TargetReplicas, lastScaleEvent.ToReplicas)
+
+	// Test 5: The target number of replicas is unchanged. The previous scale events are preserved.
+	prevStatus = deploymentStatus
+	deploymentStatus = rayv1alpha1.ServeDeploymentStatus{}
+	setServeDeploymentScaleStatus(&deploymentStatus, deployment, prevStatus, timeNow)
+	assert.Equal(t, prevStatus.ScaleEvents, deploymentStatus.ScaleEvents)
+}
+
 func TestCheckIfNeedSubmitServeDeployment(t *testing.T) {
 	// Create a new scheme with CRDs, Pod, Service schemes.
 	newScheme := runtime.NewScheme()
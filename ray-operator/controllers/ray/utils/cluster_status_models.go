@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ClusterStatusResponse is the response of the dashboard's cluster status API (ClusterStatusPath).
+type ClusterStatusResponse struct {
+	Result bool   `json:"result"`
+	Msg    string `json:"msg,omitempty"`
+	Data   struct {
+		// ClusterStatus is nil until the Ray monitor of the head Pod has published the first status.
+		ClusterStatus *ClusterStatus `json:"clusterStatus"`
+	} `json:"data"`
+}
+
+// ClusterStatus is the autoscaling status published by the Ray monitor to the GCS.
+type ClusterStatus struct {
+	// Time is the Unix time, in seconds, at which the Ray monitor published the status.
+	Time              float64           `json:"time"`
+	LoadMetricsReport LoadMetricsReport `json:"load_metrics_report"`
+}
+
+// LoadMetricsReport describes the resource demand and the resource usage of a Ray cluster.
+type LoadMetricsReport struct {
+	// ResourceDemand lists the resource shapes of pending tasks and actors.
+	ResourceDemand []ResourceDemand `json:"resource_demand"`
+	// PlacementGroupDemand lists the pending placement groups.
+	PlacementGroupDemand []PlacementGroupDemand `json:"pg_demand"`
+	// UsageByNode maps the IP of every alive Ray node to the used and the total amount of each of its resources.
+	UsageByNode map[string]map[string][2]float64 `json:"usage_by_node"`
+}
+
+// ResourceDemand is a resource shape and the number of pending requests for it. The Ray monitor serializes it as a
+// two-element array: [{"CPU": 1.0}, 3].
+type ResourceDemand struct {
+	Resources map[string]float64
+	Count     int
+}
+
+func (d *ResourceDemand) UnmarshalJSON(data []byte) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 2 {
+		return fmt.Errorf("expected a [resources, count] pair, got %s", string(data))
+	}
+	if err := json.Unmarshal(tuple[0], &d.Resources); err != nil {
+		return err
+	}
+	return json.Unmarshal(tuple[1], &d.Count)
+}
+
+// PlacementGroupDemand is a pending placement group shape and the number of pending placement groups with it. The Ray
+// monitor serializes it as a two-element array: [{"strategy": "PACK", "bundles": [[{"CPU": 1.0}, 2]]}, 1].
+type PlacementGroupDemand struct {
+	Strategy string
+	Bundles  []ResourceDemand
+	Count    int
+}
+
+func (d *PlacementGroupDemand) UnmarshalJSON(data []byte) error {
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 2 {
+		return fmt.Errorf("expected a [placement group, count] pair, got %s", string(data))
+	}
+	var placementGroup struct {
+		Strategy string           `json:"strategy"`
+		Bundles  []ResourceDemand `json:"bundles"`
+	}
+	if err := json.Unmarshal(tuple[0], &placementGroup); err != nil {
+		return err
+	}
+	d.Strategy = placementGroup.Strategy
+	d.Bundles = placementGroup.Bundles
+	return json.Unmarshal(tuple[1], &d.Count)
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	// DefaultDashboardMetricsPort is the port on which the Ray dashboard of the head Pod exports its own metrics.
+	DefaultDashboardMetricsPort = 44227
+	// dashboardAPIRequestsMetric counts the requests served by the Ray dashboard, labeled by request path.
+	dashboardAPIRequestsMetric = "ray_dashboard_api_requests_count_requests_total"
+)
+
+// KubeRayDashboardPaths are the path prefixes of the dashboard requests made by KubeRay itself and by the probes of
+// the head Pod. They are not user activity.
+var KubeRayDashboardPaths = []string{"/api/serve/", "/api/jobs/", "/api/cluster_status", "/api/gcs_healthz"}
+
+// FetchDashboardRequestCountFunc Used for unit tests.
+var FetchDashboardRequestCountFunc = FetchDashboardRequestCount
+
+// FetchDashboardRequestCount scrapes the metrics exported by the Ray dashboard at metricsURL, and returns the number of
+// requests the dashboard served, ignoring the paths starting with one of ignoredPaths.
+func FetchDashboardRequestCount(ctx context.Context, metricsURL string, ignoredPaths []string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+metricsURL+"/metrics", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	client := http.Client{Timeout: 10 * time.Second, Transport: newTracingTransport("DashboardMetricsClient", nil)}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("FetchDashboardRequestCount fail: %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	family, ok := families[dashboardAPIRequestsMetric]
+	if !ok {
+		return 0, fmt.Errorf("FetchDashboardRequestCount fail: metric %s not found", dashboardAPIRequestsMetric)
+	}
+
+	count := 0.0
+	for _, metric := range family.GetMetric() {
+		path := ""
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "endpoint" {
+				path = label.GetValue()
+			}
+		}
+		if isIgnoredDashboardPath(path, ignoredPaths) {
+			continue
+		}
+		count += metric.GetCounter().GetValue()
+	}
+	return int64(count), nil
+}
+
+func isIgnoredDashboardPath(path string, ignoredPaths []string) bool {
+	for _, ignoredPath := range ignoredPaths {
+		if strings.HasPrefix(path, ignoredPath) {
+			return true
+		}
+	}
+	return false
+}
@@ -31,10 +31,15 @@ var (
 	DeployPathV2     = "/api/serve/applications/"
 	// Job URL paths
 	JobPath = "/api/jobs/"
+	// Cluster status URL path
+	ClusterStatusPath = "/api/cluster_status"
 )
 
+// DashboardAuthTokenKey is the key of the token in the dashboard auth Secret of a RayCluster.
+const DashboardAuthTokenKey = "token"
+
 type RayDashboardClientInterface interface {
-	InitClient(url string)
+	InitClient(url string, authToken string)
 	GetDeployments(context.Context) (string, error)
 	UpdateDeployments(ctx context.Context, configJson []byte, serveConfigType RayServeConfigType) error
 	// V1/single-app Rest API
@@ -44,8 +49,10 @@ type RayDashboardClientInterface interface {
 	GetMultiApplicationStatus(context.Context) (map[string]*ServeApplicationStatus, error)
 	ConvertServeConfigV1(rayv1alpha1.ServeDeploymentGraphSpec) ServingClusterDeployments
 	GetJobInfo(ctx context.Context, jobId string) (*RayJobInfo, error)
+	ListJobs(ctx context.Context) ([]RayJobInfo, error)
 	SubmitJob(ctx context.Context, rayJob *rayv1alpha1.RayJob, log *logr.Logger) (jobId string, err error)
 	StopJob(ctx context.Context, jobName string, log *logr.Logger) (err error)
+	GetClusterStatus(ctx context.Context) (*ClusterStatus, error)
 }
 
 type BaseDashboardClient struct {
@@ -106,13 +113,50 @@ func FetchHeadServiceURL(ctx context.Context, log *logr.Logger, cli client.Clien
 	return headServiceURL, nil
 }
 
-func (r *RayDashboardClient) InitClient(url string) {
+// InitClient initializes the client. If authToken is not empty, it is sent as a bearer token to the dashboard proxy.
+func (r *RayDashboardClient) InitClient(url string, authToken string) {
 	r.client = http.Client{
-		Timeout: 120 * time.Second,
+		Timeout:   120 * time.Second,
+		Transport: &bearerTokenTransport{token: authToken, base: newTracingTransport("RayDashboardClient", nil)},
 	}
 	r.dashboardURL = "http://" + url
 }
 
+// bearerTokenTransport adds the Authorization header expected by the dashboard proxy to every request, and records
+// the latency and the outcome of every request.
+type bearerTokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.token != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	observeDashboardRequest(req, resp, err, time.Since(start))
+	return resp, err
+}
+
+// FetchDashboardAuthToken returns the token accepted by the dashboard proxy of the RayCluster, or an empty string if
+// dashboard auth is disabled or the RayCluster does not have a dashboard auth Secret yet. Pass an uncached reader, so
+// that the manager does not cache every Secret.
+func FetchDashboardAuthToken(ctx context.Context, cli client.Reader, rayCluster *rayv1alpha1.RayCluster) (string, error) {
+	if rayCluster.Spec.DashboardAuth == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: rayCluster.Namespace, Name: GenerateDashboardAuthSecretName(rayCluster.Name)}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(secret.Data[DashboardAuthTokenKey]), nil
+}
+
 // GetDeployments get the current deployments in the Ray cluster.
 func (r *RayDashboardClient) GetDeployments(ctx context.Context) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+DeployPath, nil)
@@ -369,6 +413,35 @@ func (r *RayDashboardClient) GetJobInfo(ctx context.Context, jobId string) (*Ray
 	return &jobInfo, nil
 }
 
+// ListJobs returns the submitted jobs and the drivers, such as those of Ray clients, known by the Ray cluster.
+func (r *RayDashboardClient) ListJobs(ctx context.Context) ([]RayJobInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.dashboardURL+JobPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ListJobs fail: %s %s", resp.Status, string(body))
+	}
+
+	var jobInfos []RayJobInfo
+	if err = json.Unmarshal(body, &jobInfos); err != nil {
+		return nil, fmt.Errorf("ListJobs fail: %s", string(body))
+	}
+
+	return jobInfos, nil
+}
+
 func (r *RayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1alpha1.RayJob, log *logr.Logger) (jobId string, err error) {
 	request, err := ConvertRayJobToReq(rayJob)
 	if err != nil {
@@ -438,6 +511,39 @@ func (r *RayDashboardClient) StopJob(ctx context.Context, jobName string, log *l
 	return nil
 }
 
+// GetClusterStatus returns the autoscaling status published by the Ray monitor, or nil if the monitor has not
+// published it yet.
+func (r *RayDashboardClient) GetClusterStatus(ctx context.Context) (*ClusterStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.dashboardURL+ClusterStatusPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s %s", resp.Status, string(body))
+	}
+
+	var statusResp ClusterStatusResponse
+	if err = json.Unmarshal(body, &statusResp); err != nil {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s", string(body))
+	}
+	if !statusResp.Result {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s", statusResp.Msg)
+	}
+
+	return statusResp.Data.ClusterStatus, nil
+}
+
 func ConvertRayJobToReq(rayJob *rayv1alpha1.RayJob) (*RayJobRequest, error) {
 	req := &RayJobRequest{
 		Entrypoint: rayJob.Spec.Entrypoint,
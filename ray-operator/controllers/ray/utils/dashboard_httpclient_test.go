@@ -44,7 +44,7 @@ var _ = Describe("RayFrameworkGenerator", func() {
 			},
 		}
 		rayDashboardClient = &RayDashboardClient{}
-		rayDashboardClient.InitClient("127.0.0.1:8090")
+		rayDashboardClient.InitClient("127.0.0.1:8090", "")
 	})
 
 	It("Test ConvertRayJobToReq", func() {
@@ -138,4 +138,83 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		err := rayDashboardClient.StopJob(context.TODO(), "stop-job-1", &ctrl.Log)
 		Expect(err).To(BeNil())
 	})
+
+	It("Test getting the cluster status", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			httpmock.NewStringResponder(200, `{
+				"result": true,
+				"msg": "Got cluster status.",
+				"data": {
+					"clusterStatus": {
+						"time": 1700000000.5,
+						"load_metrics_report": {
+							"resource_demand": [[{"CPU": 1.0}, 3]],
+							"pg_demand": [[{"strategy": "PACK", "bundles": [[{"GPU": 1.0}, 2]]}, 1]],
+							"usage_by_node": {"10.0.0.1": {"CPU": [1.0, 4.0]}}
+						}
+					}
+				}
+			}`))
+
+		clusterStatus, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).To(BeNil())
+		report := clusterStatus.LoadMetricsReport
+		Expect(report.ResourceDemand).To(Equal([]ResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 3}}))
+		Expect(report.PlacementGroupDemand).To(Equal([]PlacementGroupDemand{{
+			Strategy: "PACK",
+			Bundles:  []ResourceDemand{{Resources: map[string]float64{"GPU": 1}, Count: 2}},
+			Count:    1,
+		}}))
+		Expect(report.UsageByNode["10.0.0.1"]["CPU"]).To(Equal([2]float64{1, 4}))
+	})
+
+	It("Test getting the cluster status before the Ray monitor publishes it", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "", "data": {"clusterStatus": null}}`))
+
+		clusterStatus, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(clusterStatus).To(BeNil())
+	})
+
+	It("Test listing jobs", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+JobPath,
+			httpmock.NewStringResponder(200, `[
+				{"type": "SUBMISSION", "submission_id": "raysubmit_test001", "status": "SUCCEEDED", "entrypoint": "python samply.py"},
+				{"type": "DRIVER", "job_id": "02000000", "status": "RUNNING", "entrypoint": ""}
+			]`))
+
+		jobInfos, err := rayDashboardClient.ListJobs(context.TODO())
+		Expect(err).To(BeNil())
+		Expect(len(jobInfos)).To(Equal(2))
+		Expect(jobInfos[0].JobStatus).To(Equal(rayv1alpha1.JobStatusSucceeded))
+		Expect(jobInfos[1].JobStatus).To(Equal(rayv1alpha1.JobStatusRunning))
+	})
+
+	It("Test counting the dashboard requests", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", "http://10.0.0.1:44227/metrics",
+			httpmock.NewStringResponder(200, `# HELP ray_dashboard_api_requests_count_requests_total Total requests count per endpoint.
+# TYPE ray_dashboard_api_requests_count_requests_total counter
+ray_dashboard_api_requests_count_requests_total{Component="dashboard",endpoint="/nodes",http_status="2xx",method="GET"} 12.0
+ray_dashboard_api_requests_count_requests_total{Component="dashboard",endpoint="/logical/actors",http_status="2xx",method="GET"} 3.0
+ray_dashboard_api_requests_count_requests_total{Component="dashboard",endpoint="/api/cluster_status",http_status="2xx",method="GET"} 40.0
+ray_dashboard_api_requests_count_requests_total{Component="dashboard",endpoint="/api/jobs/",http_status="2xx",method="GET"} 7.0
+`))
+
+		count, err := FetchDashboardRequestCount(context.TODO(), "10.0.0.1:44227", KubeRayDashboardPaths)
+		Expect(err).To(BeNil())
+		Expect(count).To(Equal(int64(15)))
+
+		count, err = FetchDashboardRequestCount(context.TODO(), "10.0.0.1:44227", nil)
+		Expect(err).To(BeNil())
+		Expect(count).To(Equal(int64(62)))
+	})
 })
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Define the prometheus metrics for the requests sent by the dashboard client. They are defined here rather than in
+// the common package because the common package depends on this one.
+var (
+	dashboardRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ray_operator_dashboard_request_duration_seconds",
+			Help:    "Latency of the requests sent to the Ray dashboard",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "path"},
+	)
+	dashboardRequestsCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_dashboard_requests_total",
+			Help: "Counts number of requests sent to the Ray dashboard by response code, or \"error\" if no response was received",
+		},
+		[]string{"method", "path", "code"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(dashboardRequestDuration, dashboardRequestsCount)
+}
+
+// observeDashboardRequest records the latency and the outcome of a request sent to the Ray dashboard.
+func observeDashboardRequest(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	path := dashboardRequestPath(req.URL.Path)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	dashboardRequestDuration.WithLabelValues(req.Method, path).Observe(duration.Seconds())
+	dashboardRequestsCount.WithLabelValues(req.Method, path, code).Inc()
+}
+
+// dashboardRequestPath replaces the job ID in the path of the job API with a placeholder to bound the number of series.
+func dashboardRequestPath(path string) string {
+	if !strings.HasPrefix(path, JobPath) || path == JobPath {
+		return path
+	}
+	if strings.HasSuffix(path, "/stop") {
+		return JobPath + "{job_id}/stop"
+	}
+	return JobPath + "{job_id}"
+}
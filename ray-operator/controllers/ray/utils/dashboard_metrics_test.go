@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardRequestPath(t *testing.T) {
+	assert.Equal(t, JobPath, dashboardRequestPath(JobPath))
+	assert.Equal(t, JobPath+"{job_id}", dashboardRequestPath(JobPath+"raysubmit_test001"))
+	assert.Equal(t, JobPath+"{job_id}/stop", dashboardRequestPath(JobPath+"raysubmit_test001/stop"))
+	assert.Equal(t, ServeDetailsPath, dashboardRequestPath(ServeDetailsPath))
+}
+
+func TestDashboardRequestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	rayDashboardClient := &RayDashboardClient{}
+	rayDashboardClient.InitClient(strings.TrimPrefix(server.URL, "http://"), "")
+	_, err := rayDashboardClient.GetJobInfo(context.TODO(), "raysubmit_test001")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(dashboardRequestsCount.WithLabelValues(http.MethodGet, JobPath+"{job_id}", "500")))
+	assert.Equal(t, 1, testutil.CollectAndCount(dashboardRequestDuration))
+}
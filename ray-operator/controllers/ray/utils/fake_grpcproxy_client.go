@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"context"
+)
+
+func GetFakeRayGrpcProxyClient() RayGrpcProxyClientInterface {
+	return &FakeRayGrpcProxyClient{}
+}
+
+type FakeRayGrpcProxyClient struct{}
+
+func (r *FakeRayGrpcProxyClient) InitClient() {}
+
+func (r *FakeRayGrpcProxyClient) SetHostIp(_ string, _ int) {}
+
+func (r *FakeRayGrpcProxyClient) CheckHealth(_ context.Context) error {
+	// Always return successful.
+	return nil
+}
@@ -15,11 +15,13 @@ type FakeRayDashboardClient struct {
 	singleAppStatus  ServeApplicationStatus
 	multiAppStatuses map[string]*ServeApplicationStatus
 	serveDetails     ServeDetails
+	clusterStatus    *ClusterStatus
+	jobInfos         []RayJobInfo
 }
 
 var _ RayDashboardClientInterface = (*FakeRayDashboardClient)(nil)
 
-func (r *FakeRayDashboardClient) InitClient(url string) {
+func (r *FakeRayDashboardClient) InitClient(url string, _ string) {
 	r.client = http.Client{}
 	r.dashboardURL = "http://" + url
 }
@@ -57,6 +59,14 @@ func (r *FakeRayDashboardClient) GetJobInfo(_ context.Context, jobId string) (*R
 	return nil, nil
 }
 
+func (r *FakeRayDashboardClient) ListJobs(_ context.Context) ([]RayJobInfo, error) {
+	return r.jobInfos, nil
+}
+
+func (r *FakeRayDashboardClient) SetJobInfos(jobInfos []RayJobInfo) {
+	r.jobInfos = jobInfos
+}
+
 func (r *FakeRayDashboardClient) SubmitJob(_ context.Context, rayJob *rayv1alpha1.RayJob, log *logr.Logger) (jobId string, err error) {
 	return "", nil
 }
@@ -64,3 +74,11 @@ func (r *FakeRayDashboardClient) SubmitJob(_ context.Context, rayJob *rayv1alpha
 func (r *FakeRayDashboardClient) StopJob(_ context.Context, jobName string, log *logr.Logger) (err error) {
 	return nil
 }
+
+func (r *FakeRayDashboardClient) GetClusterStatus(_ context.Context) (*ClusterStatus, error) {
+	return r.clusterStatus, nil
+}
+
+func (r *FakeRayDashboardClient) SetClusterStatus(status *ClusterStatus) {
+	r.clusterStatus = status
+}
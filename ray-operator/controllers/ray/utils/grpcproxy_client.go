@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type RayGrpcProxyClientInterface interface {
+	InitClient()
+	CheckHealth(ctx context.Context) error
+	SetHostIp(hostIp string, port int)
+}
+
+// GetRayGrpcProxyClientFunc Used for unit tests.
+var GetRayGrpcProxyClientFunc = GetRayGrpcProxyClient
+
+func GetRayGrpcProxyClient() RayGrpcProxyClientInterface {
+	return &RayGrpcProxyClient{}
+}
+
+// RayGrpcProxyClient checks the health of the Ray Serve gRPC proxy with the standard gRPC health checking protocol.
+type RayGrpcProxyClient struct {
+	timeout      time.Duration
+	grpcProxyURL string
+}
+
+func (r *RayGrpcProxyClient) InitClient() {
+	r.timeout = 100 * time.Millisecond
+}
+
+func (r *RayGrpcProxyClient) SetHostIp(hostIp string, port int) {
+	r.grpcProxyURL = fmt.Sprintf("%s:%d", hostIp, port)
+}
+
+func (r *RayGrpcProxyClient) CheckHealth(ctx context.Context) error {
+	// The connection is established in the background, so that the timeout only bounds the health check RPC.
+	conn, err := grpc.Dial(r.grpcProxyURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("RayGrpcProxyClient CheckHealth fail: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRayGrpcProxyClientCheckHealth(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	client := GetRayGrpcProxyClient()
+	client.InitClient()
+	client.SetHostIp("127.0.0.1", listener.Addr().(*net.TCPAddr).Port)
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	assert.Nil(t, client.CheckHealth(context.Background()))
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.NotNil(t, client.CheckHealth(context.Background()))
+
+	// The dial does not block, so a proxy which is not listening fails the health check RPC.
+	server.Stop()
+	assert.NotNil(t, client.CheckHealth(context.Background()))
+}
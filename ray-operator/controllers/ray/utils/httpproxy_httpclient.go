@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,7 +12,7 @@ const healthCheckPath = "/-/healthz"
 
 type RayHttpProxyClientInterface interface {
 	InitClient()
-	CheckHealth() error
+	CheckHealth(ctx context.Context) error
 	SetHostIp(hostIp string, port int)
 }
 
@@ -29,7 +30,8 @@ type RayHttpProxyClient struct {
 
 func (r *RayHttpProxyClient) InitClient() {
 	r.client = http.Client{
-		Timeout: 20 * time.Millisecond,
+		Timeout:   20 * time.Millisecond,
+		Transport: newTracingTransport("RayHttpProxyClient", nil),
 	}
 }
 
@@ -37,8 +39,8 @@ func (r *RayHttpProxyClient) SetHostIp(hostIp string, port int) {
 	r.httpProxyURL = fmt.Sprintf("http://%s:%d", hostIp, port)
 }
 
-func (r *RayHttpProxyClient) CheckHealth() error {
-	req, err := http.NewRequest("GET", r.httpProxyURL+healthCheckPath, nil)
+func (r *RayHttpProxyClient) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.httpProxyURL+healthCheckPath, nil)
 	if err != nil {
 		return err
 	}
@@ -29,6 +29,23 @@ type ServeDeploymentStatus struct {
 	Name    string `json:"name,omitempty"`
 	Status  string `json:"status,omitempty"`
 	Message string `json:"message,omitempty"`
+	// The fields below are only returned by the V2/multi-app GET API.
+	StatusTrigger     string                 `json:"status_trigger,omitempty"`
+	TargetNumReplicas *int32                 `json:"target_num_replicas,omitempty"`
+	DeploymentConfig  *ServeDeploymentConfig `json:"deployment_config,omitempty"`
+	Replicas          []ServeReplicaDetails  `json:"replicas,omitempty"`
+}
+
+// Describes the subset of a deployment's config in effect that KubeRay reports in the RayService status
+type ServeDeploymentConfig struct {
+	NumReplicas       *int32                 `json:"num_replicas,omitempty"`
+	AutoscalingConfig map[string]interface{} `json:"autoscaling_config,omitempty"`
+}
+
+// Describes a single replica of a deployment
+type ServeReplicaDetails struct {
+	ReplicaId string `json:"replica_id,omitempty"`
+	State     string `json:"state,omitempty"`
 }
 
 // Describes the status of an application
@@ -59,6 +76,19 @@ type ServeDetails struct {
 	DeployMode   string                             `json:"deploy_mode,omitempty"`
 }
 
+// ServeConfigV2 is the subset of the multi-application Serve config (`serveConfigV2`) that KubeRay reads.
+// See https://docs.ray.io/en/latest/serve/api/doc/ray.serve.schema.ServeDeploySchema.html for the full schema.
+type ServeConfigV2 struct {
+	Applications []ServeConfigV2Application `json:"applications"`
+}
+
+// ServeConfigV2Application describes a single application in the multi-application Serve config.
+type ServeConfigV2Application struct {
+	Name        string `json:"name"`
+	RoutePrefix string `json:"route_prefix,omitempty"`
+	ImportPath  string `json:"import_path,omitempty"`
+}
+
 // ServingClusterDeployments defines the request sent to the dashboard api server.
 // See https://docs.ray.io/en/master/_modules/ray/serve/schema.html#ServeApplicationSchema for more details.
 type ServingClusterDeployments struct {
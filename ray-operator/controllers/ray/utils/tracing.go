@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the KubeRay operator.
+const TracerName = "github.com/ray-project/kuberay/ray-operator"
+
+// InitTracing exports the spans of the operator via OTLP over HTTP to the endpoint, e.g. http://otel-collector:4318,
+// and propagates the trace context in the headers of HTTP requests. Tracing is a no-op until InitTracing is called.
+// The returned function flushes the pending spans and stops the exporter.
+func InitTracing(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL such as http://otel-collector:4318", endpoint)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("kuberay-operator"))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer used for all the spans of the operator.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// endSpan records the error, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingReconciler starts a span for every reconciliation of the wrapped reconciler.
+type tracingReconciler struct {
+	kind       string
+	reconciler reconcile.Reconciler
+}
+
+// NewTracingReconciler wraps the reconciler of the custom resource kind so that every reconciliation is a span, which
+// is the parent of the spans of the Kubernetes API calls and dashboard requests of the reconciliation.
+func NewTracingReconciler(kind string, reconciler reconcile.Reconciler) reconcile.Reconciler {
+	return &tracingReconciler{kind: kind, reconciler: reconciler}
+}
+
+func (r *tracingReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := Tracer().Start(ctx, r.kind+" Reconcile", trace.WithAttributes(
+		attribute.String("k8s.namespace.name", request.Namespace),
+		attribute.String("kuberay.resource.kind", r.kind),
+		attribute.String("kuberay.resource.name", request.Name),
+	))
+	result, err := r.reconciler.Reconcile(ctx, request)
+	span.SetAttributes(
+		attribute.Bool("kuberay.reconcile.requeue", result.Requeue || result.RequeueAfter > 0),
+		attribute.String("kuberay.reconcile.requeue_after", result.RequeueAfter.String()),
+	)
+	endSpan(span, err)
+	return result, err
+}
+
+// tracingClient starts a span for every call of the wrapped Kubernetes client.
+type tracingClient struct {
+	client.Client
+}
+
+// NewTracingClient wraps the Kubernetes client so that every call is a span. Reads served by the informer cache are
+// traced as well.
+func NewTracingClient(c client.Client) client.Client {
+	return &tracingClient{Client: c}
+}
+
+func startClientSpan(ctx context.Context, c client.Client, verb string, obj client.Object) (context.Context, trace.Span) {
+	return startClientSpanForKey(ctx, c, verb, obj, client.ObjectKeyFromObject(obj))
+}
+
+func startClientSpanForKey(ctx context.Context, c client.Client, verb string, obj runtime.Object, key client.ObjectKey) (context.Context, trace.Span) {
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	return Tracer().Start(ctx, verb+" "+kind, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("k8s.namespace.name", key.Namespace),
+		attribute.String("k8s.object.name", key.Name),
+	))
+}
+
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) (err error) {
+	ctx, span := startClientSpanForKey(ctx, c.Client, "Get", obj, key)
+	defer func() { endSpan(span, err) }()
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	ctx, span := startClientSpanForKey(ctx, c.Client, "List", list, client.ObjectKey{Namespace: listOpts.Namespace})
+	defer func() { endSpan(span, err) }()
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) (err error) {
+	ctx, span := startClientSpan(ctx, c.Client, "Create", obj)
+	defer func() { endSpan(span, err) }()
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) (err error) {
+	ctx, span := startClientSpan(ctx, c.Client, "Delete", obj)
+	defer func() { endSpan(span, err) }()
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := startClientSpan(ctx, c.Client, "Update", obj)
+	defer func() { endSpan(span, err) }()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := startClientSpan(ctx, c.Client, "Patch", obj)
+	defer func() { endSpan(span, err) }()
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *tracingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) (err error) {
+	ctx, span := startClientSpan(ctx, c.Client, "DeleteAllOf", obj)
+	defer func() { endSpan(span, err) }()
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Status() client.StatusWriter {
+	return &tracingStatusWriter{StatusWriter: c.Client.Status(), client: c.Client}
+}
+
+// tracingStatusWriter starts a span for every status update of the wrapped status writer.
+type tracingStatusWriter struct {
+	client.StatusWriter
+	client client.Client
+}
+
+func (w *tracingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := startClientSpan(ctx, w.client, "UpdateStatus", obj)
+	defer func() { endSpan(span, err) }()
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w *tracingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := startClientSpan(ctx, w.client, "PatchStatus", obj)
+	defer func() { endSpan(span, err) }()
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// tracingTransport starts a span for every HTTP request of a Ray client, and propagates the trace context to the
+// server in the request headers. Requests are sent with the base transport, or with http.DefaultTransport if base is nil,
+// looked up for every request so that it can be mocked in tests.
+type tracingTransport struct {
+	component string
+	base      http.RoundTripper
+}
+
+func newTracingTransport(component string, base http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{component: component, base: base}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	ctx, span := Tracer().Start(req.Context(), t.component+" "+req.Method+" "+dashboardRequestPath(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPURLKey.String(req.URL.String()),
+		))
+	defer func() { endSpan(span, err) }()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err = base.RoundTrip(req)
+	if err == nil {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+		if resp.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	return resp, err
+}
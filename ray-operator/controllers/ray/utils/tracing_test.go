@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	oldProvider, oldPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(oldProvider)
+		otel.SetTextMapPropagator(oldPropagator)
+	})
+	return recorder
+}
+
+func TestTracingReconcilerAndClient(t *testing.T) {
+	recorder := setupTestTracing(t)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}}
+	fakeClient := NewTracingClient(clientFake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(pod).Build())
+
+	reconciler := NewTracingReconciler("RayCluster", reconcile.Func(func(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+		if err := fakeClient.Get(ctx, request.NamespacedName, &corev1.Pod{}); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, fakeClient.List(ctx, &corev1.PodList{}, client.InNamespace(request.Namespace))
+	}))
+	_, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pod)})
+	assert.Nil(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "Get Pod", spans[0].Name())
+	assert.Equal(t, "List PodList", spans[1].Name())
+	assert.Equal(t, "RayCluster Reconcile", spans[2].Name())
+	// The spans of the Kubernetes API calls are children of the span of the reconciliation.
+	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, spans[2].SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+}
+
+func TestTracingTransport(t *testing.T) {
+	recorder := setupTestTracing(t)
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	rayDashboardClient := &RayDashboardClient{}
+	rayDashboardClient.InitClient(strings.TrimPrefix(server.URL, "http://"), "")
+	_, err := rayDashboardClient.GetServeDetails(ctx)
+	assert.Nil(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Equal(t, "RayDashboardClient GET "+ServeDetailsPath, spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	// The trace context is propagated to the dashboard.
+	assert.Contains(t, traceparent, spans[0].SpanContext().TraceID().String())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTracingTransportBase(t *testing.T) {
+	recorder := setupTestTracing(t)
+	var traceparent string
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		traceparent = req.Header.Get("traceparent")
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: http.NoBody}, nil
+	})
+
+	client := http.Client{Transport: newTracingTransport("RayHttpProxyClient", base)}
+	resp, err := client.Get("http://127.0.0.1:8000/-/healthz")
+	assert.Nil(t, err)
+	resp.Body.Close()
+
+	// The requests are sent with the base transport, with the trace context of their span.
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Contains(t, traceparent, spans[0].SpanContext().TraceID().String())
+}
@@ -13,6 +13,7 @@ import (
 	"unicode"
 
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"k8s.io/apimachinery/pkg/util/rand"
 
@@ -30,6 +31,10 @@ const (
 	ServeName           = "serve"
 	ClusterDomainEnvKey = "CLUSTER_DOMAIN"
 	DefaultDomainName   = "cluster.local"
+	// The namespace of the KubeRay operator Pod, set through the downward API.
+	OperatorNamespaceEnvKey = "POD_NAMESPACE"
+	// The namespace of the service account token mounted into every Pod. Used if POD_NAMESPACE is not set.
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 // TODO (kevin85421): Define CRDType here rather than constant.go to avoid circular dependency.
@@ -51,6 +56,17 @@ func GetClusterDomainName() string {
 	return DefaultDomainName
 }
 
+// GetOperatorNamespace returns the namespace of the KubeRay operator, or an empty string if the operator does not run in a Pod.
+func GetOperatorNamespace() string {
+	if namespace := os.Getenv(OperatorNamespaceEnvKey); len(namespace) > 0 {
+		return namespace
+	}
+	if namespace, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(namespace))
+	}
+	return ""
+}
+
 // IsCreated returns true if pod has been created and is maintained by the API server
 func IsCreated(pod *corev1.Pod) bool {
 	return pod.Status.Phase != ""
@@ -69,6 +85,30 @@ func IsRunningAndReady(pod *corev1.Pod) bool {
 	return false
 }
 
+// IsRunningAndContainersReady returns true if pod is in the PodRunning Phase and all of its containers are ready.
+// Unlike IsRunningAndReady, it ignores the readiness gates of the Pod.
+func IsRunningAndContainersReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.ContainersReady && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return IsRunningAndReady(pod)
+}
+
+// HasReadinessGate returns true if the Pod spec contains a readiness gate with the given condition type.
+func HasReadinessGate(podSpec corev1.PodSpec, conditionType corev1.PodConditionType) bool {
+	for _, gate := range podSpec.ReadinessGates {
+		if gate.ConditionType == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
 // CheckName makes sure the name does not start with a numeric value and the total length is < 63 char
 func CheckName(s string) string {
 	maxLength := 50 // 63 - (max(8,6) + 5 ) // 6 to 8 char are consumed at the end with "-head-" or -worker- + 5 generated.
@@ -185,6 +225,54 @@ func GenerateServeServiceName(serviceName string) string {
 	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "svc"))
 }
 
+// GenerateServeApplicationServiceName generates name for the service of a single Serve application.
+// Serve application names are not restricted to DNS labels, so they are lowercased and every character
+// that is not allowed in a Kubernetes object name is replaced with a dash.
+func GenerateServeApplicationServiceName(serviceName string, appName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s-%s", serviceName, SanitizeDNSLabel(appName), ServeName, "svc"))
+}
+
+// GenerateServeGRPCIngressName generates the name of the ingress for the gRPC port of the serve service.
+func GenerateServeGRPCIngressName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, "serve-grpc", "ingress"))
+}
+
+// GenerateServeGRPCRouteName generates the name of the GRPCRoute for the gRPC port of the serve service.
+func GenerateServeGRPCRouteName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, "serve-grpc", "route"))
+}
+
+// GenerateServeApplicationIngressName generates name for the ingress of the per-application serve services.
+func GenerateServeApplicationIngressName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "ingress"))
+}
+
+// SanitizeDNSLabel lowercases s and replaces every character that is not a lowercase alphanumeric
+// character or a dash with a dash, so that the result can be used in a DNS-1035 label.
+func SanitizeDNSLabel(s string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, s), "-")
+}
+
+// ParseServeConfigV2Applications returns the applications defined in a multi-application Serve config.
+func ParseServeConfigV2Applications(serveConfigV2 string) ([]ServeConfigV2Application, error) {
+	serveConfig := ServeConfigV2{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &serveConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse serveConfigV2: %v", err)
+	}
+	for i, app := range serveConfig.Applications {
+		if app.Name == "" {
+			return nil, fmt.Errorf("the application at index %d in serveConfigV2 does not have a name", i)
+		}
+	}
+	return serveConfig.Applications, nil
+}
+
 // GenerateServeServiceLabel generates label value for serve service selector.
 func GenerateServeServiceLabel(serviceName string) string {
 	return fmt.Sprintf("%s-%s", serviceName, ServeName)
@@ -195,6 +283,76 @@ func GenerateIngressName(clusterName string) string {
 	return fmt.Sprintf("%s-%s-%s", clusterName, rayv1alpha1.HeadNode, "ingress")
 }
 
+// GenerateTLSCASecretName generates the name of the CA Secret KubeRay manages for a RayCluster with TLS enabled
+func GenerateTLSCASecretName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "tls-ca")
+}
+
+// GenerateTLSNodeSecretName generates the prefix of the names of the Secrets holding the node certificates of a RayCluster with TLS enabled
+func GenerateTLSNodeSecretName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, "tls"))
+}
+
+// GenerateDashboardAuthSecretName generates the name of the Secret holding the token accepted by the dashboard proxy of a RayCluster
+func GenerateDashboardAuthSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "dashboard-auth")
+}
+
+// GenerateRedisPasswordSecretName generates the name of the Secret holding the Redis password managed by KubeRay. Each
+// rotation ID gets its own Secret, so that the password of the Pods created before a rotation is never overwritten.
+func GenerateRedisPasswordSecretName(clusterName string, rotationID string) string {
+	if rotationID == "" {
+		return fmt.Sprintf("%s-%s", clusterName, "redis-password")
+	}
+	hash := sha1.Sum([]byte(rotationID))
+	return fmt.Sprintf("%s-%s-%x", clusterName, "redis-password", hash[:4])
+}
+
+// GenerateMonitorName generates the name of the Prometheus Operator monitor scraping the metrics of a RayCluster
+func GenerateMonitorName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "monitor")
+}
+
+// GenerateWorkerHeadlessServiceName generates the name of the headless service giving a DNS name to each worker Pod of a RayCluster
+func GenerateWorkerHeadlessServiceName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, "workers"))
+}
+
+// GenerateAutoscalerDeploymentName generates the name of the Deployment running the Ray autoscaler of a RayCluster
+func GenerateAutoscalerDeploymentName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, "autoscaler"))
+}
+
+// GenerateAutoscalerTLSSecretName generates the name of the Secret holding the TLS certificate of the Ray autoscaler Deployment of a RayCluster
+func GenerateAutoscalerTLSSecretName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, "autoscaler-tls"))
+}
+
+// GenerateRayWorkerGroupName generates the name of the RayWorkerGroup exposing the scale subresource of a worker group of a RayCluster
+func GenerateRayWorkerGroupName(clusterName string, groupName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, groupName))
+}
+
+// GenerateWorkloadName generates the name of the Kueue Workload requesting the quota of a RayJob
+func GenerateWorkloadName(rayJobName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", "rayjob", rayJobName))
+}
+
+// GenerateRayClusterWorkloadName generates the name of the Kueue Workload requesting the quota of a standalone RayCluster
+func GenerateRayClusterWorkloadName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", "raycluster", clusterName))
+}
+
+// GenerateNetworkPolicyName generates the name of the NetworkPolicy isolating the Pods of a RayCluster
+func GenerateNetworkPolicyName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "network-policy")
+}
+
+// GeneratePodDisruptionBudgetName generates the name of the PodDisruptionBudget protecting a group of a RayCluster
+func GeneratePodDisruptionBudgetName(clusterName string, groupName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", clusterName, groupName, "pdb"))
+}
+
 // GenerateRouteName generates an ingress name from cluster name
 func GenerateRouteName(clusterName string) string {
 	return fmt.Sprintf("%s-%s-%s", clusterName, rayv1alpha1.HeadNode, "route")
@@ -263,12 +421,12 @@ func CalculateAvailableReplicas(pods corev1.PodList) int32 {
 
 func CalculateDesiredResources(cluster *rayv1alpha1.RayCluster) corev1.ResourceList {
 	desiredResourcesList := []corev1.ResourceList{{}}
-	headPodResource := calculatePodResource(cluster.Spec.HeadGroupSpec.Template.Spec)
+	headPodResource := CalculatePodResource(cluster.Spec.HeadGroupSpec.Template.Spec)
 	for i := int32(0); i < *cluster.Spec.HeadGroupSpec.Replicas; i++ {
 		desiredResourcesList = append(desiredResourcesList, headPodResource)
 	}
 	for _, nodeGroup := range cluster.Spec.WorkerGroupSpecs {
-		podResource := calculatePodResource(nodeGroup.Template.Spec)
+		podResource := CalculatePodResource(nodeGroup.Template.Spec)
 		for i := int32(0); i < *nodeGroup.Replicas; i++ {
 			desiredResourcesList = append(desiredResourcesList, podResource)
 		}
@@ -278,12 +436,12 @@ func CalculateDesiredResources(cluster *rayv1alpha1.RayCluster) corev1.ResourceL
 
 func CalculateMinResources(cluster *rayv1alpha1.RayCluster) corev1.ResourceList {
 	minResourcesList := []corev1.ResourceList{{}}
-	headPodResource := calculatePodResource(cluster.Spec.HeadGroupSpec.Template.Spec)
+	headPodResource := CalculatePodResource(cluster.Spec.HeadGroupSpec.Template.Spec)
 	for i := int32(0); i < *cluster.Spec.HeadGroupSpec.Replicas; i++ {
 		minResourcesList = append(minResourcesList, headPodResource)
 	}
 	for _, nodeGroup := range cluster.Spec.WorkerGroupSpecs {
-		podResource := calculatePodResource(nodeGroup.Template.Spec)
+		podResource := CalculatePodResource(nodeGroup.Template.Spec)
 		for i := int32(0); i < *nodeGroup.MinReplicas; i++ {
 			minResourcesList = append(minResourcesList, podResource)
 		}
@@ -291,16 +449,13 @@ func CalculateMinResources(cluster *rayv1alpha1.RayCluster) corev1.ResourceList
 	return sumResourceList(minResourcesList)
 }
 
-func calculatePodResource(podSpec corev1.PodSpec) corev1.ResourceList {
+// CalculatePodResource returns the resources of a Pod, summing the requests of its containers, or their limits when
+// the requests are not set. The init containers run one at a time before the containers, so the Pod needs, for each
+// resource, the maximum of that sum and of the resource of each init container, like the Kubernetes scheduler computes.
+func CalculatePodResource(podSpec corev1.PodSpec) corev1.ResourceList {
 	podResource := corev1.ResourceList{}
 	for _, container := range podSpec.Containers {
-		containerResource := container.Resources.Requests
-		for name, quantity := range container.Resources.Limits {
-			if _, ok := containerResource[name]; !ok {
-				containerResource[name] = quantity
-			}
-		}
-		for name, quantity := range containerResource {
+		for name, quantity := range calculateContainerResource(container) {
 			if totalQuantity, ok := podResource[name]; ok {
 				totalQuantity.Add(quantity)
 				podResource[name] = totalQuantity
@@ -309,9 +464,30 @@ func calculatePodResource(podSpec corev1.PodSpec) corev1.ResourceList {
 			}
 		}
 	}
+	for _, container := range podSpec.InitContainers {
+		for name, quantity := range calculateContainerResource(container) {
+			if totalQuantity, ok := podResource[name]; !ok || quantity.Cmp(totalQuantity) > 0 {
+				podResource[name] = quantity
+			}
+		}
+	}
 	return podResource
 }
 
+// calculateContainerResource returns the requests of the container, or its limits when the requests are not set.
+func calculateContainerResource(container corev1.Container) corev1.ResourceList {
+	containerResource := corev1.ResourceList{}
+	for name, quantity := range container.Resources.Requests {
+		containerResource[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range container.Resources.Limits {
+		if _, ok := containerResource[name]; !ok {
+			containerResource[name] = quantity.DeepCopy()
+		}
+	}
+	return containerResource
+}
+
 func sumResourceList(list []corev1.ResourceList) corev1.ResourceList {
 	totalResource := corev1.ResourceList{}
 	for _, l := range list {
@@ -463,3 +463,52 @@ func TestGenerateHeadServiceName(t *testing.T) {
 	_, err = GenerateHeadServiceName(RayJobCRD, rayv1alpha1.RayClusterSpec{}, "rayjob-sample")
 	assert.NotNil(t, err)
 }
+
+func TestSanitizeDNSLabel(t *testing.T) {
+	assert.Equal(t, "text-ml-app", SanitizeDNSLabel("text_ml_app"))
+	assert.Equal(t, "fruit-app", SanitizeDNSLabel("_Fruit.App_"))
+	assert.Equal(t, "app1", SanitizeDNSLabel("app1"))
+}
+
+func TestParseServeConfigV2Applications(t *testing.T) {
+	apps, err := ParseServeConfigV2Applications(`
+applications:
+  - name: app1
+    route_prefix: /app1
+    import_path: app1.app
+  - name: app2
+    import_path: app2.app
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, []ServeConfigV2Application{
+		{Name: "app1", RoutePrefix: "/app1", ImportPath: "app1.app"},
+		{Name: "app2", ImportPath: "app2.app"},
+	}, apps)
+
+	_, err = ParseServeConfigV2Applications(`
+applications:
+  - import_path: app1.app
+`)
+	assert.NotNil(t, err)
+
+	_, err = ParseServeConfigV2Applications("applications: [")
+	assert.NotNil(t, err)
+}
+
+func TestIsRunningAndContainersReady(t *testing.T) {
+	pod := createSomePod()
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+		{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+	}
+	assert.False(t, IsRunningAndReady(pod))
+	assert.True(t, IsRunningAndContainersReady(pod))
+
+	pod.Status.Conditions[1].Status = corev1.ConditionFalse
+	assert.False(t, IsRunningAndContainersReady(pod))
+
+	pod.Status.Phase = corev1.PodPending
+	pod.Status.Conditions[1].Status = corev1.ConditionTrue
+	assert.False(t, IsRunningAndContainersReady(pod))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"go.uber.org/zap/zapcore"
@@ -52,6 +55,8 @@ func main() {
 	var reconcileConcurrency int
 	var watchNamespace string
 	var logFile string
+	var tracingEndpoint string
+	var preemptionQuotaGroups string
 	flag.BoolVar(&version, "version", false, "Show the version information.")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8082", "The address the probe endpoint binds to.")
@@ -69,6 +74,15 @@ func main() {
 		"Synchronize logs to local file")
 	flag.BoolVar(&ray.EnableBatchScheduler, "enable-batch-scheduler", false,
 		"Enable batch scheduler. Currently is volcano, which supports gang scheduler policy.")
+	flag.BoolVar(&ray.EnablePreemption, "enable-preemption", false,
+		"Enable the preemption of lower-priority RayClusters and RayJobs in favor of RayClusters with unschedulable Pods.")
+	flag.DurationVar(&ray.PreemptionPendingThreshold, "preemption-pending-threshold", ray.PreemptionPendingThreshold,
+		"How long Pods of a RayCluster must stay unschedulable before the RayCluster preempts lower-priority RayClusters.")
+	flag.StringVar(&preemptionQuotaGroups, "preemption-quota-groups", "",
+		"Namespaces whose RayClusters may preempt each other, such as team-a=ns-1,ns-2;team-b=ns-3. "+
+			"Otherwise, a RayCluster only preempts the RayClusters of its namespace.")
+	flag.StringVar(&tracingEndpoint, "tracing-otlp-endpoint", "",
+		"The OTLP/HTTP endpoint, e.g. http://otel-collector:4318, to export OpenTelemetry traces to. Tracing is disabled if empty.")
 
 	opts := k8szap.Options{
 		Development: true,
@@ -115,6 +129,22 @@ func main() {
 	if ray.EnableBatchScheduler {
 		setupLog.Info("Feature flag enable-batch-scheduler is enabled.")
 	}
+	quotaGroups, err := common.ParsePreemptionQuotaGroups(preemptionQuotaGroups)
+	if err != nil {
+		setupLog.Error(err, "invalid --preemption-quota-groups")
+		os.Exit(1)
+	}
+	ray.PreemptionQuotaGroups = quotaGroups
+
+	shutdownTracing := func(context.Context) error { return nil }
+	if tracingEndpoint != "" {
+		var err error
+		if shutdownTracing, err = utils.InitTracing(context.Background(), tracingEndpoint); err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		setupLog.Info("OpenTelemetry tracing is enabled.", "endpoint", tracingEndpoint)
+	}
 
 	watchNamespaces := strings.Split(watchNamespace, ",")
 	options := ctrl.Options{
@@ -168,8 +198,12 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
+	startErr := mgr.Start(ctrl.SetupSignalHandler())
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+	if startErr != nil {
+		setupLog.Error(startErr, "problem running manager")
 		os.Exit(1)
 	}
 }
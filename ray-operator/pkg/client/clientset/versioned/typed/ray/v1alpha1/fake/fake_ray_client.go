@@ -24,6 +24,10 @@ func (c *FakeRayV1alpha1) RayServices(namespace string) v1alpha1.RayServiceInter
 	return &FakeRayServices{c, namespace}
 }
 
+func (c *FakeRayV1alpha1) RayWorkerGroups(namespace string) v1alpha1.RayWorkerGroupInterface {
+	return &FakeRayWorkerGroups{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeRayV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRayWorkerGroups implements RayWorkerGroupInterface
+type FakeRayWorkerGroups struct {
+	Fake *FakeRayV1alpha1
+	ns   string
+}
+
+var rayworkergroupsResource = schema.GroupVersionResource{Group: "ray.io", Version: "v1alpha1", Resource: "rayworkergroups"}
+
+var rayworkergroupsKind = schema.GroupVersionKind{Group: "ray.io", Version: "v1alpha1", Kind: "RayWorkerGroup"}
+
+// Get takes name of the rayWorkerGroup, and returns the corresponding rayWorkerGroup object, and an error if there is any.
+func (c *FakeRayWorkerGroups) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(rayworkergroupsResource, c.ns, name), &v1alpha1.RayWorkerGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RayWorkerGroup), err
+}
+
+// List takes label and field selectors, and returns the list of RayWorkerGroups that match those selectors.
+func (c *FakeRayWorkerGroups) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.RayWorkerGroupList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(rayworkergroupsResource, rayworkergroupsKind, c.ns, opts), &v1alpha1.RayWorkerGroupList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.RayWorkerGroupList{ListMeta: obj.(*v1alpha1.RayWorkerGroupList).ListMeta}
+	for _, item := range obj.(*v1alpha1.RayWorkerGroupList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested rayWorkerGroups.
+func (c *FakeRayWorkerGroups) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(rayworkergroupsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a rayWorkerGroup and creates it.  Returns the server's representation of the rayWorkerGroup, and an error, if there is any.
+func (c *FakeRayWorkerGroups) Create(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.CreateOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(rayworkergroupsResource, c.ns, rayWorkerGroup), &v1alpha1.RayWorkerGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RayWorkerGroup), err
+}
+
+// Update takes the representation of a rayWorkerGroup and updates it. Returns the server's representation of the rayWorkerGroup, and an error, if there is any.
+func (c *FakeRayWorkerGroups) Update(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.UpdateOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(rayworkergroupsResource, c.ns, rayWorkerGroup), &v1alpha1.RayWorkerGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RayWorkerGroup), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRayWorkerGroups) UpdateStatus(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.UpdateOptions) (*v1alpha1.RayWorkerGroup, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(rayworkergroupsResource, "status", c.ns, rayWorkerGroup), &v1alpha1.RayWorkerGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RayWorkerGroup), err
+}
+
+// Delete takes name of the rayWorkerGroup and deletes it. Returns an error if one occurs.
+func (c *FakeRayWorkerGroups) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(rayworkergroupsResource, c.ns, name, opts), &v1alpha1.RayWorkerGroup{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRayWorkerGroups) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(rayworkergroupsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.RayWorkerGroupList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched rayWorkerGroup.
+func (c *FakeRayWorkerGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RayWorkerGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(rayworkergroupsResource, c.ns, name, pt, data, subresources...), &v1alpha1.RayWorkerGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RayWorkerGroup), err
+}
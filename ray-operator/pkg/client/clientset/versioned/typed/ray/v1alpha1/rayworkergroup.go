@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	scheme "github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RayWorkerGroupsGetter has a method to return a RayWorkerGroupInterface.
+// A group's client should implement this interface.
+type RayWorkerGroupsGetter interface {
+	RayWorkerGroups(namespace string) RayWorkerGroupInterface
+}
+
+// RayWorkerGroupInterface has methods to work with RayWorkerGroup resources.
+type RayWorkerGroupInterface interface {
+	Create(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.CreateOptions) (*v1alpha1.RayWorkerGroup, error)
+	Update(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.UpdateOptions) (*v1alpha1.RayWorkerGroup, error)
+	UpdateStatus(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.UpdateOptions) (*v1alpha1.RayWorkerGroup, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.RayWorkerGroup, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RayWorkerGroupList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RayWorkerGroup, err error)
+	RayWorkerGroupExpansion
+}
+
+// rayWorkerGroups implements RayWorkerGroupInterface
+type rayWorkerGroups struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRayWorkerGroups returns a RayWorkerGroups
+func newRayWorkerGroups(c *RayV1alpha1Client, namespace string) *rayWorkerGroups {
+	return &rayWorkerGroups{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the rayWorkerGroup, and returns the corresponding rayWorkerGroup object, and an error if there is any.
+func (c *rayWorkerGroups) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	result = &v1alpha1.RayWorkerGroup{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RayWorkerGroups that match those selectors.
+func (c *rayWorkerGroups) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.RayWorkerGroupList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.RayWorkerGroupList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested rayWorkerGroups.
+func (c *rayWorkerGroups) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a rayWorkerGroup and creates it.  Returns the server's representation of the rayWorkerGroup, and an error, if there is any.
+func (c *rayWorkerGroups) Create(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.CreateOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	result = &v1alpha1.RayWorkerGroup{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(rayWorkerGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a rayWorkerGroup and updates it. Returns the server's representation of the rayWorkerGroup, and an error, if there is any.
+func (c *rayWorkerGroups) Update(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.UpdateOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	result = &v1alpha1.RayWorkerGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		Name(rayWorkerGroup.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(rayWorkerGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *rayWorkerGroups) UpdateStatus(ctx context.Context, rayWorkerGroup *v1alpha1.RayWorkerGroup, opts v1.UpdateOptions) (result *v1alpha1.RayWorkerGroup, err error) {
+	result = &v1alpha1.RayWorkerGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		Name(rayWorkerGroup.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(rayWorkerGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the rayWorkerGroup and deletes it. Returns an error if one occurs.
+func (c *rayWorkerGroups) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *rayWorkerGroups) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched rayWorkerGroup.
+func (c *rayWorkerGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RayWorkerGroup, err error) {
+	result = &v1alpha1.RayWorkerGroup{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("rayworkergroups").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	rayv1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	versioned "github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/ray-project/kuberay/ray-operator/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/ray-project/kuberay/ray-operator/pkg/client/listers/ray/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// RayWorkerGroupInformer provides access to a shared informer and lister for
+// RayWorkerGroups.
+type RayWorkerGroupInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.RayWorkerGroupLister
+}
+
+type rayWorkerGroupInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewRayWorkerGroupInformer constructs a new informer for RayWorkerGroup type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewRayWorkerGroupInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredRayWorkerGroupInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredRayWorkerGroupInformer constructs a new informer for RayWorkerGroup type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredRayWorkerGroupInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.RayV1alpha1().RayWorkerGroups(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.RayV1alpha1().RayWorkerGroups(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&rayv1alpha1.RayWorkerGroup{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *rayWorkerGroupInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredRayWorkerGroupInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *rayWorkerGroupInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&rayv1alpha1.RayWorkerGroup{}, f.defaultInformer)
+}
+
+func (f *rayWorkerGroupInformer) Lister() v1alpha1.RayWorkerGroupLister {
+	return v1alpha1.NewRayWorkerGroupLister(f.Informer().GetIndexer())
+}
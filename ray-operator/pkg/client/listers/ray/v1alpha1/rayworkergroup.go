@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RayWorkerGroupLister helps list RayWorkerGroups.
+// All objects returned here must be treated as read-only.
+type RayWorkerGroupLister interface {
+	// List lists all RayWorkerGroups in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.RayWorkerGroup, err error)
+	// RayWorkerGroups returns an object that can list and get RayWorkerGroups.
+	RayWorkerGroups(namespace string) RayWorkerGroupNamespaceLister
+	RayWorkerGroupListerExpansion
+}
+
+// rayWorkerGroupLister implements the RayWorkerGroupLister interface.
+type rayWorkerGroupLister struct {
+	indexer cache.Indexer
+}
+
+// NewRayWorkerGroupLister returns a new RayWorkerGroupLister.
+func NewRayWorkerGroupLister(indexer cache.Indexer) RayWorkerGroupLister {
+	return &rayWorkerGroupLister{indexer: indexer}
+}
+
+// List lists all RayWorkerGroups in the indexer.
+func (s *rayWorkerGroupLister) List(selector labels.Selector) (ret []*v1alpha1.RayWorkerGroup, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.RayWorkerGroup))
+	})
+	return ret, err
+}
+
+// RayWorkerGroups returns an object that can list and get RayWorkerGroups.
+func (s *rayWorkerGroupLister) RayWorkerGroups(namespace string) RayWorkerGroupNamespaceLister {
+	return rayWorkerGroupNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RayWorkerGroupNamespaceLister helps list and get RayWorkerGroups.
+// All objects returned here must be treated as read-only.
+type RayWorkerGroupNamespaceLister interface {
+	// List lists all RayWorkerGroups in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.RayWorkerGroup, err error)
+	// Get retrieves the RayWorkerGroup from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.RayWorkerGroup, error)
+	RayWorkerGroupNamespaceListerExpansion
+}
+
+// rayWorkerGroupNamespaceLister implements the RayWorkerGroupNamespaceLister
+// interface.
+type rayWorkerGroupNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RayWorkerGroups in the indexer for a given namespace.
+func (s rayWorkerGroupNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.RayWorkerGroup, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.RayWorkerGroup))
+	})
+	return ret, err
+}
+
+// Get retrieves the RayWorkerGroup from the indexer for a given namespace and name.
+func (s rayWorkerGroupNamespaceLister) Get(name string) (*v1alpha1.RayWorkerGroup, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("rayworkergroup"), name)
+	}
+	return obj.(*v1alpha1.RayWorkerGroup), nil
+}
@@ -0,0 +1,89 @@
+// Package dashboardauth implements the authenticating reverse proxy that KubeRay injects into the head Pod
+// to protect the Ray dashboard and the dashboard agent.
+package dashboardauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrMissingToken is returned when a request does not carry a bearer token.
+	ErrMissingToken = errors.New("missing bearer token")
+	// ErrInvalidToken is returned when no authenticator accepts the bearer token of a request.
+	ErrInvalidToken = errors.New("invalid bearer token")
+)
+
+// Authenticator verifies the bearer token of a request.
+type Authenticator interface {
+	// Authenticate returns nil if the token is valid.
+	Authenticate(ctx context.Context, token string) error
+}
+
+// TokenAuthenticator accepts a single static token.
+type TokenAuthenticator struct {
+	token []byte
+}
+
+// NewTokenAuthenticator returns a TokenAuthenticator for the given token. The token must not be empty.
+func NewTokenAuthenticator(token string) (*TokenAuthenticator, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errors.New("the token must not be empty")
+	}
+	return &TokenAuthenticator{token: []byte(token)}, nil
+}
+
+// NewTokenAuthenticatorFromFile reads the token from a file, e.g. a mounted Secret.
+func NewTokenAuthenticatorFromFile(path string) (*TokenAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token file: %w", err)
+	}
+	return NewTokenAuthenticator(string(data))
+}
+
+// Authenticate compares the token in constant time.
+func (a *TokenAuthenticator) Authenticate(_ context.Context, token string) error {
+	if subtle.ConstantTimeCompare([]byte(token), a.token) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// BearerToken extracts the token from the Authorization header of a request.
+func BearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", ErrMissingToken
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", ErrMissingToken
+	}
+	return token, nil
+}
+
+// authenticate tries the authenticators in order and returns nil as soon as one of them accepts the token.
+func authenticate(ctx context.Context, token string, authenticators []Authenticator) error {
+	var errs []string
+	for _, a := range authenticators {
+		err := a.Authenticate(ctx, token)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrInvalidToken) {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidToken, strings.Join(errs, "; "))
+	}
+	return ErrInvalidToken
+}
@@ -0,0 +1,96 @@
+package dashboardauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// Minimum interval between two attempts to fetch the discovery document of the issuer.
+const oidcMinDiscoveryInterval = time.Minute
+
+// OIDCAuthenticator verifies ID tokens signed by an OIDC issuer with go-oidc. The signing keys are discovered through
+// the issuer's discovery document, and refreshed by go-oidc when a token is signed by an unknown key. Only the
+// signing algorithms advertised by the issuer are accepted, and go-jose checks that they match the type of the key.
+type OIDCAuthenticator struct {
+	issuer   string
+	audience string
+	client   *http.Client
+	now      func() time.Time
+
+	mu                sync.Mutex
+	verifier          *oidc.IDTokenVerifier
+	lastDiscoveryTime time.Time
+	lastDiscoveryErr  error
+}
+
+// NewOIDCAuthenticator returns an OIDCAuthenticator for the issuer. The discovery document is fetched lazily.
+func NewOIDCAuthenticator(issuerURL string, audience string, client *http.Client) (*OIDCAuthenticator, error) {
+	if issuerURL == "" || audience == "" {
+		return nil, errors.New("both the OIDC issuer URL and audience are required")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCAuthenticator{
+		issuer:   issuerURL,
+		audience: audience,
+		client:   client,
+		now:      time.Now,
+	}, nil
+}
+
+// Authenticate verifies the signature and the iss, aud, exp, and nbf claims of the token.
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, token string) error {
+	if strings.Count(token, ".") != 2 {
+		// Not a JWT, e.g. the static token.
+		return ErrInvalidToken
+	}
+	verifier, err := a.getVerifier(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := verifier.Verify(ctx, token); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getVerifier returns the verifier of the issuer, fetching the discovery document on first use. The lock is not held
+// during the fetch, so that a slow issuer does not block the requests. A failed fetch is not retried before
+// oidcMinDiscoveryInterval.
+func (a *OIDCAuthenticator) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	a.mu.Lock()
+	if a.verifier != nil {
+		defer a.mu.Unlock()
+		return a.verifier, nil
+	}
+	if a.lastDiscoveryErr != nil && a.now().Sub(a.lastDiscoveryTime) < oidcMinDiscoveryInterval {
+		defer a.mu.Unlock()
+		return nil, a.lastDiscoveryErr
+	}
+	a.mu.Unlock()
+
+	// go-oidc only keeps the HTTP client of the context for the later fetches of the signing keys.
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, a.client), a.issuer)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.verifier != nil {
+		return a.verifier, nil
+	}
+	a.lastDiscoveryTime = a.now()
+	if err != nil {
+		a.lastDiscoveryErr = fmt.Errorf("failed to fetch the OIDC discovery document: %w", err)
+		return nil, a.lastDiscoveryErr
+	}
+	a.lastDiscoveryErr = nil
+	a.verifier = provider.Verifier(&oidc.Config{ClientID: a.audience, Now: a.now})
+	return a.verifier, nil
+}
@@ -0,0 +1,171 @@
+package dashboardauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testIssuer struct {
+	server     *httptest.Server
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	jwksServed int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                issuer.server.URL,
+			"jwks_uri":                              issuer.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256", "ES256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		issuer.jwksServed++
+		b64 := base64.RawURLEncoding.EncodeToString
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{"kty": "RSA", "kid": "rsa", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+				{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+			},
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	return issuer
+}
+
+func (i *testIssuer) sign(t *testing.T, alg string, kid string, claims map[string]interface{}) string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, i.rsaKey, crypto.SHA256, digest[:])
+		assert.Nil(t, err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, i.ecKey, digest[:])
+		assert.Nil(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case "HS256":
+		// Signed with the public RSA key as the HMAC secret, as in an algorithm confusion attack.
+		mac := hmac.New(sha256.New, i.rsaKey.N.Bytes())
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	}
+	return signingInput + "." + b64(signature)
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	issuer := newTestIssuer(t)
+	defer issuer.server.Close()
+
+	authenticator, err := NewOIDCAuthenticator(issuer.server.URL, "ray", nil)
+	assert.Nil(t, err)
+	now := time.Now()
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss": issuer.server.URL,
+			"aud": "ray",
+			"exp": now.Add(time.Hour).Unix(),
+		}
+	}
+
+	ctx := context.Background()
+	assert.Nil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "rsa", validClaims())))
+	assert.Nil(t, authenticator.Authenticate(ctx, issuer.sign(t, "ES256", "ec", validClaims())))
+
+	claims := validClaims()
+	claims["aud"] = []string{"other", "ray"}
+	assert.Nil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "rsa", claims)))
+
+	claims = validClaims()
+	claims["aud"] = "other"
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "rsa", claims)))
+
+	claims = validClaims()
+	claims["iss"] = "https://evil.example.com"
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "rsa", claims)))
+
+	claims = validClaims()
+	claims["exp"] = now.Add(-time.Hour).Unix()
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "rsa", claims)))
+
+	claims = validClaims()
+	claims["nbf"] = now.Add(time.Hour).Unix()
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "rsa", claims)))
+
+	// A token signed by the EC key but labeled with the RSA key ID is rejected.
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "ES256", "rsa", validClaims())))
+	// The algorithm is bound to the key: an HMAC signature keyed with the public RSA key is rejected.
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "HS256", "rsa", validClaims())))
+
+	// A tampered payload invalidates the signature.
+	token := strings.Split(issuer.sign(t, "RS256", "rsa", validClaims()), ".")
+	claims = validClaims()
+	claims["exp"] = now.Add(24 * time.Hour).Unix()
+	tampered := strings.Split(issuer.sign(t, "RS256", "rsa", claims), ".")
+	assert.NotNil(t, authenticator.Authenticate(ctx, token[0]+"."+tampered[1]+"."+token[2]))
+
+	// Non-JWT tokens are rejected without contacting the issuer.
+	assert.ErrorIs(t, authenticator.Authenticate(ctx, "static-token"), ErrInvalidToken)
+
+	// Tokens signed by an unknown key are rejected after the keys are refreshed.
+	served := issuer.jwksServed
+	assert.NotNil(t, authenticator.Authenticate(ctx, issuer.sign(t, "RS256", "unknown", validClaims())))
+	assert.Equal(t, served+1, issuer.jwksServed)
+
+	_, err = NewOIDCAuthenticator(issuer.server.URL, "", nil)
+	assert.NotNil(t, err)
+}
+
+func TestOIDCAuthenticatorDiscoveryFailure(t *testing.T) {
+	discoveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		discoveries++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	authenticator, err := NewOIDCAuthenticator(server.URL, "ray", nil)
+	assert.Nil(t, err)
+	now := time.Now()
+	authenticator.now = func() time.Time { return now }
+	token := "header.payload.signature"
+
+	// A failed discovery is not retried before the minimum interval.
+	ctx := context.Background()
+	assert.NotNil(t, authenticator.Authenticate(ctx, token))
+	assert.NotNil(t, authenticator.Authenticate(ctx, token))
+	assert.Equal(t, 1, discoveries)
+	now = now.Add(oidcMinDiscoveryInterval)
+	assert.NotNil(t, authenticator.Authenticate(ctx, token))
+	assert.Equal(t, 2, discoveries)
+}
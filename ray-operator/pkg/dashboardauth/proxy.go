@@ -0,0 +1,38 @@
+package dashboardauth
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/go-logr/logr"
+)
+
+// NewProxy returns a handler that forwards the requests authenticated by any of the authenticators to upstream.
+// Other requests are rejected with 401 Unauthorized. The Authorization header is not forwarded.
+func NewProxy(upstream *url.URL, authenticators []Authenticator, log logr.Logger) http.Handler {
+	reverseProxy := httputil.NewSingleHostReverseProxy(upstream)
+	director := reverseProxy.Director
+	reverseProxy.Director = func(r *http.Request) {
+		director(r)
+		r.Header.Del("Authorization")
+	}
+	reverseProxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		log.Error(err, "Failed to forward request", "upstream", upstream.String(), "path", r.URL.Path)
+		w.WriteHeader(http.StatusBadGateway)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := BearerToken(r)
+		if err == nil {
+			err = authenticate(r.Context(), token, authenticators)
+		}
+		if err != nil {
+			log.Info("Rejected unauthenticated request", "remote", r.RemoteAddr, "method", r.Method, "path", r.URL.Path, "reason", err.Error())
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ray-dashboard"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		reverseProxy.ServeHTTP(w, r)
+	})
+}
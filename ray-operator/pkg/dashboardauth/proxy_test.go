@@ -0,0 +1,57 @@
+package dashboardauth
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The token must not be forwarded to the dashboard.
+		assert.Empty(t, r.Header.Get("Authorization"))
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer upstream.Close()
+	upstreamURL, _ := url.Parse(upstream.URL)
+
+	tokenAuthenticator, err := NewTokenAuthenticator("secret-token\n")
+	assert.Nil(t, err)
+	proxy := httptest.NewServer(NewProxy(upstreamURL, []Authenticator{tokenAuthenticator}, logr.Discard()))
+	defer proxy.Close()
+
+	tests := map[string]struct {
+		authorization string
+		expectedCode  int
+	}{
+		"no token":             {"", http.StatusUnauthorized},
+		"wrong scheme":         {"Basic secret-token", http.StatusUnauthorized},
+		"wrong token":          {"Bearer wrong-token", http.StatusUnauthorized},
+		"valid token":          {"Bearer secret-token", http.StatusOK},
+		"case-insensitive":     {"bearer secret-token", http.StatusOK},
+		"token prefix refused": {"Bearer secret", http.StatusUnauthorized},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, proxy.URL+"/api/jobs/", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			assert.Nil(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			if tc.expectedCode == http.StatusUnauthorized {
+				assert.NotEmpty(t, resp.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+
+	_, err = NewTokenAuthenticator(" ")
+	assert.NotNil(t, err)
+}